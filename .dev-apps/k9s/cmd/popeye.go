@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/view"
+	"github.com/spf13/cobra"
+)
+
+func popeyeCmd() *cobra.Command {
+	var (
+		output              string
+		allNS, diff, noSave bool
+		minScore            int
+	)
+
+	command := cobra.Command{
+		Use:          "popeye",
+		Short:        "Sanitize the cluster and export the report",
+		Long:         "Sanitize the cluster and export the report as " + strings.Join(dao.PopeyeExportFormats(), ", "),
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPopeye(output, allNS, !noSave, diff, minScore)
+		},
+	}
+
+	command.Flags().StringVarP(&output, "output", "o", dao.PopeyeExportJSON, "Output format. One of "+strings.Join(dao.PopeyeExportFormats(), "|"))
+	command.Flags().BoolVarP(&allNS, "all-namespaces", "A", false, "Sanitize resources across all namespaces")
+	command.Flags().BoolVar(&noSave, "no-save", false, "Do not add this run to the runs history")
+	command.Flags().BoolVar(&diff, "diff", false, "Print new and resolved issues since the previous run to stderr")
+	command.Flags().IntVar(&minScore, "min-score", 0, "Exit with an error when the score is below this value")
+
+	return &command
+}
+
+func runPopeye(output string, allNS, save, diff bool, minScore int) error {
+	if err := checkFormat(output, dao.PopeyeExportFormats()); err != nil {
+		return err
+	}
+	cfg, ns, done, err := headlessSetup(allNS)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	run, prev, err := view.RunPopeye(cfg, ns, output, save, os.Stdout)
+	if err != nil {
+		return fmt.Errorf("popeye run failed: %w", err)
+	}
+	if diff {
+		if prev == nil {
+			fmt.Fprintln(os.Stderr, "No previous run to compare with")
+		} else {
+			fmt.Fprint(os.Stderr, dao.DiffPopeye(prev, run).String())
+		}
+	}
+	if run.Report.Score < minScore {
+		return fmt.Errorf("popeye score %d is below %d", run.Report.Score, minScore)
+	}
+
+	return nil
+}
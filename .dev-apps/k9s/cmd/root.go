@@ -13,6 +13,7 @@ import (
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"os"
 	"runtime/debug"
+	"strings"
 )
 
 const (
@@ -29,17 +30,18 @@ var (
 	k8sFlags              *genericclioptions.ConfigFlags
 
 	rootCmd = &cobra.Command{
-		Use:   appName,
-		Short: shortAppDesc,
-		Long:  longAppDesc,
-		Run:   run,
+		Use:           appName,
+		Short:         shortAppDesc,
+		Long:          longAppDesc,
+		Run:           run,
+		SilenceErrors: true,
 	}
 
 	out = colorable.NewColorableStdout()
 )
 
 func init() {
-	rootCmd.AddCommand(versionCmd(), infoCmd())
+	rootCmd.AddCommand(versionCmd(), infoCmd(), xrayCmd(), popeyeCmd())
 	initK9sFlags()
 	initK8sFlags()
 }
@@ -47,7 +49,8 @@ func init() {
 // Execute root command.
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		log.Panic().Err(err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
 }
 
@@ -135,6 +138,39 @@ func loadConfiguration() *config.Config {
 	return k9sCfg
 }
 
+// headlessSetup directs logs to the k9s log file and loads the configuration
+// for commands running without the UI. It returns the configuration, the
+// namespace in scope and a function closing the log file.
+func headlessSetup(allNS bool) (*config.Config, string, func(), error) {
+	config.EnsurePath(*k9sFlags.LogFile, config.DefaultDirMod)
+	file, err := os.OpenFile(*k9sFlags.LogFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, config.DefaultFileMod)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	log.Logger = log.Output(zerolog.ConsoleWriter{Out: file})
+	zerolog.SetGlobalLevel(parseLevel(*k9sFlags.LogLevel))
+
+	cfg := loadConfiguration()
+	ns := cfg.ActiveNamespace()
+	switch {
+	case allNS:
+		ns = client.NamespaceAll
+	case k8sFlags.Namespace != nil && *k8sFlags.Namespace != "":
+		ns = *k8sFlags.Namespace
+	}
+
+	return cfg, ns, func() { _ = file.Close() }, nil
+}
+
+// checkFormat ensures an output format is one of the supported formats.
+func checkFormat(format string, formats []string) error {
+	if !config.InList(formats, format) {
+		return fmt.Errorf("invalid output format %q. Must be one of %s", format, strings.Join(formats, "|"))
+	}
+
+	return nil
+}
+
 func parseLevel(level string) zerolog.Level {
 	switch level {
 	case "trace":
@@ -226,42 +262,42 @@ func initK9sFlags() {
 func initK8sFlags() {
 	k8sFlags = genericclioptions.NewConfigFlags(client.UsePersistentConfig)
 
-	rootCmd.Flags().StringVar(
+	rootCmd.PersistentFlags().StringVar(
 		k8sFlags.KubeConfig,
 		"kubeconfig",
 		"",
 		"Path to the kubeconfig file to use for CLI requests",
 	)
 
-	rootCmd.Flags().StringVar(
+	rootCmd.PersistentFlags().StringVar(
 		k8sFlags.Timeout,
 		"request-timeout",
 		"",
 		"The length of time to wait before giving up on a single server request",
 	)
 
-	rootCmd.Flags().StringVar(
+	rootCmd.PersistentFlags().StringVar(
 		k8sFlags.Context,
 		"context",
 		"",
 		"The name of the kubeconfig context to use",
 	)
 
-	rootCmd.Flags().StringVar(
+	rootCmd.PersistentFlags().StringVar(
 		k8sFlags.ClusterName,
 		"cluster",
 		"",
 		"The name of the kubeconfig cluster to use",
 	)
 
-	rootCmd.Flags().StringVar(
+	rootCmd.PersistentFlags().StringVar(
 		k8sFlags.AuthInfoName,
 		"user",
 		"",
 		"The name of the kubeconfig user to use",
 	)
 
-	rootCmd.Flags().StringVarP(
+	rootCmd.PersistentFlags().StringVarP(
 		k8sFlags.Namespace,
 		"namespace",
 		"n",
@@ -274,14 +310,14 @@ func initK8sFlags() {
 }
 
 func initAsFlags() {
-	rootCmd.Flags().StringVar(
+	rootCmd.PersistentFlags().StringVar(
 		k8sFlags.Impersonate,
 		"as",
 		"",
 		"Username to impersonate for the operation",
 	)
 
-	rootCmd.Flags().StringArrayVar(
+	rootCmd.PersistentFlags().StringArrayVar(
 		k8sFlags.ImpersonateGroup,
 		"as-group",
 		[]string{},
@@ -290,35 +326,35 @@ func initAsFlags() {
 }
 
 func initCertFlags() {
-	rootCmd.Flags().BoolVar(
+	rootCmd.PersistentFlags().BoolVar(
 		k8sFlags.Insecure,
 		"insecure-skip-tls-verify",
 		false,
 		"If true, the server's caCertFile will not be checked for validity",
 	)
 
-	rootCmd.Flags().StringVar(
+	rootCmd.PersistentFlags().StringVar(
 		k8sFlags.CAFile,
 		"certificate-authority",
 		"",
 		"Path to a cert file for the certificate authority",
 	)
 
-	rootCmd.Flags().StringVar(
+	rootCmd.PersistentFlags().StringVar(
 		k8sFlags.KeyFile,
 		"client-key",
 		"",
 		"Path to a client key file for TLS",
 	)
 
-	rootCmd.Flags().StringVar(
+	rootCmd.PersistentFlags().StringVar(
 		k8sFlags.CertFile,
 		"client-certificate",
 		"",
 		"Path to a client certificate file for TLS",
 	)
 
-	rootCmd.Flags().StringVar(
+	rootCmd.PersistentFlags().StringVar(
 		k8sFlags.BearerToken,
 		"token",
 		"",
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const executeArgsEnv = "K9S_TEST_EXECUTE_ARGS"
+
+func TestExecuteExitStatus(t *testing.T) {
+	if args := os.Getenv(executeArgsEnv); args != "" {
+		os.Args = append([]string{appName}, strings.Fields(args)...)
+		Execute()
+		return
+	}
+
+	uu := map[string]struct {
+		args string
+		err  string
+	}{
+		"popeye-format": {
+			args: "popeye -o bogus",
+			err:  `Error: invalid output format "bogus"`,
+		},
+		"xray-format": {
+			args: "xray po -o bogus",
+			err:  `Error: invalid output format "bogus"`,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			cmd := exec.Command(os.Args[0], "-test.run=^TestExecuteExitStatus$")
+			cmd.Env = append(os.Environ(), executeArgsEnv+"="+u.args)
+			out, err := cmd.CombinedOutput()
+
+			var exitErr *exec.ExitError
+			assert.True(t, errors.As(err, &exitErr))
+			assert.Equal(t, 1, exitErr.ExitCode())
+			assert.Contains(t, string(out), u.err)
+			assert.NotContains(t, string(out), "Usage:")
+		})
+	}
+}
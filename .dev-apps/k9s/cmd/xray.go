@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/derailed/k9s/internal/view"
+	"github.com/derailed/k9s/internal/xray"
+	"github.com/spf13/cobra"
+)
+
+func xrayCmd() *cobra.Command {
+	var (
+		output, filter string
+		allNS          bool
+	)
+
+	command := cobra.Command{
+		Use:          "xray RESOURCE",
+		Short:        "Export a resource xray tree",
+		Long:         "Export a resource xray tree as " + strings.Join(xray.ExportFormats(), ", "),
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return exportXray(args[0], output, filter, allNS)
+		},
+	}
+
+	command.Flags().StringVarP(&output, "output", "o", xray.ExportJSON, "Output format. One of "+strings.Join(xray.ExportFormats(), "|"))
+	command.Flags().StringVarP(&filter, "filter", "f", "", "Filters the tree using the xray view syntax (regex, !inverse, -f fuzzy, -l labels)")
+	command.Flags().BoolVarP(&allNS, "all-namespaces", "A", false, "Export resources across all namespaces")
+
+	return &command
+}
+
+func exportXray(res, output, filter string, allNS bool) error {
+	if err := checkFormat(output, xray.ExportFormats()); err != nil {
+		return err
+	}
+	cfg, ns, done, err := headlessSetup(allNS)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	if err := view.ExportXray(cfg, res, ns, filter, output, os.Stdout); err != nil {
+		return fmt.Errorf("xray export failed: %w", err)
+	}
+
+	return nil
+}
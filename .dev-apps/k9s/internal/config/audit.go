@@ -0,0 +1,48 @@
+package config
+
+import (
+	"path/filepath"
+)
+
+const (
+	// DefaultAuditMaxSizeMB tracks the default audit log size prior to rotation.
+	DefaultAuditMaxSizeMB = 10
+	// DefaultAuditMaxBackups tracks the default number of rotated audit logs.
+	DefaultAuditMaxBackups = 3
+)
+
+// K9sAuditLog represents the location of the mutating actions audit log.
+var K9sAuditLog = filepath.Join(K9sHome(), "audit.log")
+
+// Audit tracks mutating actions audit log options.
+type Audit struct {
+	Enable     bool   `yaml:"enable"`
+	MaxSizeMB  int    `yaml:"maxSizeMB"`
+	MaxBackups int    `yaml:"maxBackups"`
+	Socket     string `yaml:"socket,omitempty"`
+	Webhook    string `yaml:"webhook,omitempty"`
+}
+
+// NewAudit returns a new instance.
+func NewAudit() *Audit {
+	return &Audit{
+		Enable:     true,
+		MaxSizeMB:  DefaultAuditMaxSizeMB,
+		MaxBackups: DefaultAuditMaxBackups,
+	}
+}
+
+// MaxSize returns the audit log max size in bytes.
+func (a *Audit) MaxSize() int64 {
+	return int64(a.MaxSizeMB) * 1024 * 1024
+}
+
+// Validate checks the audit settings and make sure we're cool. If not use defaults.
+func (a *Audit) Validate() {
+	if a.MaxSizeMB <= 0 {
+		a.MaxSizeMB = DefaultAuditMaxSizeMB
+	}
+	if a.MaxBackups < 0 {
+		a.MaxBackups = DefaultAuditMaxBackups
+	}
+}
@@ -0,0 +1,35 @@
+package config
+
+const (
+	// DefaultCertsWarnDays tracks the default days to expiry before a certificate is flagged.
+	DefaultCertsWarnDays = 30
+	// DefaultCertsCriticalDays tracks the default days to expiry before a certificate is deemed critical.
+	DefaultCertsCriticalDays = 7
+)
+
+// Certs tracks certificates expiry thresholds.
+type Certs struct {
+	WarnDays     int `yaml:"warnDays"`
+	CriticalDays int `yaml:"criticalDays"`
+}
+
+// NewCerts returns a new instance.
+func NewCerts() *Certs {
+	return &Certs{
+		WarnDays:     DefaultCertsWarnDays,
+		CriticalDays: DefaultCertsCriticalDays,
+	}
+}
+
+// Validate checks the certificates thresholds and make sure we're cool. If not use defaults.
+func (c *Certs) Validate() {
+	if c.WarnDays <= 0 {
+		c.WarnDays = DefaultCertsWarnDays
+	}
+	if c.CriticalDays <= 0 {
+		c.CriticalDays = DefaultCertsCriticalDays
+	}
+	if c.CriticalDays > c.WarnDays {
+		c.CriticalDays = c.WarnDays
+	}
+}
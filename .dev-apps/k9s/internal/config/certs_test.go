@@ -0,0 +1,39 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCertsValidate(t *testing.T) {
+	uu := map[string]struct {
+		d, e *config.Certs
+	}{
+		"default": {
+			d: config.NewCerts(),
+			e: config.NewCerts(),
+		},
+		"empty": {
+			d: &config.Certs{},
+			e: config.NewCerts(),
+		},
+		"custom": {
+			d: &config.Certs{WarnDays: 60, CriticalDays: 14},
+			e: &config.Certs{WarnDays: 60, CriticalDays: 14},
+		},
+		"critical-over-warn": {
+			d: &config.Certs{WarnDays: 5, CriticalDays: 10},
+			e: &config.Certs{WarnDays: 5, CriticalDays: 5},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			u.d.Validate()
+			assert.Equal(t, u.e, u.d)
+		})
+	}
+}
@@ -7,11 +7,12 @@ const DefaultPFAddress = "localhost"
 
 // Cluster tracks K9s cluster configuration.
 type Cluster struct {
-	Namespace          *Namespace    `yaml:"namespace"`
-	View               *View         `yaml:"view"`
-	FeatureGates       *FeatureGates `yaml:"featureGates"`
-	ShellPod           *ShellPod     `yaml:"shellPod"`
-	PortForwardAddress string        `yaml:"portForwardAddress"`
+	Namespace          *Namespace      `yaml:"namespace"`
+	View               *View           `yaml:"view"`
+	FeatureGates       *FeatureGates   `yaml:"featureGates"`
+	ShellPod           *ShellPod       `yaml:"shellPod"`
+	DebugContainer     *DebugContainer `yaml:"debugContainer"`
+	PortForwardAddress string          `yaml:"portForwardAddress"`
 }
 
 // NewCluster creates a new cluster configuration.
@@ -22,6 +23,7 @@ func NewCluster() *Cluster {
 		PortForwardAddress: DefaultPFAddress,
 		FeatureGates:       NewFeatureGates(),
 		ShellPod:           NewShellPod(),
+		DebugContainer:     NewDebugContainer(),
 	}
 }
 
@@ -51,4 +53,9 @@ func (c *Cluster) Validate(conn client.Connection, ks KubeSettings) {
 		c.ShellPod = NewShellPod()
 	}
 	c.ShellPod.Validate(conn, ks)
+
+	if c.DebugContainer == nil {
+		c.DebugContainer = NewDebugContainer()
+	}
+	c.DebugContainer.Validate()
 }
@@ -21,6 +21,10 @@ var (
 	K9sConfigFile = filepath.Join(K9sHome(), "config.yml")
 	// K9sDefaultScreenDumpDir represents a default directory where K9s screen dumps will be persisted.
 	K9sDefaultScreenDumpDir = filepath.Join(os.TempDir(), fmt.Sprintf("k9s-screens-%s", MustK9sUser()))
+	// K9sPopeyeDir represents the location of the sanitizer runs history.
+	K9sPopeyeDir = filepath.Join(K9sHome(), "popeye")
+	// K9sHelmValuesDir represents the location of the local charts edited values.
+	K9sHelmValuesDir = filepath.Join(K9sHome(), "helm")
 )
 
 type (
@@ -128,6 +132,11 @@ func (c *Config) CurrentCluster() *Cluster {
 	return nil
 }
 
+// IsActionAllowed checks if an action is allowed in a given namespace on the current context.
+func (c *Config) IsActionAllowed(action, ns string) bool {
+	return c.K9s.Policies[c.K9s.CurrentContext].Allows(action, ns)
+}
+
 // ActiveNamespace returns the active namespace in the current cluster.
 func (c *Config) ActiveNamespace() string {
 	if c.K9s.Clusters == nil {
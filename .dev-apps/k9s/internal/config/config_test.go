@@ -125,6 +125,24 @@ func TestConfigCurrentCluster(t *testing.T) {
 	assert.Equal(t, "ctx", cfg.CurrentCluster().View.Active)
 }
 
+func TestConfigIsActionAllowed(t *testing.T) {
+	cfg := config.NewConfig(NewMockKubeSettings())
+	cfg.K9s.CurrentCluster = "prod"
+	cfg.K9s.Policies = map[string]*config.ActionPolicy{
+		"prod-staff": {
+			Default: config.PolicyDeny,
+			Rules:   []config.ActionRule{{Actions: []string{config.ActionPortForward}}},
+		},
+	}
+
+	cfg.K9s.CurrentContext = "prod-admin"
+	assert.True(t, cfg.IsActionAllowed(config.ActionDelete, "default"))
+
+	cfg.K9s.CurrentContext = "prod-staff"
+	assert.False(t, cfg.IsActionAllowed(config.ActionDelete, "default"))
+	assert.True(t, cfg.IsActionAllowed(config.ActionPortForward, "default"))
+}
+
 func TestConfigActiveNamespace(t *testing.T) {
 	mk := NewMockKubeSettings()
 	cfg := config.NewConfig(mk)
@@ -293,6 +311,27 @@ var expectedConfig = `k9s:
     fullScreenLogs: false
     textWrap: false
     showTime: false
+  journal:
+    maxEntries: 200
+    maxAgeDays: 14
+    secrets: false
+  audit:
+    enable: true
+    maxSizeMB: 10
+    maxBackups: 3
+  rightsizing:
+    windowMins: 60
+    minSamples: 5
+    headroom: 20
+  trends:
+    enable: false
+    sampleSeconds: 60
+    retentionDays: 7
+    compactAfterHours: 24
+    compactMinutes: 15
+  certs:
+    warnDays: 30
+    criticalDays: 7
   currentContext: blee
   currentCluster: blee
   clusters:
@@ -306,6 +345,7 @@ var expectedConfig = `k9s:
         active: po
       featureGates:
         nodeShell: false
+        recordSessions: false
       shellPod:
         image: busybox:1.35.0
         command: []
@@ -314,6 +354,9 @@ var expectedConfig = `k9s:
         limits:
           cpu: 100m
           memory: 100Mi
+      debugContainer:
+        image: busybox:1.35.0
+        targetProcess: true
       portForwardAddress: localhost
     fred:
       namespace:
@@ -329,6 +372,7 @@ var expectedConfig = `k9s:
         active: po
       featureGates:
         nodeShell: false
+        recordSessions: false
       shellPod:
         image: busybox:1.35.0
         command: []
@@ -337,6 +381,9 @@ var expectedConfig = `k9s:
         limits:
           cpu: 100m
           memory: 100Mi
+      debugContainer:
+        image: busybox:1.35.0
+        targetProcess: true
       portForwardAddress: localhost
     minikube:
       namespace:
@@ -352,6 +399,7 @@ var expectedConfig = `k9s:
         active: ctx
       featureGates:
         nodeShell: false
+        recordSessions: false
       shellPod:
         image: busybox:1.35.0
         command: []
@@ -360,6 +408,9 @@ var expectedConfig = `k9s:
         limits:
           cpu: 100m
           memory: 100Mi
+      debugContainer:
+        image: busybox:1.35.0
+        targetProcess: true
       portForwardAddress: localhost
   thresholds:
     cpu:
@@ -388,6 +439,27 @@ var resetConfig = `k9s:
     fullScreenLogs: false
     textWrap: false
     showTime: false
+  journal:
+    maxEntries: 200
+    maxAgeDays: 14
+    secrets: false
+  audit:
+    enable: true
+    maxSizeMB: 10
+    maxBackups: 3
+  rightsizing:
+    windowMins: 60
+    minSamples: 5
+    headroom: 20
+  trends:
+    enable: false
+    sampleSeconds: 60
+    retentionDays: 7
+    compactAfterHours: 24
+    compactMinutes: 15
+  certs:
+    warnDays: 30
+    criticalDays: 7
   currentContext: blee
   currentCluster: blee
   clusters:
@@ -401,6 +473,7 @@ var resetConfig = `k9s:
         active: po
       featureGates:
         nodeShell: false
+        recordSessions: false
       shellPod:
         image: busybox:1.35.0
         command: []
@@ -409,6 +482,9 @@ var resetConfig = `k9s:
         limits:
           cpu: 100m
           memory: 100Mi
+      debugContainer:
+        image: busybox:1.35.0
+        targetProcess: true
       portForwardAddress: localhost
   thresholds:
     cpu:
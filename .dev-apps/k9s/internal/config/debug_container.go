@@ -0,0 +1,24 @@
+package config
+
+// DebugContainer represents k9s ephemeral debug container configuration.
+type DebugContainer struct {
+	Image         string   `yaml:"image"`
+	Command       []string `yaml:"command,omitempty"`
+	Args          []string `yaml:"args,omitempty"`
+	TargetProcess bool     `yaml:"targetProcess"`
+}
+
+// NewDebugContainer returns a new instance.
+func NewDebugContainer() *DebugContainer {
+	return &DebugContainer{
+		Image:         defaultDockerShellImage,
+		TargetProcess: true,
+	}
+}
+
+// Validate validates the configuration.
+func (d *DebugContainer) Validate() {
+	if d.Image == "" {
+		d.Image = defaultDockerShellImage
+	}
+}
@@ -2,7 +2,8 @@ package config
 
 // FeatureGates represents K9s opt-in features.
 type FeatureGates struct {
-	NodeShell bool `yaml:"nodeShell"`
+	NodeShell      bool `yaml:"nodeShell"`
+	RecordSessions bool `yaml:"recordSessions"`
 }
 
 // NewFeatureGates returns a new feature gate.
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"path/filepath"
+	"time"
+)
+
+const (
+	// DefaultJournalMaxEntries tracks the default number of journal entries per context.
+	DefaultJournalMaxEntries = 200
+	// DefaultJournalMaxAgeDays tracks the default journal entries retention.
+	DefaultJournalMaxAgeDays = 14
+)
+
+// K9sJournalDir represents the location of the resources undo journal.
+var K9sJournalDir = filepath.Join(K9sHome(), "journal")
+
+// Journal tracks resources undo journal options.
+type Journal struct {
+	MaxEntries int  `yaml:"maxEntries"`
+	MaxAgeDays int  `yaml:"maxAgeDays"`
+	Secrets    bool `yaml:"secrets"`
+}
+
+// NewJournal returns a new instance.
+func NewJournal() *Journal {
+	return &Journal{
+		MaxEntries: DefaultJournalMaxEntries,
+		MaxAgeDays: DefaultJournalMaxAgeDays,
+	}
+}
+
+// MaxAge returns the journal entries retention duration.
+func (j *Journal) MaxAge() time.Duration {
+	return time.Duration(j.MaxAgeDays) * 24 * time.Hour
+}
+
+// Validate checks the journal settings and make sure we're cool. If not use defaults.
+func (j *Journal) Validate() {
+	if j.MaxEntries <= 0 {
+		j.MaxEntries = DefaultJournalMaxEntries
+	}
+	if j.MaxAgeDays <= 0 {
+		j.MaxAgeDays = DefaultJournalMaxAgeDays
+	}
+}
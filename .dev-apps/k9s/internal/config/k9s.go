@@ -1,6 +1,8 @@
 package config
 
 import (
+	"path/filepath"
+
 	"github.com/derailed/k9s/internal/client"
 )
 
@@ -11,21 +13,27 @@ const (
 
 // K9s tracks K9s configuration options.
 type K9s struct {
-	RefreshRate         int                 `yaml:"refreshRate"`
-	MaxConnRetry        int                 `yaml:"maxConnRetry"`
-	EnableMouse         bool                `yaml:"enableMouse"`
-	Headless            bool                `yaml:"headless"`
-	Logoless            bool                `yaml:"logoless"`
-	Crumbsless          bool                `yaml:"crumbsless"`
-	ReadOnly            bool                `yaml:"readOnly"`
-	NoExitOnCtrlC       bool                `yaml:"noExitOnCtrlC"`
-	NoIcons             bool                `yaml:"noIcons"`
-	Logger              *Logger             `yaml:"logger"`
-	CurrentContext      string              `yaml:"currentContext"`
-	CurrentCluster      string              `yaml:"currentCluster"`
-	Clusters            map[string]*Cluster `yaml:"clusters,omitempty"`
-	Thresholds          Threshold           `yaml:"thresholds"`
-	ScreenDumpDir       string              `yaml:"screenDumpDir"`
+	RefreshRate         int                      `yaml:"refreshRate"`
+	MaxConnRetry        int                      `yaml:"maxConnRetry"`
+	EnableMouse         bool                     `yaml:"enableMouse"`
+	Headless            bool                     `yaml:"headless"`
+	Logoless            bool                     `yaml:"logoless"`
+	Crumbsless          bool                     `yaml:"crumbsless"`
+	ReadOnly            bool                     `yaml:"readOnly"`
+	NoExitOnCtrlC       bool                     `yaml:"noExitOnCtrlC"`
+	NoIcons             bool                     `yaml:"noIcons"`
+	Logger              *Logger                  `yaml:"logger"`
+	Journal             *Journal                 `yaml:"journal"`
+	Audit               *Audit                   `yaml:"audit"`
+	Rightsizing         *Rightsizing             `yaml:"rightsizing"`
+	Trends              *Trends                  `yaml:"trends"`
+	Certs               *Certs                   `yaml:"certs"`
+	CurrentContext      string                   `yaml:"currentContext"`
+	CurrentCluster      string                   `yaml:"currentCluster"`
+	Clusters            map[string]*Cluster      `yaml:"clusters,omitempty"`
+	Policies            map[string]*ActionPolicy `yaml:"policies,omitempty"`
+	Thresholds          Threshold                `yaml:"thresholds"`
+	ScreenDumpDir       string                   `yaml:"screenDumpDir"`
 	manualRefreshRate   int
 	manualHeadless      *bool
 	manualLogoless      *bool
@@ -41,6 +49,11 @@ func NewK9s() *K9s {
 		RefreshRate:   defaultRefreshRate,
 		MaxConnRetry:  defaultMaxConnRetry,
 		Logger:        NewLogger(),
+		Journal:       NewJournal(),
+		Audit:         NewAudit(),
+		Rightsizing:   NewRightsizing(),
+		Trends:        NewTrends(),
+		Certs:         NewCerts(),
 		Clusters:      make(map[string]*Cluster),
 		Thresholds:    NewThreshold(),
 		ScreenDumpDir: K9sDefaultScreenDumpDir,
@@ -169,6 +182,31 @@ func (k *K9s) ActiveCluster() *Cluster {
 	return k.Clusters[k.CurrentCluster]
 }
 
+// GetJournalDir returns the undo journal directory for the current context.
+func (k *K9s) GetJournalDir() string {
+	return filepath.Join(K9sJournalDir, k.CurrentContextDir())
+}
+
+// GetTrendsDir returns the health trends directory for the current context.
+func (k *K9s) GetTrendsDir() string {
+	return filepath.Join(K9sTrendsDir, k.CurrentContextDir())
+}
+
+// GetPopeyeDir returns the sanitizer runs directory for the current context
+// and a given namespace.
+func (k *K9s) GetPopeyeDir(ns string) string {
+	if client.IsAllNamespaces(ns) {
+		ns = "all"
+	}
+
+	return filepath.Join(K9sPopeyeDir, k.CurrentContextDir(), SanitizeFilename(ns))
+}
+
+// GetHelmValuesFile returns a release edited values file for the current context.
+func (k *K9s) GetHelmValuesFile(ns, rel string) string {
+	return filepath.Join(K9sHelmValuesDir, k.CurrentContextDir(), SanitizeFilename(ns), SanitizeFilename(rel)+".yaml")
+}
+
 func (k *K9s) GetScreenDumpDir() string {
 	screenDumpDir := k.ScreenDumpDir
 	if k.manualScreenDumpDir != nil && *k.manualScreenDumpDir != "" {
@@ -224,6 +262,31 @@ func (k *K9s) Validate(c client.Connection, ks KubeSettings) {
 	} else {
 		k.Logger.Validate(c, ks)
 	}
+	if k.Journal == nil {
+		k.Journal = NewJournal()
+	}
+	k.Journal.Validate()
+	if k.Audit == nil {
+		k.Audit = NewAudit()
+	}
+	k.Audit.Validate()
+	if k.Rightsizing == nil {
+		k.Rightsizing = NewRightsizing()
+	}
+	k.Rightsizing.Validate()
+	if k.Trends == nil {
+		k.Trends = NewTrends()
+	}
+	k.Trends.Validate()
+	if k.Certs == nil {
+		k.Certs = NewCerts()
+	}
+	k.Certs.Validate()
+	for _, p := range k.Policies {
+		if p != nil {
+			p.Validate()
+		}
+	}
 	if k.Thresholds == nil {
 		k.Thresholds = NewThreshold()
 	}
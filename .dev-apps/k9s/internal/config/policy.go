@@ -0,0 +1,113 @@
+package config
+
+import (
+	"path/filepath"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// ActionShell tracks shell, attach and node shell actions.
+	ActionShell = "shell"
+	// ActionPortForward tracks port-forward actions.
+	ActionPortForward = "portForward"
+	// ActionDelete tracks delete and kill actions.
+	ActionDelete = "delete"
+	// ActionEdit tracks edit, labels, set image, restart and rollback actions.
+	ActionEdit = "edit"
+	// ActionScale tracks scale actions.
+	ActionScale = "scale"
+	// ActionDrain tracks drain, cordon and uncordon actions.
+	ActionDrain = "drain"
+	// ActionPlugins tracks plugin actions.
+	ActionPlugins = "plugins"
+
+	// PolicyAllow allows actions by default.
+	PolicyAllow = "allow"
+	// PolicyDeny denies actions by default.
+	PolicyDeny = "deny"
+
+	policyAllActions = "*"
+)
+
+// ActionRule represents an action policy rule.
+type ActionRule struct {
+	Actions    []string `yaml:"actions"`
+	Namespaces []string `yaml:"namespaces,omitempty"`
+	Deny       bool     `yaml:"deny"`
+}
+
+func (r ActionRule) covers(action string) bool {
+	for _, a := range r.Actions {
+		if a == action || a == policyAllActions {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r ActionRule) matches(ns string) bool {
+	if len(r.Namespaces) == 0 {
+		return true
+	}
+	for _, pattern := range r.Namespaces {
+		ok, err := filepath.Match(pattern, ns)
+		if err != nil {
+			log.Warn().Err(err).Msgf("Invalid policy namespace pattern %q", pattern)
+			continue
+		}
+		if ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ActionPolicy represents a set of actions allowed on a context.
+type ActionPolicy struct {
+	Default string       `yaml:"default"`
+	Rules   []ActionRule `yaml:"rules"`
+}
+
+// NewActionPolicy returns a new policy allowing all actions.
+func NewActionPolicy() *ActionPolicy {
+	return &ActionPolicy{Default: PolicyAllow}
+}
+
+// Validate checks the policy and make sure we're cool. If not use defaults.
+func (p *ActionPolicy) Validate() {
+	if p.Default != PolicyDeny {
+		p.Default = PolicyAllow
+	}
+}
+
+// Allows checks if an action is allowed in a given namespace. Rules are evaluated
+// in order and the last matching rule wins. When no specific namespace is given,
+// namespace bound rules can only deny an action.
+func (p *ActionPolicy) Allows(action, ns string) bool {
+	if p == nil {
+		return true
+	}
+
+	allowed, anyNS := p.Default != PolicyDeny, client.IsClusterWide(ns)
+	for _, r := range p.Rules {
+		if !r.covers(action) {
+			continue
+		}
+		if anyNS && len(r.Namespaces) > 0 {
+			if r.Deny {
+				allowed = false
+			}
+			continue
+		}
+		if !r.matches(ns) {
+			continue
+		}
+		allowed = !r.Deny
+	}
+
+	return allowed
+}
@@ -0,0 +1,147 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestActionPolicyValidate(t *testing.T) {
+	uu := map[string]struct {
+		d, e string
+	}{
+		"blank": {e: config.PolicyAllow},
+		"deny":  {d: config.PolicyDeny, e: config.PolicyDeny},
+		"toast": {d: "toast", e: config.PolicyAllow},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			p := config.ActionPolicy{Default: u.d}
+			p.Validate()
+			assert.Equal(t, u.e, p.Default)
+		})
+	}
+}
+
+func TestActionPolicyAllows(t *testing.T) {
+	prod := config.ActionPolicy{
+		Default: config.PolicyDeny,
+		Rules: []config.ActionRule{
+			{Actions: []string{config.ActionPortForward}},
+			{Actions: []string{"*"}, Namespaces: []string{"dev-*", "sandbox"}},
+			{Actions: []string{config.ActionDelete}, Namespaces: []string{"dev-db"}, Deny: true},
+		},
+	}
+	staging := config.ActionPolicy{
+		Default: config.PolicyAllow,
+		Rules: []config.ActionRule{
+			{Actions: []string{config.ActionShell, config.ActionDrain}, Deny: true},
+			{Actions: []string{config.ActionShell}, Namespaces: []string{"dev"}},
+			{Actions: []string{config.ActionDelete}, Namespaces: []string{"kube-system"}, Deny: true},
+		},
+	}
+
+	uu := map[string]struct {
+		p          *config.ActionPolicy
+		action, ns string
+		e          bool
+	}{
+		"none": {
+			action: config.ActionDelete,
+			ns:     "default",
+			e:      true,
+		},
+		"default-deny": {
+			p:      &prod,
+			action: config.ActionShell,
+			ns:     "default",
+		},
+		"allow-rule": {
+			p:      &prod,
+			action: config.ActionPortForward,
+			ns:     "default",
+			e:      true,
+		},
+		"ns-glob": {
+			p:      &prod,
+			action: config.ActionShell,
+			ns:     "dev-fred",
+			e:      true,
+		},
+		"ns-exact": {
+			p:      &prod,
+			action: config.ActionScale,
+			ns:     "sandbox",
+			e:      true,
+		},
+		"last-wins": {
+			p:      &prod,
+			action: config.ActionDelete,
+			ns:     "dev-db",
+		},
+		"all-ns-no-grant": {
+			p:      &prod,
+			action: config.ActionShell,
+			ns:     "",
+		},
+		"default-allow": {
+			p:      &staging,
+			action: config.ActionScale,
+			ns:     "default",
+			e:      true,
+		},
+		"deny-rule": {
+			p:      &staging,
+			action: config.ActionDrain,
+			ns:     "-",
+		},
+		"ns-grant": {
+			p:      &staging,
+			action: config.ActionShell,
+			ns:     "dev",
+			e:      true,
+		},
+		"all-ns-deny": {
+			p:      &staging,
+			action: config.ActionDelete,
+			ns:     "all",
+		},
+		"other-ns": {
+			p:      &staging,
+			action: config.ActionDelete,
+			ns:     "default",
+			e:      true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, u.p.Allows(u.action, u.ns))
+		})
+	}
+}
+
+func TestActionPolicyLoad(t *testing.T) {
+	raw := `
+default: deny
+rules:
+- actions: [portForward, shell]
+  namespaces: [dev-*]
+- actions: [delete]
+  deny: true
+`
+	var p config.ActionPolicy
+	assert.Nil(t, yaml.Unmarshal([]byte(raw), &p))
+	p.Validate()
+
+	assert.Equal(t, config.PolicyDeny, p.Default)
+	assert.Equal(t, 2, len(p.Rules))
+	assert.True(t, p.Allows(config.ActionShell, "dev-1"))
+	assert.False(t, p.Allows(config.ActionShell, "prod"))
+	assert.False(t, p.Allows(config.ActionDelete, "dev-1"))
+}
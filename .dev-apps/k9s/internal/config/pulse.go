@@ -0,0 +1,163 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+)
+
+// K9sPulseConfigFile represents the location for the pulses dashboard configuration.
+var K9sPulseConfigFile = filepath.Join(K9sHome(), "pulse.yml")
+
+const (
+	// PulseGauge renders a tile as a gauge.
+	PulseGauge PulseChart = "gauge"
+
+	// PulseSparkLine renders a tile as a sparkline.
+	PulseSparkLine PulseChart = "sparkline"
+
+	// PulseDotMatrix renders a tile as a dot matrix.
+	PulseDotMatrix PulseChart = "dotmatrix"
+
+	// PulseCPU represents the cluster cpu metrics tile.
+	PulseCPU = "cpu"
+
+	// PulseMEM represents the cluster memory metrics tile.
+	PulseMEM = "mem"
+
+	defaultPulseColumns = 8
+	defaultPulseSpan    = 2
+)
+
+// PulseChart represents a pulse tile chart type.
+type PulseChart string
+
+// PulseRule represents a resource health rule.
+// A rule either checks a status condition or a JSONPath expression.
+type PulseRule struct {
+	Condition string `yaml:"condition,omitempty"`
+	Status    string `yaml:"status,omitempty"`
+	JSONPath  string `yaml:"jsonPath,omitempty"`
+	Op        string `yaml:"op,omitempty"`
+	Value     string `yaml:"value,omitempty"`
+}
+
+// PulseTile represents a pulses dashboard tile.
+type PulseTile struct {
+	GVR      string      `yaml:"gvr"`
+	Title    string      `yaml:"title,omitempty"`
+	Chart    PulseChart  `yaml:"chart"`
+	RowSpan  int         `yaml:"rowSpan,omitempty"`
+	ColSpan  int         `yaml:"colSpan,omitempty"`
+	Healthy  []PulseRule `yaml:"healthy,omitempty"`
+	Warning  []PulseRule `yaml:"warning,omitempty"`
+	Critical []PulseRule `yaml:"critical,omitempty"`
+}
+
+// HasRules checks if the tile defines custom health rules.
+func (t PulseTile) HasRules() bool {
+	return len(t.Healthy)+len(t.Warning)+len(t.Critical) > 0
+}
+
+// IsMetrics checks if the tile tracks cluster metrics.
+func (t PulseTile) IsMetrics() bool {
+	return t.GVR == PulseCPU || t.GVR == PulseMEM
+}
+
+// Pulse represents the pulses dashboard configuration.
+type Pulse struct {
+	Columns int         `yaml:"columns"`
+	Tiles   []PulseTile `yaml:"tiles"`
+}
+
+type pulseConfig struct {
+	Pulse Pulse `yaml:"pulse"`
+}
+
+// NewPulse returns the default pulses dashboard.
+func NewPulse() *Pulse {
+	return &Pulse{
+		Columns: defaultPulseColumns,
+		Tiles: []PulseTile{
+			{GVR: "apps/v1/deployments", Chart: PulseGauge, RowSpan: 2, ColSpan: 2},
+			{GVR: "apps/v1/replicasets", Chart: PulseGauge, RowSpan: 2, ColSpan: 2},
+			{GVR: "apps/v1/statefulsets", Chart: PulseGauge, RowSpan: 2, ColSpan: 2},
+			{GVR: "apps/v1/daemonsets", Chart: PulseGauge, RowSpan: 2, ColSpan: 2},
+			{GVR: "v1/pods", Chart: PulseSparkLine, RowSpan: 3, ColSpan: 2},
+			{GVR: "v1/events", Chart: PulseSparkLine, RowSpan: 3, ColSpan: 2},
+			{GVR: "batch/v1/jobs", Chart: PulseSparkLine, RowSpan: 3, ColSpan: 2},
+			{GVR: "v1/persistentvolumes", Chart: PulseSparkLine, RowSpan: 3, ColSpan: 2},
+			{GVR: PulseCPU, Chart: PulseSparkLine, RowSpan: 2, ColSpan: 4},
+			{GVR: PulseMEM, Chart: PulseSparkLine, RowSpan: 2, ColSpan: 4},
+		},
+	}
+}
+
+// Load loads a pulses dashboard configuration. Tiles replace the default ones.
+func (p *Pulse) Load(path string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var in pulseConfig
+	if err := yaml.Unmarshal(raw, &in); err != nil {
+		return err
+	}
+	if in.Pulse.Columns > 0 {
+		p.Columns = in.Pulse.Columns
+	}
+	if len(in.Pulse.Tiles) > 0 {
+		p.Tiles = in.Pulse.Tiles
+	}
+	p.Validate()
+
+	return nil
+}
+
+// Validate checks the configuration and sets defaults.
+func (p *Pulse) Validate() {
+	if p.Columns <= 0 {
+		p.Columns = defaultPulseColumns
+	}
+
+	tt, seen := make([]PulseTile, 0, len(p.Tiles)), make(map[string]struct{}, len(p.Tiles))
+	for _, t := range p.Tiles {
+		if _, ok := seen[t.GVR]; ok || t.GVR == "" {
+			continue
+		}
+		seen[t.GVR] = struct{}{}
+		switch t.Chart {
+		case PulseGauge, PulseSparkLine, PulseDotMatrix:
+		default:
+			t.Chart = PulseSparkLine
+		}
+		if t.RowSpan <= 0 {
+			t.RowSpan = defaultPulseSpan
+		}
+		if t.ColSpan <= 0 {
+			t.ColSpan = defaultPulseSpan
+		}
+		if t.ColSpan > p.Columns {
+			t.ColSpan = p.Columns
+		}
+		for i := range t.Healthy {
+			t.Healthy[i].validate()
+		}
+		for i := range t.Warning {
+			t.Warning[i].validate()
+		}
+		for i := range t.Critical {
+			t.Critical[i].validate()
+		}
+		tt = append(tt, t)
+	}
+	p.Tiles = tt
+}
+
+func (r *PulseRule) validate() {
+	if r.Condition != "" && r.Status == "" {
+		r.Status = "True"
+	}
+}
@@ -0,0 +1,44 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPulseDefaults(t *testing.T) {
+	p := config.NewPulse()
+
+	assert.Equal(t, 8, p.Columns)
+	assert.Equal(t, 10, len(p.Tiles))
+	assert.True(t, p.Tiles[8].IsMetrics())
+	assert.False(t, p.Tiles[0].HasRules())
+}
+
+func TestPulseLoad(t *testing.T) {
+	p := config.NewPulse()
+	assert.Nil(t, p.Load("testdata/pulse.yml"))
+
+	assert.Equal(t, 6, p.Columns)
+	assert.Equal(t, 3, len(p.Tiles))
+
+	assert.Equal(t, config.PulseTile{GVR: "v1/pods", Chart: config.PulseDotMatrix, RowSpan: 2, ColSpan: 3}, p.Tiles[0])
+
+	certs := p.Tiles[1]
+	assert.Equal(t, "Certs", certs.Title)
+	assert.Equal(t, config.PulseGauge, certs.Chart)
+	assert.True(t, certs.HasRules())
+	assert.Equal(t, []config.PulseRule{{Condition: "Ready", Status: "False"}}, certs.Critical)
+	assert.Equal(t, []config.PulseRule{{JSONPath: "{.status.renewalTime}"}}, certs.Warning)
+
+	dp := p.Tiles[2]
+	assert.Equal(t, config.PulseSparkLine, dp.Chart)
+	assert.Equal(t, 6, dp.ColSpan)
+}
+
+func TestPulseLoadMissing(t *testing.T) {
+	p := config.NewPulse()
+	assert.NotNil(t, p.Load("testdata/missing.yml"))
+	assert.Equal(t, config.NewPulse(), p)
+}
@@ -0,0 +1,46 @@
+package config
+
+import "time"
+
+const (
+	// DefaultRightsizingWindowMins tracks the default usage sampling window.
+	DefaultRightsizingWindowMins = 60
+	// DefaultRightsizingMinSamples tracks the default number of samples needed for a recommendation.
+	DefaultRightsizingMinSamples = 5
+	// DefaultRightsizingHeadroom tracks the default headroom percentage added to observed usage.
+	DefaultRightsizingHeadroom = 20
+)
+
+// Rightsizing tracks requests/limits recommendations options.
+type Rightsizing struct {
+	WindowMins int `yaml:"windowMins"`
+	MinSamples int `yaml:"minSamples"`
+	Headroom   int `yaml:"headroom"`
+}
+
+// NewRightsizing returns a new instance.
+func NewRightsizing() *Rightsizing {
+	return &Rightsizing{
+		WindowMins: DefaultRightsizingWindowMins,
+		MinSamples: DefaultRightsizingMinSamples,
+		Headroom:   DefaultRightsizingHeadroom,
+	}
+}
+
+// Window returns the usage sampling window.
+func (r *Rightsizing) Window() time.Duration {
+	return time.Duration(r.WindowMins) * time.Minute
+}
+
+// Validate checks the rightsizing settings and make sure we're cool. If not use defaults.
+func (r *Rightsizing) Validate() {
+	if r.WindowMins <= 0 {
+		r.WindowMins = DefaultRightsizingWindowMins
+	}
+	if r.MinSamples <= 0 {
+		r.MinSamples = DefaultRightsizingMinSamples
+	}
+	if r.Headroom < 0 {
+		r.Headroom = DefaultRightsizingHeadroom
+	}
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"path/filepath"
+	"time"
+)
+
+const (
+	// DefaultTrendsSampleSeconds tracks the default interval between health samples.
+	DefaultTrendsSampleSeconds = 60
+	// DefaultTrendsRetentionDays tracks the default health samples retention.
+	DefaultTrendsRetentionDays = 7
+	// DefaultTrendsCompactAfterHours tracks the default age after which samples are compacted.
+	DefaultTrendsCompactAfterHours = 24
+	// DefaultTrendsCompactMinutes tracks the default compacted samples resolution.
+	DefaultTrendsCompactMinutes = 15
+)
+
+// K9sTrendsDir represents the location of the health trends history.
+var K9sTrendsDir = filepath.Join(K9sHome(), "trends")
+
+// Trends tracks health trends history options.
+type Trends struct {
+	Enable            bool `yaml:"enable"`
+	SampleSeconds     int  `yaml:"sampleSeconds"`
+	RetentionDays     int  `yaml:"retentionDays"`
+	CompactAfterHours int  `yaml:"compactAfterHours"`
+	CompactMinutes    int  `yaml:"compactMinutes"`
+}
+
+// NewTrends returns a new instance.
+func NewTrends() *Trends {
+	return &Trends{
+		SampleSeconds:     DefaultTrendsSampleSeconds,
+		RetentionDays:     DefaultTrendsRetentionDays,
+		CompactAfterHours: DefaultTrendsCompactAfterHours,
+		CompactMinutes:    DefaultTrendsCompactMinutes,
+	}
+}
+
+// SampleInterval returns the interval between health samples.
+func (t *Trends) SampleInterval() time.Duration {
+	return time.Duration(t.SampleSeconds) * time.Second
+}
+
+// Retention returns the health samples retention duration.
+func (t *Trends) Retention() time.Duration {
+	return time.Duration(t.RetentionDays) * 24 * time.Hour
+}
+
+// CompactAfter returns the age after which samples are compacted.
+func (t *Trends) CompactAfter() time.Duration {
+	return time.Duration(t.CompactAfterHours) * time.Hour
+}
+
+// CompactResolution returns the compacted samples resolution.
+func (t *Trends) CompactResolution() time.Duration {
+	return time.Duration(t.CompactMinutes) * time.Minute
+}
+
+// Validate checks the trends settings and make sure we're cool. If not use defaults.
+func (t *Trends) Validate() {
+	if t.SampleSeconds <= 0 {
+		t.SampleSeconds = DefaultTrendsSampleSeconds
+	}
+	if t.RetentionDays <= 0 {
+		t.RetentionDays = DefaultTrendsRetentionDays
+	}
+	if t.CompactAfterHours <= 0 {
+		t.CompactAfterHours = DefaultTrendsCompactAfterHours
+	}
+	if t.CompactMinutes <= 0 {
+		t.CompactMinutes = DefaultTrendsCompactMinutes
+	}
+}
@@ -0,0 +1,134 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v2"
+)
+
+// K9sXrayConfigFile represents the location for the xray relationship rules.
+var K9sXrayConfigFile = filepath.Join(K9sHome(), "xray.yml")
+
+const defaultControllerNamespace = "ingress-nginx"
+
+// XrayRelation describes how to locate resources related to a parent resource.
+// A relation uses exactly one strategy:
+//   - owned matches resources whose owner references point to the parent.
+//   - selector is a JSONPath to a parent label selector ie {.spec.selector}.
+//   - jsonPath lists the related resources names from the parent.
+//   - refJsonPath lists the parent names from the related resources.
+type XrayRelation struct {
+	GVR         string `yaml:"gvr"`
+	Owned       bool   `yaml:"owned,omitempty"`
+	Selector    string `yaml:"selector,omitempty"`
+	JSONPath    string `yaml:"jsonPath,omitempty"`
+	RefJSONPath string `yaml:"refJsonPath,omitempty"`
+}
+
+func (r XrayRelation) strategies() int {
+	var n int
+	if r.Owned {
+		n++
+	}
+	for _, s := range []string{r.Selector, r.JSONPath, r.RefJSONPath} {
+		if s != "" {
+			n++
+		}
+	}
+
+	return n
+}
+
+// XrayRule represents a resource relationships.
+type XrayRule struct {
+	GVR       string         `yaml:"gvr"`
+	Relations []XrayRelation `yaml:"relations"`
+}
+
+// XrayRules represents the xray relationship rules.
+type XrayRules struct {
+	// ControllerNamespace the ingress controller namespace used to check
+	// network policies along ingress paths.
+	ControllerNamespace string     `yaml:"controllerNamespace,omitempty"`
+	Rules               []XrayRule `yaml:"rules"`
+}
+
+type xrayConfig struct {
+	Xray XrayRules `yaml:"xray"`
+}
+
+// NewXrayRules returns a new xray rules configuration.
+func NewXrayRules() *XrayRules {
+	return &XrayRules{}
+}
+
+// Load loads the xray rules from a given file.
+func (x *XrayRules) Load(path string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var in xrayConfig
+	if err := yaml.Unmarshal(raw, &in); err != nil {
+		return err
+	}
+	x.ControllerNamespace, x.Rules = in.Xray.ControllerNamespace, in.Xray.Rules
+	x.Validate()
+
+	return nil
+}
+
+// Validate drops invalid relations and merges rules targeting the same resource.
+func (x *XrayRules) Validate() {
+	rr, index := make([]XrayRule, 0, len(x.Rules)), make(map[string]int, len(x.Rules))
+	for _, r := range x.Rules {
+		if r.GVR == "" {
+			continue
+		}
+		i, ok := index[r.GVR]
+		if !ok {
+			i = len(rr)
+			index[r.GVR] = i
+			rr = append(rr, XrayRule{GVR: r.GVR})
+		}
+		for _, rel := range r.Relations {
+			if rel.GVR == "" || rel.strategies() != 1 {
+				log.Warn().Msgf("Skipping invalid xray relation %s -> %s. Exactly one of owned, selector, jsonPath or refJsonPath is required", r.GVR, rel.GVR)
+				continue
+			}
+			rr[i].Relations = append(rr[i].Relations, rel)
+		}
+	}
+	x.Rules = rr
+}
+
+// IngressControllerNamespace returns the ingress controller namespace.
+func (x *XrayRules) IngressControllerNamespace() string {
+	if x == nil || x.ControllerNamespace == "" {
+		return defaultControllerNamespace
+	}
+
+	return x.ControllerNamespace
+}
+
+// HasRules checks if relationship rules are defined for a given resource.
+func (x *XrayRules) HasRules(gvr string) bool {
+	return len(x.RelationsFor(gvr)) > 0
+}
+
+// RelationsFor returns a resource relations.
+func (x *XrayRules) RelationsFor(gvr string) []XrayRelation {
+	if x == nil {
+		return nil
+	}
+	for _, r := range x.Rules {
+		if r.GVR == gvr {
+			return r.Relations
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestXrayRulesLoad(t *testing.T) {
+	x := config.NewXrayRules()
+	assert.Nil(t, x.Load("testdata/xray.yml"))
+
+	assert.Equal(t, "traefik", x.IngressControllerNamespace())
+	assert.Equal(t, 3, len(x.Rules))
+	assert.Equal(t, []config.XrayRelation{{GVR: "v1/secrets", JSONPath: "{.spec.secretName}"}}, x.RelationsFor("cert-manager.io/v1/certificates"))
+	assert.Equal(t, []config.XrayRelation{{GVR: "networking.k8s.io/v1/ingresses", RefJSONPath: "{.spec.tls[*].secretName}"}}, x.RelationsFor("v1/secrets"))
+	assert.Equal(t, []config.XrayRelation{
+		{GVR: "apps/v1/deployments", Owned: true},
+		{GVR: "v1/services", Selector: "{.spec.selector}"},
+	}, x.RelationsFor("example.com/v1/apps"))
+	assert.True(t, x.HasRules("v1/secrets"))
+	assert.False(t, x.HasRules("v1/pods"))
+}
+
+func TestXrayRulesLoadMissing(t *testing.T) {
+	x := config.NewXrayRules()
+	assert.NotNil(t, x.Load("testdata/missing.yml"))
+	assert.Equal(t, 0, len(x.Rules))
+	assert.Equal(t, "ingress-nginx", x.IngressControllerNamespace())
+
+	var none *config.XrayRules
+	assert.False(t, none.HasRules("v1/pods"))
+}
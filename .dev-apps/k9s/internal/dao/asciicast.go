@@ -0,0 +1,136 @@
+package dao
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// CastExt tracks asciicast recordings file extension.
+const CastExt = ".cast"
+
+// CastHeader represents an asciicast v2 header.
+type CastHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// CastRecorder records terminal output as asciicast v2 events.
+type CastRecorder struct {
+	w     io.Writer
+	start time.Time
+	mx    sync.Mutex
+	err   error
+}
+
+// NewCastRecorder writes an asciicast header and returns a new recorder.
+func NewCastRecorder(w io.Writer, h CastHeader) (*CastRecorder, error) {
+	r := CastRecorder{w: w, start: time.Now()}
+	h.Version = 2
+	if h.Timestamp == 0 {
+		h.Timestamp = r.start.Unix()
+	}
+	raw, err := json.Marshal(h)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fmt.Fprintf(w, "%s\n", raw); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+// Write records an output event.
+func (r *CastRecorder) Write(b []byte) (int, error) {
+	r.event("o", string(b))
+
+	return len(b), nil
+}
+
+// Resize records a terminal resize event.
+func (r *CastRecorder) Resize(width, height int) {
+	r.event("r", fmt.Sprintf("%dx%d", width, height))
+}
+
+// Err returns the first recording error if any.
+func (r *CastRecorder) Err() error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	return r.err
+}
+
+func (r *CastRecorder) event(kind, data string) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	if r.err != nil {
+		return
+	}
+	raw, err := json.Marshal([]interface{}{time.Since(r.start).Seconds(), kind, data})
+	if err != nil {
+		r.err = err
+		return
+	}
+	_, r.err = fmt.Fprintf(r.w, "%s\n", raw)
+}
+
+// PlayCast replays an asciicast v2 recording output events.
+// Pauses longer than maxIdle are shortened to maxIdle.
+func PlayCast(ctx context.Context, r io.Reader, w io.Writer, maxIdle time.Duration) (*CastHeader, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("empty recording")
+	}
+	var h CastHeader
+	if err := json.Unmarshal(scanner.Bytes(), &h); err != nil {
+		return nil, fmt.Errorf("invalid recording header: %w", err)
+	}
+	if h.Version != 2 {
+		return nil, fmt.Errorf("unsupported asciicast version %d", h.Version)
+	}
+
+	var last float64
+	for scanner.Scan() {
+		var evt []interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &evt); err != nil {
+			return &h, fmt.Errorf("invalid recording event: %w", err)
+		}
+		if len(evt) != 3 {
+			continue
+		}
+		at, _ := evt[0].(float64)
+		kind, _ := evt[1].(string)
+		data, _ := evt[2].(string)
+		delay := time.Duration((at - last) * float64(time.Second))
+		if maxIdle > 0 && delay > maxIdle {
+			delay = maxIdle
+		}
+		last = at
+		if delay > 0 {
+			select {
+			case <-ctx.Done():
+				return &h, ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+		if kind != "o" {
+			continue
+		}
+		if _, err := io.WriteString(w, data); err != nil {
+			return &h, err
+		}
+	}
+
+	return &h, scanner.Err()
+}
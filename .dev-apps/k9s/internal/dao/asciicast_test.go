@@ -0,0 +1,95 @@
+package dao
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCastRecorder(t *testing.T) {
+	var buff bytes.Buffer
+	r, err := NewCastRecorder(&buff, CastHeader{Width: 120, Height: 40, Title: "shell fred/blee:c1"})
+	assert.NoError(t, err)
+
+	n, err := r.Write([]byte("hello\r\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, 7, n)
+	r.Resize(100, 30)
+	assert.NoError(t, r.Err())
+
+	ll := strings.Split(strings.TrimSpace(buff.String()), "\n")
+	assert.Equal(t, 3, len(ll))
+
+	var h CastHeader
+	assert.NoError(t, json.Unmarshal([]byte(ll[0]), &h))
+	assert.Equal(t, 2, h.Version)
+	assert.Equal(t, 120, h.Width)
+	assert.Equal(t, 40, h.Height)
+	assert.Equal(t, "shell fred/blee:c1", h.Title)
+	assert.True(t, h.Timestamp > 0)
+
+	var evt []interface{}
+	assert.NoError(t, json.Unmarshal([]byte(ll[1]), &evt))
+	assert.Equal(t, "o", evt[1])
+	assert.Equal(t, "hello\r\n", evt[2])
+	assert.NoError(t, json.Unmarshal([]byte(ll[2]), &evt))
+	assert.Equal(t, "r", evt[1])
+	assert.Equal(t, "100x30", evt[2])
+}
+
+func TestPlayCast(t *testing.T) {
+	uu := map[string]struct {
+		cast string
+		out  string
+		err  bool
+	}{
+		"happy": {
+			cast: `{"version":2,"width":80,"height":24}
+[0.1,"o","hello "]
+[0.2,"r","100x30"]
+[10.5,"o","world"]
+`,
+			out: "hello world",
+		},
+		"empty": {
+			err: true,
+		},
+		"bad-version": {
+			cast: `{"version":1,"width":80,"height":24}`,
+			err:  true,
+		},
+		"bad-event": {
+			cast: `{"version":2,"width":80,"height":24}
+[0.1,"o",`,
+			err: true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			var out bytes.Buffer
+			_, err := PlayCast(context.Background(), strings.NewReader(u.cast), &out, time.Millisecond)
+			assert.Equal(t, u.err, err != nil)
+			assert.Equal(t, u.out, out.String())
+		})
+	}
+}
+
+func TestPlayCastCanceled(t *testing.T) {
+	cast := `{"version":2,"width":80,"height":24}
+[100,"o","never"]
+`
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var out bytes.Buffer
+	_, err := PlayCast(ctx, strings.NewReader(cast), &out, 0)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, "", out.String())
+}
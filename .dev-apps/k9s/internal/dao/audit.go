@@ -0,0 +1,158 @@
+package dao
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// AuditSuccess tracks a successful action.
+	AuditSuccess = "success"
+
+	// AuditFailure tracks a failed action.
+	AuditFailure = "failure"
+
+	auditSinkTimeout = 2 * time.Second
+)
+
+var auditMx sync.Mutex
+
+// AuditRecord represents a mutating action audit record.
+type AuditRecord struct {
+	Time      time.Time `json:"time"`
+	Action    string    `json:"action"`
+	Context   string    `json:"context"`
+	Cluster   string    `json:"cluster,omitempty"`
+	User      string    `json:"user,omitempty"`
+	GVR       string    `json:"gvr,omitempty"`
+	Namespace string    `json:"namespace,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	Details   string    `json:"details,omitempty"`
+	Result    string    `json:"result"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// NewAuditRecord returns a new audit record given an action outcome.
+func NewAuditRecord(action, gvr, ns, n string, err error) AuditRecord {
+	r := AuditRecord{
+		Time:      time.Now(),
+		Action:    action,
+		GVR:       gvr,
+		Namespace: ns,
+		Name:      n,
+		Result:    AuditSuccess,
+	}
+	if err != nil {
+		r.Result, r.Error = AuditFailure, err.Error()
+	}
+
+	return r
+}
+
+// WriteAudit appends a record to the audit log and ships it to the configured sinks.
+func WriteAudit(path string, cfg *config.Audit, r AuditRecord) error {
+	if cfg == nil || !cfg.Enable {
+		return nil
+	}
+	raw, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	raw = append(raw, '\n')
+
+	auditMx.Lock()
+	err = appendAudit(path, cfg, raw)
+	auditMx.Unlock()
+
+	if cfg.Socket != "" {
+		go shipAuditSocket(cfg.Socket, raw)
+	}
+	if cfg.Webhook != "" {
+		go shipAuditWebhook(cfg.Webhook, raw)
+	}
+
+	return err
+}
+
+// RotateAudit rotates the audit log keeping at most max backups.
+func RotateAudit(path string, max int) error {
+	if max <= 0 {
+		return os.Remove(path)
+	}
+	if err := os.Remove(auditBackup(path, max)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for i := max - 1; i > 0; i-- {
+		if err := os.Rename(auditBackup(path, i), auditBackup(path, i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return os.Rename(path, auditBackup(path, 1))
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func appendAudit(path string, cfg *config.Audit, raw []byte) error {
+	config.EnsureFullPath(filepath.Dir(path), config.DefaultDirMod)
+	if fi, err := os.Stat(path); err == nil && fi.Size()+int64(len(raw)) > cfg.MaxSize() {
+		if err := RotateAudit(path, cfg.MaxBackups); err != nil {
+			return fmt.Errorf("audit log rotation failed: %w", err)
+		}
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, config.DefaultFileMod)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Error().Err(err).Msgf("Closing audit log")
+		}
+	}()
+	_, err = f.Write(raw)
+
+	return err
+}
+
+func auditBackup(path string, i int) string {
+	return fmt.Sprintf("%s.%d", path, i)
+}
+
+func shipAuditSocket(addr string, raw []byte) {
+	conn, err := net.DialTimeout("unix", addr, auditSinkTimeout)
+	if err != nil {
+		log.Warn().Err(err).Msgf("Audit socket sink %q unavailable", addr)
+		return
+	}
+	defer conn.Close()
+	if err := conn.SetWriteDeadline(time.Now().Add(auditSinkTimeout)); err != nil {
+		log.Warn().Err(err).Msgf("Audit socket sink deadline")
+	}
+	if _, err := conn.Write(raw); err != nil {
+		log.Warn().Err(err).Msgf("Audit socket sink %q write failed", addr)
+	}
+}
+
+func shipAuditWebhook(url string, raw []byte) {
+	c := http.Client{Timeout: auditSinkTimeout}
+	resp, err := c.Post(url, "application/json", bytes.NewReader(bytes.TrimSpace(raw)))
+	if err != nil {
+		log.Warn().Err(err).Msgf("Audit webhook sink %q failed", url)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		log.Warn().Msgf("Audit webhook sink %q returned %s", url, resp.Status)
+	}
+}
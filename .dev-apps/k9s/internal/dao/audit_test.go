@@ -0,0 +1,132 @@
+package dao
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAuditRecord(t *testing.T) {
+	uu := map[string]struct {
+		err         error
+		result, msg string
+	}{
+		"success": {result: AuditSuccess},
+		"failure": {err: errors.New("boom"), result: AuditFailure, msg: "boom"},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			r := NewAuditRecord("delete", "v1/pods", "blee", "fred", u.err)
+			assert.Equal(t, "delete", r.Action)
+			assert.Equal(t, "v1/pods", r.GVR)
+			assert.Equal(t, "blee", r.Namespace)
+			assert.Equal(t, "fred", r.Name)
+			assert.Equal(t, u.result, r.Result)
+			assert.Equal(t, u.msg, r.Error)
+		})
+	}
+}
+
+func TestWriteAudit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	cfg := config.NewAudit()
+
+	assert.Nil(t, WriteAudit(path, cfg, NewAuditRecord("scale", "apps/v1/deployments", "blee", "fred", nil)))
+	assert.Nil(t, WriteAudit(path, cfg, NewAuditRecord("delete", "v1/pods", "blee", "zorg", errors.New("boom"))))
+
+	rr := readAudit(t, path)
+	assert.Equal(t, 2, len(rr))
+	assert.Equal(t, "scale", rr[0].Action)
+	assert.Equal(t, AuditSuccess, rr[0].Result)
+	assert.Equal(t, "zorg", rr[1].Name)
+	assert.Equal(t, AuditFailure, rr[1].Result)
+	assert.Equal(t, "boom", rr[1].Error)
+}
+
+func TestWriteAuditDisabled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	cfg := config.NewAudit()
+	cfg.Enable = false
+
+	assert.Nil(t, WriteAudit(path, cfg, NewAuditRecord("delete", "v1/pods", "blee", "fred", nil)))
+	assert.Nil(t, WriteAudit(path, nil, NewAuditRecord("delete", "v1/pods", "blee", "fred", nil)))
+
+	_, err := os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestWriteAuditRotate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.log")
+	cfg := config.NewAudit()
+	cfg.MaxBackups = 2
+	assert.Nil(t, os.WriteFile(path, make([]byte, cfg.MaxSize()), 0600))
+	assert.Nil(t, os.WriteFile(auditBackup(path, 1), []byte("b1"), 0600))
+	assert.Nil(t, os.WriteFile(auditBackup(path, 2), []byte("b2"), 0600))
+
+	assert.Nil(t, WriteAudit(path, cfg, NewAuditRecord("edit", "v1/pods", "blee", "fred", nil)))
+
+	assert.Equal(t, 1, len(readAudit(t, path)))
+	fi, err := os.Stat(auditBackup(path, 1))
+	assert.Nil(t, err)
+	assert.Equal(t, cfg.MaxSize(), fi.Size())
+	raw, err := os.ReadFile(auditBackup(path, 2))
+	assert.Nil(t, err)
+	assert.Equal(t, "b1", string(raw))
+	_, err = os.Stat(auditBackup(path, 3))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestWriteAuditWebhook(t *testing.T) {
+	recs := make(chan AuditRecord, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		var rec AuditRecord
+		_ = json.Unmarshal(raw, &rec)
+		recs <- rec
+	}))
+	defer srv.Close()
+
+	cfg := config.NewAudit()
+	cfg.Webhook = srv.URL
+	path := filepath.Join(t.TempDir(), "audit.log")
+	assert.Nil(t, WriteAudit(path, cfg, NewAuditRecord("drain", "v1/nodes", "", "n1", nil)))
+
+	select {
+	case rec := <-recs:
+		assert.Equal(t, "drain", rec.Action)
+		assert.Equal(t, "n1", rec.Name)
+	case <-time.After(2 * time.Second):
+		assert.Fail(t, "webhook sink timed out")
+	}
+}
+
+// Helpers...
+
+func readAudit(t *testing.T, path string) []AuditRecord {
+	f, err := os.Open(path)
+	assert.Nil(t, err)
+	defer f.Close()
+
+	var rr []AuditRecord
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		var r AuditRecord
+		assert.Nil(t, json.Unmarshal(s.Bytes(), &r))
+		rr = append(rr, r)
+	}
+
+	return rr
+}
@@ -0,0 +1,422 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/render"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	mv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	"sigs.k8s.io/yaml"
+)
+
+var _ Accessor = (*Capacity)(nil)
+
+// Capacity represents nodes capacity and bin-packing.
+type Capacity struct {
+	NonResource
+}
+
+// List returns the cluster nodes capacity.
+func (c *Capacity) List(ctx context.Context, _ string) ([]runtime.Object, error) {
+	cc, err := c.Capacities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	oo := make([]runtime.Object, 0, len(cc))
+	for _, nc := range cc {
+		oo = append(oo, nc)
+	}
+
+	return oo, nil
+}
+
+// Capacities returns all nodes capacity sorted by name.
+func (c *Capacity) Capacities(ctx context.Context) ([]render.CapacityRes, error) {
+	nodes, pods, err := c.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var nmx client.NodesMetricsMap
+	if c.Client().HasMetrics() {
+		nmx, _ = client.DialMetrics(c.Client()).FetchNodesMetricsMap(ctx)
+	}
+
+	cc := make([]render.CapacityRes, 0, len(nodes))
+	for i := range nodes {
+		cc = append(cc, NewNodeCapacity(&nodes[i], pods[nodes[i].Name], nmx[nodes[i].Name]))
+	}
+
+	return cc, nil
+}
+
+// Fit checks whether a given number of pods fit on the cluster nodes.
+func (c *Capacity) Fit(ctx context.Context, spec *v1.PodSpec, replicas int) (*FitReport, error) {
+	nodes, pods, err := c.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cc := make(map[string]render.CapacityRes, len(nodes))
+	for i := range nodes {
+		cc[nodes[i].Name] = NewNodeCapacity(&nodes[i], pods[nodes[i].Name], nil)
+	}
+
+	return FitPods(spec, replicas, nodes, cc), nil
+}
+
+// NewNodeCapacity computes a node capacity given its scheduled pods.
+func NewNodeCapacity(no *v1.Node, pods []v1.Pod, mx *mv1beta1.NodeMetrics) render.CapacityRes {
+	res := render.CapacityRes{
+		Name:          no.Name,
+		Unschedulable: no.Spec.Unschedulable,
+		Pods:          len(pods),
+		MaxPods:       no.Status.Allocatable.Pods().Value(),
+	}
+	res.CPU.Allocatable = no.Status.Allocatable.Cpu().MilliValue()
+	res.Mem.Allocatable = no.Status.Allocatable.Memory().Value()
+	for i := range pods {
+		req, lim := PodResources(&pods[i].Spec)
+		res.CPU.Requests += req.Cpu().MilliValue()
+		res.CPU.Limits += lim.Cpu().MilliValue()
+		res.Mem.Requests += req.Memory().Value()
+		res.Mem.Limits += lim.Memory().Value()
+	}
+	if mx != nil {
+		res.CPU.Usage = mx.Usage.Cpu().MilliValue()
+		res.Mem.Usage = mx.Usage.Memory().Value()
+	}
+
+	return res
+}
+
+// PodResources returns a pod effective requests and limits.
+func PodResources(spec *v1.PodSpec) (v1.ResourceList, v1.ResourceList) {
+	req, lim := v1.ResourceList{}, v1.ResourceList{}
+	for _, co := range spec.Containers {
+		addResources(req, co.Resources.Requests)
+		addResources(lim, co.Resources.Limits)
+	}
+	for _, co := range spec.InitContainers {
+		maxResources(req, co.Resources.Requests)
+		maxResources(lim, co.Resources.Limits)
+	}
+	addResources(req, spec.Overhead)
+	addResources(lim, spec.Overhead)
+
+	return req, lim
+}
+
+// ManifestPodSpec extracts a pod spec from a pod or workload manifest.
+func ManifestPodSpec(raw []byte) (*v1.PodSpec, error) {
+	var u unstructured.Unstructured
+	if err := yaml.Unmarshal(raw, &u.Object); err != nil {
+		return nil, err
+	}
+	path := []string{"spec", "template", "spec"}
+	switch u.GetKind() {
+	case "Pod":
+		path = []string{"spec"}
+	case "CronJob":
+		path = []string{"spec", "jobTemplate", "spec", "template", "spec"}
+	}
+	m, ok, err := unstructured.NestedMap(u.Object, path...)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("no pod spec found in %s manifest", u.GetKind())
+	}
+	var spec v1.PodSpec
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m, &spec); err != nil {
+		return nil, err
+	}
+
+	return &spec, nil
+}
+
+// FitResult represents a pod fit on a given node.
+type FitResult struct {
+	Node    string
+	Fits    int
+	Reasons []string
+}
+
+// FitReport represents a pods fit across the cluster nodes.
+type FitReport struct {
+	Replicas int
+	// CPU request in millicores.
+	CPU int64
+	// Mem request in bytes.
+	Mem   int64
+	Total int
+	Nodes []FitResult
+}
+
+// Fits returns true if all replicas can be scheduled.
+func (f *FitReport) Fits() bool {
+	return f.Total >= f.Replicas
+}
+
+// String returns a human readable fit report.
+func (f *FitReport) String() string {
+	var b strings.Builder
+	verdict := "FITS"
+	if !f.Fits() {
+		verdict = "DOES NOT FIT"
+	}
+	fmt.Fprintf(&b, "Replicas: %d\nRequests: cpu %dm, memory %dMi per pod\nCapacity: %d pods schedulable\nVerdict:  %s\n\n",
+		f.Replicas, f.CPU, client.ToMB(f.Mem), f.Total, verdict)
+	for _, n := range f.Nodes {
+		if n.Fits > 0 {
+			fmt.Fprintf(&b, "  ✓ %-40s fits %d\n", n.Node, n.Fits)
+			continue
+		}
+		fmt.Fprintf(&b, "  ✗ %-40s %s\n", n.Node, strings.Join(n.Reasons, ", "))
+	}
+
+	return b.String()
+}
+
+// FitPods checks how many pods with a given spec can be scheduled on each node.
+func FitPods(spec *v1.PodSpec, replicas int, nodes []v1.Node, cc map[string]render.CapacityRes) *FitReport {
+	req, _ := PodResources(spec)
+	r := FitReport{
+		Replicas: replicas,
+		CPU:      req.Cpu().MilliValue(),
+		Mem:      req.Memory().Value(),
+	}
+	for i := range nodes {
+		fr := fitNode(spec, r.CPU, r.Mem, &nodes[i], cc[nodes[i].Name])
+		r.Total += fr.Fits
+		r.Nodes = append(r.Nodes, fr)
+	}
+	sort.Slice(r.Nodes, func(i, j int) bool {
+		if r.Nodes[i].Fits != r.Nodes[j].Fits {
+			return r.Nodes[i].Fits > r.Nodes[j].Fits
+		}
+		return r.Nodes[i].Node < r.Nodes[j].Node
+	})
+
+	return &r
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func (c *Capacity) load(ctx context.Context) ([]v1.Node, map[string][]v1.Pod, error) {
+	nl, err := FetchNodes(ctx, c.Factory, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	oo, err := c.GetFactory().List("v1/pods", client.AllNamespaces, false, labels.Everything())
+	if err != nil {
+		return nil, nil, err
+	}
+	pods := make(map[string][]v1.Pod, len(nl.Items))
+	for _, o := range oo {
+		u, ok := o.(*unstructured.Unstructured)
+		if !ok {
+			return nil, nil, fmt.Errorf("expecting unstructured resource but got %T", o)
+		}
+		var po v1.Pod
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &po); err != nil {
+			return nil, nil, err
+		}
+		if po.Spec.NodeName == "" || po.Status.Phase == v1.PodSucceeded || po.Status.Phase == v1.PodFailed {
+			continue
+		}
+		pods[po.Spec.NodeName] = append(pods[po.Spec.NodeName], po)
+	}
+	sort.Slice(nl.Items, func(i, j int) bool {
+		return nl.Items[i].Name < nl.Items[j].Name
+	})
+
+	return nl.Items, pods, nil
+}
+
+func fitNode(spec *v1.PodSpec, cpu, mem int64, no *v1.Node, nc render.CapacityRes) FitResult {
+	fr := FitResult{Node: no.Name}
+	if no.Spec.Unschedulable {
+		fr.Reasons = append(fr.Reasons, "node is cordoned")
+	}
+	if !isNodeReady(no) {
+		fr.Reasons = append(fr.Reasons, "node is not ready")
+	}
+	if t, ok := untoleratedTaint(no.Spec.Taints, spec.Tolerations); ok {
+		fr.Reasons = append(fr.Reasons, fmt.Sprintf("untolerated taint %s=%s:%s", t.Key, t.Value, t.Effect))
+	}
+	if !labels.SelectorFromSet(spec.NodeSelector).Matches(labels.Set(no.Labels)) {
+		fr.Reasons = append(fr.Reasons, "node selector mismatch")
+	}
+	if !matchNodeAffinity(spec.Affinity, no) {
+		fr.Reasons = append(fr.Reasons, "node affinity mismatch")
+	}
+	if len(fr.Reasons) > 0 {
+		return fr
+	}
+
+	fits := nc.MaxPods - int64(nc.Pods)
+	if cpu > 0 {
+		fits = minInt64(fits, (nc.CPU.Allocatable-nc.CPU.Requests)/cpu)
+	}
+	if mem > 0 {
+		fits = minInt64(fits, (nc.Mem.Allocatable-nc.Mem.Requests)/mem)
+	}
+	if fits <= 0 {
+		fr.Reasons = append(fr.Reasons, insufficientResources(cpu, mem, nc)...)
+		return fr
+	}
+	fr.Fits = int(fits)
+
+	return fr
+}
+
+func insufficientResources(cpu, mem int64, nc render.CapacityRes) []string {
+	var rr []string
+	if nc.MaxPods-int64(nc.Pods) <= 0 {
+		rr = append(rr, "too many pods")
+	}
+	if cpu > nc.CPU.Allocatable-nc.CPU.Requests {
+		rr = append(rr, "insufficient cpu")
+	}
+	if mem > nc.Mem.Allocatable-nc.Mem.Requests {
+		rr = append(rr, "insufficient memory")
+	}
+
+	return rr
+}
+
+func untoleratedTaint(tt []v1.Taint, tols []v1.Toleration) (v1.Taint, bool) {
+	for i := range tt {
+		if tt[i].Effect == v1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		var tolerated bool
+		for j := range tols {
+			if tols[j].ToleratesTaint(&tt[i]) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return tt[i], true
+		}
+	}
+
+	return v1.Taint{}, false
+}
+
+func matchNodeAffinity(a *v1.Affinity, no *v1.Node) bool {
+	if a == nil || a.NodeAffinity == nil || a.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return true
+	}
+	terms := a.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	if len(terms) == 0 {
+		return true
+	}
+	for _, t := range terms {
+		if matchNodeSelectorTerm(t, no) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchNodeSelectorTerm(t v1.NodeSelectorTerm, no *v1.Node) bool {
+	if len(t.MatchExpressions) == 0 && len(t.MatchFields) == 0 {
+		return false
+	}
+	for _, r := range t.MatchExpressions {
+		v, ok := no.Labels[r.Key]
+		if !matchNodeSelectorRequirement(r, v, ok) {
+			return false
+		}
+	}
+	// Only the node name is a supported field.
+	for _, r := range t.MatchFields {
+		if r.Key != "metadata.name" || !matchNodeSelectorRequirement(r, no.Name, true) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func matchNodeSelectorRequirement(r v1.NodeSelectorRequirement, v string, ok bool) bool {
+	switch r.Operator {
+	case v1.NodeSelectorOpIn:
+		return ok && in(r.Values, v)
+	case v1.NodeSelectorOpNotIn:
+		return !ok || !in(r.Values, v)
+	case v1.NodeSelectorOpExists:
+		return ok
+	case v1.NodeSelectorOpDoesNotExist:
+		return !ok
+	case v1.NodeSelectorOpGt, v1.NodeSelectorOpLt:
+		if !ok || len(r.Values) != 1 {
+			return false
+		}
+		lv, err1 := strconv.ParseInt(v, 10, 64)
+		rv, err2 := strconv.ParseInt(r.Values[0], 10, 64)
+		if err1 != nil || err2 != nil {
+			return false
+		}
+		if r.Operator == v1.NodeSelectorOpGt {
+			return lv > rv
+		}
+		return lv < rv
+	default:
+		return false
+	}
+}
+
+func isNodeReady(no *v1.Node) bool {
+	for _, c := range no.Status.Conditions {
+		if c.Type == v1.NodeReady {
+			return c.Status == v1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+func addResources(dst, src v1.ResourceList) {
+	for k, v := range src {
+		q := dst[k]
+		q.Add(v)
+		dst[k] = q
+	}
+}
+
+func maxResources(dst, src v1.ResourceList) {
+	for k, v := range src {
+		if q, ok := dst[k]; !ok || v.Cmp(q) > 0 {
+			dst[k] = v.DeepCopy()
+		}
+	}
+}
+
+func minInt64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func in(ll []string, s string) bool {
+	for _, l := range ll {
+		if l == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,282 @@
+package dao
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/render"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	mv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+func TestPodResources(t *testing.T) {
+	uu := map[string]struct {
+		spec     v1.PodSpec
+		cpu, mem string
+	}{
+		"empty": {
+			cpu: "0", mem: "0",
+		},
+		"containers": {
+			spec: v1.PodSpec{
+				Containers: []v1.Container{
+					makeFitContainer("100m", "64Mi"),
+					makeFitContainer("200m", "64Mi"),
+				},
+			},
+			cpu: "300m", mem: "128Mi",
+		},
+		"init-wins": {
+			spec: v1.PodSpec{
+				InitContainers: []v1.Container{makeFitContainer("1", "32Mi")},
+				Containers:     []v1.Container{makeFitContainer("100m", "64Mi")},
+			},
+			cpu: "1", mem: "64Mi",
+		},
+		"overhead": {
+			spec: v1.PodSpec{
+				Containers: []v1.Container{makeFitContainer("100m", "64Mi")},
+				Overhead:   makeFitResources("50m", "16Mi"),
+			},
+			cpu: "150m", mem: "80Mi",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			req, _ := PodResources(&u.spec)
+			cpu, mem := resource.MustParse(u.cpu), resource.MustParse(u.mem)
+			assert.Equal(t, cpu.MilliValue(), req.Cpu().MilliValue())
+			assert.Equal(t, mem.Value(), req.Memory().Value())
+		})
+	}
+}
+
+func TestNewNodeCapacity(t *testing.T) {
+	no := makeFitNode("n1", nil, nil)
+	pods := []v1.Pod{
+		{Spec: v1.PodSpec{Containers: []v1.Container{makeFitContainer("500m", "1Gi")}}},
+		{Spec: v1.PodSpec{Containers: []v1.Container{makeFitContainer("1", "1Gi")}}},
+	}
+	pods[1].Spec.Containers[0].Resources.Limits = makeFitResources("2", "2Gi")
+	mx := mv1beta1.NodeMetrics{Usage: makeFitResources("700m", "1Gi")}
+
+	c := NewNodeCapacity(no, pods, &mx)
+	assert.Equal(t, "n1", c.Name)
+	assert.Equal(t, 2, c.Pods)
+	assert.Equal(t, int64(10), c.MaxPods)
+	assert.Equal(t, render.CapacityUsage{Allocatable: 4000, Requests: 1500, Limits: 2000, Usage: 700}, c.CPU)
+	assert.Equal(t, int64(8*gib), c.Mem.Allocatable)
+	assert.Equal(t, int64(2*gib), c.Mem.Requests)
+	assert.Equal(t, int64(gib), c.Mem.Usage)
+}
+
+func TestFitPods(t *testing.T) {
+	tainted := makeFitNode("n2", nil, []v1.Taint{{Key: "gpu", Value: "true", Effect: v1.TaintEffectNoSchedule}})
+	labeled := makeFitNode("n3", map[string]string{"zone": "a"}, nil)
+	cordoned := makeFitNode("n4", nil, nil)
+	cordoned.Spec.Unschedulable = true
+	nodes := []v1.Node{*makeFitNode("n1", nil, nil), *tainted, *labeled, *cordoned}
+	cc := map[string]render.CapacityRes{
+		"n1": {Name: "n1", MaxPods: 10, CPU: render.CapacityUsage{Allocatable: 4000, Requests: 3000}, Mem: render.CapacityUsage{Allocatable: 8 * gib}},
+		"n2": {Name: "n2", MaxPods: 10, CPU: render.CapacityUsage{Allocatable: 4000}, Mem: render.CapacityUsage{Allocatable: 8 * gib}},
+		"n3": {Name: "n3", MaxPods: 10, CPU: render.CapacityUsage{Allocatable: 4000}, Mem: render.CapacityUsage{Allocatable: 8 * gib}},
+		"n4": {Name: "n4", MaxPods: 10, CPU: render.CapacityUsage{Allocatable: 4000}, Mem: render.CapacityUsage{Allocatable: 8 * gib}},
+	}
+
+	uu := map[string]struct {
+		spec     v1.PodSpec
+		replicas int
+		total    int
+		fits     bool
+		reasons  map[string][]string
+	}{
+		"plain": {
+			spec:     v1.PodSpec{Containers: []v1.Container{makeFitContainer("500m", "1Gi")}},
+			replicas: 5,
+			total:    10,
+			fits:     true,
+			reasons: map[string][]string{
+				"n2": {"untolerated taint gpu=true:NoSchedule"},
+				"n4": {"node is cordoned"},
+			},
+		},
+		"tolerations": {
+			spec: v1.PodSpec{
+				Containers:  []v1.Container{makeFitContainer("2", "1Gi")},
+				Tolerations: []v1.Toleration{{Key: "gpu", Operator: v1.TolerationOpExists}},
+			},
+			replicas: 5,
+			total:    4,
+			reasons: map[string][]string{
+				"n1": {"insufficient cpu"},
+				"n4": {"node is cordoned"},
+			},
+		},
+		"node-selector": {
+			spec: v1.PodSpec{
+				Containers:   []v1.Container{makeFitContainer("100m", "10Gi")},
+				NodeSelector: map[string]string{"zone": "a"},
+			},
+			replicas: 1,
+			reasons: map[string][]string{
+				"n1": {"node selector mismatch"},
+				"n2": {"untolerated taint gpu=true:NoSchedule", "node selector mismatch"},
+				"n3": {"insufficient memory"},
+				"n4": {"node is cordoned", "node selector mismatch"},
+			},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			r := FitPods(&u.spec, u.replicas, nodes, cc)
+			assert.Equal(t, u.total, r.Total)
+			assert.Equal(t, u.fits, r.Fits())
+			for _, n := range r.Nodes {
+				assert.Equal(t, u.reasons[n.Node], n.Reasons, n.Node)
+			}
+		})
+	}
+}
+
+func TestMatchNodeAffinity(t *testing.T) {
+	no := v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "n1",
+			Labels: map[string]string{"zone": "a", "cores": "8"},
+		},
+	}
+
+	uu := map[string]struct {
+		exprs  []v1.NodeSelectorRequirement
+		fields []v1.NodeSelectorRequirement
+		e      bool
+	}{
+		"empty": {},
+		"in": {
+			exprs: []v1.NodeSelectorRequirement{{Key: "zone", Operator: v1.NodeSelectorOpIn, Values: []string{"a", "b"}}},
+			e:     true,
+		},
+		"not-in": {
+			exprs: []v1.NodeSelectorRequirement{{Key: "zone", Operator: v1.NodeSelectorOpNotIn, Values: []string{"a"}}},
+		},
+		"exists": {
+			exprs: []v1.NodeSelectorRequirement{{Key: "zone", Operator: v1.NodeSelectorOpExists}},
+			e:     true,
+		},
+		"does-not-exist": {
+			exprs: []v1.NodeSelectorRequirement{{Key: "gpu", Operator: v1.NodeSelectorOpDoesNotExist}},
+			e:     true,
+		},
+		"gt": {
+			exprs: []v1.NodeSelectorRequirement{{Key: "cores", Operator: v1.NodeSelectorOpGt, Values: []string{"4"}}},
+			e:     true,
+		},
+		"lt": {
+			exprs: []v1.NodeSelectorRequirement{{Key: "cores", Operator: v1.NodeSelectorOpLt, Values: []string{"4"}}},
+		},
+		"field-in": {
+			fields: []v1.NodeSelectorRequirement{{Key: "metadata.name", Operator: v1.NodeSelectorOpIn, Values: []string{"n1"}}},
+			e:      true,
+		},
+		"field-not-in": {
+			fields: []v1.NodeSelectorRequirement{{Key: "metadata.name", Operator: v1.NodeSelectorOpNotIn, Values: []string{"n1"}}},
+		},
+		"field-unknown": {
+			fields: []v1.NodeSelectorRequirement{{Key: "spec.fred", Operator: v1.NodeSelectorOpIn, Values: []string{"n1"}}},
+		},
+		"field-and-expr": {
+			exprs:  []v1.NodeSelectorRequirement{{Key: "zone", Operator: v1.NodeSelectorOpIn, Values: []string{"a"}}},
+			fields: []v1.NodeSelectorRequirement{{Key: "metadata.name", Operator: v1.NodeSelectorOpIn, Values: []string{"n2"}}},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			a := v1.Affinity{
+				NodeAffinity: &v1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+						NodeSelectorTerms: []v1.NodeSelectorTerm{{MatchExpressions: u.exprs, MatchFields: u.fields}},
+					},
+				},
+			}
+			assert.Equal(t, u.e, matchNodeAffinity(&a, &no))
+		})
+	}
+}
+
+func TestManifestPodSpec(t *testing.T) {
+	uu := map[string]struct {
+		raw   string
+		image string
+		err   bool
+	}{
+		"pod": {
+			raw:   "kind: Pod\nspec:\n  containers:\n  - name: c1\n    image: fred:1\n",
+			image: "fred:1",
+		},
+		"deployment": {
+			raw:   "kind: Deployment\nspec:\n  template:\n    spec:\n      containers:\n      - name: c1\n        image: blee:2\n",
+			image: "blee:2",
+		},
+		"cronjob": {
+			raw:   "kind: CronJob\nspec:\n  jobTemplate:\n    spec:\n      template:\n        spec:\n          containers:\n          - name: c1\n            image: zorg:3\n",
+			image: "zorg:3",
+		},
+		"no-spec": {
+			raw: "kind: ConfigMap\ndata:\n  a: b\n",
+			err: true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			spec, err := ManifestPodSpec([]byte(u.raw))
+			if u.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, u.image, spec.Containers[0].Image)
+		})
+	}
+}
+
+// Helpers...
+
+const gib = 1024 * 1024 * 1024
+
+func makeFitResources(cpu, mem string) v1.ResourceList {
+	return v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse(cpu),
+		v1.ResourceMemory: resource.MustParse(mem),
+	}
+}
+
+func makeFitContainer(cpu, mem string) v1.Container {
+	return v1.Container{
+		Name:      "c1",
+		Resources: v1.ResourceRequirements{Requests: makeFitResources(cpu, mem)},
+	}
+}
+
+func makeFitNode(n string, ll map[string]string, tt []v1.Taint) *v1.Node {
+	alloc := makeFitResources("4", "8Gi")
+	alloc[v1.ResourcePods] = resource.MustParse("10")
+
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: n, Labels: ll},
+		Spec:       v1.NodeSpec{Taints: tt},
+		Status: v1.NodeStatus{
+			Allocatable: alloc,
+			Conditions:  []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}},
+		},
+	}
+}
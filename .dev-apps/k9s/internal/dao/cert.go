@@ -0,0 +1,497 @@
+package dao
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/rs/zerolog/log"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// CertOK tracks a valid certificate.
+	CertOK = "OK"
+
+	// CertWarn tracks a certificate expiring soon.
+	CertWarn = "WARN"
+
+	// CertCritical tracks a certificate about to expire.
+	CertCritical = "CRITICAL"
+
+	// CertExpired tracks an expired certificate.
+	CertExpired = "EXPIRED"
+
+	certSecretKind = "secret"
+	certCMKind     = "configmap"
+	certTimeFmt    = "2006-01-02 15:04"
+	pemCertHeader  = "-----BEGIN CERTIFICATE-----"
+)
+
+var _ Accessor = (*Cert)(nil)
+
+// CertBundle represents certificates found in a secret or configmap key.
+type CertBundle struct {
+	Kind, Namespace, Name, Key string
+
+	// Certs lists the bundle certificates in order.
+	Certs []*x509.Certificate
+
+	// KeyName is the private key entry matching this bundle if any.
+	KeyName string
+
+	// KeyMatch indicates the private key matches the leaf certificate.
+	KeyMatch bool
+
+	// KeyErr tracks a private key parsing error.
+	KeyErr error
+}
+
+// ID returns the bundle identifier.
+func (b *CertBundle) ID() string {
+	return b.Kind + ":" + client.FQN(b.Namespace, b.Name) + ":" + b.Key
+}
+
+// Chain returns the bundle certificates chain status.
+func (b *CertBundle) Chain() string {
+	if len(b.Certs) == 0 {
+		return "n/a"
+	}
+	if b.Certs[0].IsCA {
+		return fmt.Sprintf("ca bundle (%d certificate(s))", len(b.Certs))
+	}
+	for i := 0; i < len(b.Certs)-1; i++ {
+		if err := b.Certs[i].CheckSignatureFrom(b.Certs[i+1]); err != nil {
+			return fmt.Sprintf("broken, #%d is not signed by #%d", i, i+1)
+		}
+	}
+	last := b.Certs[len(b.Certs)-1]
+	if isSelfSigned(last) {
+		if len(b.Certs) == 1 {
+			return "self-signed"
+		}
+		return fmt.Sprintf("complete (%d certificate(s))", len(b.Certs))
+	}
+
+	return fmt.Sprintf("ok, issued by %s", certName(last.Issuer.String()))
+}
+
+// CertThresholds returns a certificate status given its expiry.
+func CertThresholds(notAfter, now time.Time, cfg *config.Certs) (int, string) {
+	days := int(math.Floor(notAfter.Sub(now).Hours() / 24))
+	switch {
+	case !now.Before(notAfter):
+		return days, CertExpired
+	case days < cfg.CriticalDays:
+		return days, CertCritical
+	case days < cfg.WarnDays:
+		return days, CertWarn
+	default:
+		return days, CertOK
+	}
+}
+
+// HasPEMCerts checks if a blob contains PEM certificates.
+func HasPEMCerts(raw []byte) bool {
+	return bytes.Contains(raw, []byte(pemCertHeader))
+}
+
+// ParsePEMCerts returns all the certificates found in a PEM blob.
+func ParsePEMCerts(raw []byte) ([]*x509.Certificate, error) {
+	var cc []*x509.Certificate
+	for {
+		var b *pem.Block
+		b, raw = pem.Decode(raw)
+		if b == nil {
+			break
+		}
+		if b.Type != "CERTIFICATE" {
+			continue
+		}
+		c, err := x509.ParseCertificate(b.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("certificate #%d: %w", len(cc), err)
+		}
+		cc = append(cc, c)
+	}
+	if len(cc) == 0 {
+		return nil, errors.New("no PEM certificates found")
+	}
+
+	return cc, nil
+}
+
+// CertKeyMatch checks if a PEM private key matches a certificate public key.
+func CertKeyMatch(c *x509.Certificate, raw []byte) (bool, error) {
+	b, _ := pem.Decode(raw)
+	if b == nil {
+		return false, errors.New("no PEM private key found")
+	}
+	k, err := parsePrivateKey(b.Bytes)
+	if err != nil {
+		return false, err
+	}
+	signer, ok := k.(crypto.Signer)
+	if !ok {
+		return false, fmt.Errorf("unsupported private key type %T", k)
+	}
+	pub, ok := signer.Public().(interface{ Equal(crypto.PublicKey) bool })
+	if !ok {
+		return false, fmt.Errorf("unsupported public key type %T", signer.Public())
+	}
+
+	return pub.Equal(c.PublicKey), nil
+}
+
+// SecretCertBundles returns the certificates bundles found in a secret.
+func SecretCertBundles(s *v1.Secret) []CertBundle {
+	bb := make([]CertBundle, 0, len(s.Data))
+	for k, raw := range s.Data {
+		if !HasPEMCerts(raw) {
+			continue
+		}
+		b := CertBundle{Kind: certSecretKind, Namespace: s.Namespace, Name: s.Name, Key: k}
+		cc, err := ParsePEMCerts(raw)
+		if err != nil {
+			log.Warn().Err(err).Msgf("Skipping secret %s key %s", client.FQN(s.Namespace, s.Name), k)
+			continue
+		}
+		b.Certs = cc
+		if kn := certKeyName(k); kn != "" {
+			if key, ok := s.Data[kn]; ok {
+				b.KeyName = kn
+				b.KeyMatch, b.KeyErr = CertKeyMatch(cc[0], key)
+			}
+		}
+		bb = append(bb, b)
+	}
+	sortBundles(bb)
+
+	return bb
+}
+
+// ConfigMapCertBundles returns the certificates bundles found in a configmap.
+func ConfigMapCertBundles(cm *v1.ConfigMap) []CertBundle {
+	bb := make([]CertBundle, 0, len(cm.Data))
+	add := func(k string, raw []byte) {
+		if !HasPEMCerts(raw) {
+			return
+		}
+		cc, err := ParsePEMCerts(raw)
+		if err != nil {
+			log.Warn().Err(err).Msgf("Skipping configmap %s key %s", client.FQN(cm.Namespace, cm.Name), k)
+			return
+		}
+		bb = append(bb, CertBundle{Kind: certCMKind, Namespace: cm.Namespace, Name: cm.Name, Key: k, Certs: cc})
+	}
+	for k, v := range cm.Data {
+		add(k, []byte(v))
+	}
+	for k, v := range cm.BinaryData {
+		add(k, v)
+	}
+	sortBundles(bb)
+
+	return bb
+}
+
+// CertReport returns a human readable certificates bundles report.
+func CertReport(bb []CertBundle, now time.Time, cfg *config.Certs) string {
+	if len(bb) == 0 {
+		return "No PEM certificates found\n"
+	}
+
+	var w strings.Builder
+	for i, b := range bb {
+		if i > 0 {
+			w.WriteString("\n")
+		}
+		writeCertBundle(&w, b, -1, now, cfg)
+	}
+
+	return w.String()
+}
+
+// Cert scans the cluster secrets and configmaps for certificates.
+type Cert struct {
+	NonResource
+}
+
+// List returns all the certificates found across all namespaces.
+func (c *Cert) List(ctx context.Context, _ string) ([]runtime.Object, error) {
+	cfg, ok := ctx.Value(internal.KeyCerts).(*config.Certs)
+	if !ok {
+		cfg = config.NewCerts()
+	}
+
+	bb, err := c.bundles()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	oo := make([]runtime.Object, 0, len(bb))
+	for _, b := range bb {
+		oo = append(oo, CertRows(b, now, cfg)...)
+	}
+
+	return oo, nil
+}
+
+// Inspect returns a report of the certificates found in a resource.
+func (c *Cert) Inspect(gvr, path string, cfg *config.Certs) (string, error) {
+	bb, err := c.inspect(gvr, path)
+	if err != nil {
+		return "", err
+	}
+
+	return CertReport(bb, time.Now(), cfg), nil
+}
+
+// Bundle returns the report for a given certificate id as listed by CertRows.
+func (c *Cert) Bundle(id string, cfg *config.Certs) (string, error) {
+	bid, idx := id, -1
+	if i := strings.LastIndex(id, "#"); i >= 0 {
+		n, err := strconv.Atoi(id[i+1:])
+		if err != nil {
+			return "", fmt.Errorf("invalid certificate id %q", id)
+		}
+		bid, idx = id[:i], n
+	}
+	tokens := strings.SplitN(bid, ":", 3)
+	if len(tokens) != 3 {
+		return "", fmt.Errorf("invalid certificate id %q", id)
+	}
+	gvr := "v1/secrets"
+	if tokens[0] == certCMKind {
+		gvr = "v1/configmaps"
+	}
+	bb, err := c.inspect(gvr, tokens[1])
+	if err != nil {
+		return "", err
+	}
+	for _, b := range bb {
+		if b.ID() != bid || idx >= len(b.Certs) {
+			continue
+		}
+		var w strings.Builder
+		writeCertBundle(&w, b, idx, time.Now(), cfg)
+		return w.String(), nil
+	}
+
+	return "", fmt.Errorf("no certificates found for %q", id)
+}
+
+// CertRows returns a certificate row per bundle certificate.
+func CertRows(b CertBundle, now time.Time, cfg *config.Certs) []runtime.Object {
+	oo := make([]runtime.Object, 0, len(b.Certs))
+	for i, c := range b.Certs {
+		days, status := CertThresholds(c.NotAfter, now, cfg)
+		keyMatch := "n/a"
+		if i == 0 && b.KeyName != "" {
+			keyMatch = strconv.FormatBool(b.KeyErr == nil && b.KeyMatch)
+		}
+		oo = append(oo, render.CertRes{
+			ID:        fmt.Sprintf("%s#%d", b.ID(), i),
+			Kind:      b.Kind,
+			Namespace: b.Namespace,
+			Name:      b.Name,
+			Key:       b.Key,
+			Index:     i,
+			Subject:   certName(c.Subject.String()),
+			Issuer:    certName(c.Issuer.String()),
+			SANs:      certSANs(c),
+			KeyMatch:  keyMatch,
+			NotAfter:  c.NotAfter.UTC().Format(certTimeFmt),
+			Days:      days,
+			Status:    status,
+		})
+	}
+
+	return oo
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+// writeCertBundle writes a bundle report. A non negative index only reports
+// the matching certificate.
+func writeCertBundle(w *strings.Builder, b CertBundle, index int, now time.Time, cfg *config.Certs) {
+	fmt.Fprintf(w, "%s %s\n", b.Kind, b.ID()[len(b.Kind)+1:])
+	fmt.Fprintf(w, "  chain:    %s\n", b.Chain())
+	switch {
+	case b.KeyName == "":
+		w.WriteString("  key:      none\n")
+	case b.KeyErr != nil:
+		fmt.Fprintf(w, "  key:      %s is invalid (%s)\n", b.KeyName, b.KeyErr)
+	case b.KeyMatch:
+		fmt.Fprintf(w, "  key:      matches %s\n", b.KeyName)
+	default:
+		fmt.Fprintf(w, "  key:      does NOT match %s\n", b.KeyName)
+	}
+	for j, c := range b.Certs {
+		if index >= 0 && j != index {
+			continue
+		}
+		days, status := CertThresholds(c.NotAfter, now, cfg)
+		fmt.Fprintf(w, "  #%d\n", j)
+		fmt.Fprintf(w, "    subject:  %s\n", c.Subject)
+		fmt.Fprintf(w, "    issuer:   %s\n", c.Issuer)
+		if ss := certSANs(c); len(ss) > 0 {
+			fmt.Fprintf(w, "    sans:     %s\n", strings.Join(ss, ", "))
+		}
+		fmt.Fprintf(w, "    serial:   %s\n", c.SerialNumber)
+		fmt.Fprintf(w, "    ca:       %t\n", c.IsCA)
+		fmt.Fprintf(w, "    valid:    %s -> %s\n", c.NotBefore.UTC().Format(certTimeFmt), c.NotAfter.UTC().Format(certTimeFmt))
+		fmt.Fprintf(w, "    expiry:   %s (%s)\n", certExpiry(days), status)
+	}
+}
+
+func (c *Cert) bundles() ([]CertBundle, error) {
+	ss, err := c.GetFactory().List("v1/secrets", client.AllNamespaces, true, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var bb []CertBundle
+	for _, o := range ss {
+		var s v1.Secret
+		if err := fromUnstructured(o, &s); err != nil {
+			return nil, err
+		}
+		bb = append(bb, SecretCertBundles(&s)...)
+	}
+
+	cms, err := c.GetFactory().List("v1/configmaps", client.AllNamespaces, true, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range cms {
+		var cm v1.ConfigMap
+		if err := fromUnstructured(o, &cm); err != nil {
+			return nil, err
+		}
+		bb = append(bb, ConfigMapCertBundles(&cm)...)
+	}
+
+	return bb, nil
+}
+
+func (c *Cert) inspect(gvr, path string) ([]CertBundle, error) {
+	o, err := c.GetFactory().Get(gvr, path, true, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	switch gvr {
+	case "v1/secrets":
+		var s v1.Secret
+		if err := fromUnstructured(o, &s); err != nil {
+			return nil, err
+		}
+		return SecretCertBundles(&s), nil
+	case "v1/configmaps":
+		var cm v1.ConfigMap
+		if err := fromUnstructured(o, &cm); err != nil {
+			return nil, err
+		}
+		return ConfigMapCertBundles(&cm), nil
+	default:
+		return nil, fmt.Errorf("unsupported certificates resource %q", gvr)
+	}
+}
+
+func parsePrivateKey(der []byte) (crypto.PrivateKey, error) {
+	if k, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return k, nil
+	}
+	if k, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		switch k := k.(type) {
+		case *rsa.PrivateKey, *ecdsa.PrivateKey, ed25519.PrivateKey:
+			return k, nil
+		default:
+			return nil, fmt.Errorf("unsupported private key type %T", k)
+		}
+	}
+	if k, err := x509.ParseECPrivateKey(der); err == nil {
+		return k, nil
+	}
+
+	return nil, errors.New("unable to parse private key")
+}
+
+// certKeyName returns the private key entry conventionally paired with a
+// certificate entry.
+func certKeyName(k string) string {
+	switch {
+	case k == v1.TLSCertKey:
+		return v1.TLSPrivateKeyKey
+	case strings.HasSuffix(k, ".crt"):
+		return strings.TrimSuffix(k, ".crt") + ".key"
+	case strings.HasSuffix(k, ".pem") && !strings.HasSuffix(k, "-key.pem"):
+		return strings.TrimSuffix(k, ".pem") + "-key.pem"
+	default:
+		return ""
+	}
+}
+
+func isSelfSigned(c *x509.Certificate) bool {
+	return bytes.Equal(c.RawIssuer, c.RawSubject) && c.CheckSignatureFrom(c) == nil
+}
+
+// certName returns a distinguished name common name if any.
+func certName(dn string) string {
+	for _, t := range strings.Split(dn, ",") {
+		if strings.HasPrefix(t, "CN=") {
+			return strings.TrimPrefix(t, "CN=")
+		}
+	}
+
+	return dn
+}
+
+func certSANs(c *x509.Certificate) []string {
+	ss := make([]string, 0, len(c.DNSNames)+len(c.IPAddresses)+len(c.EmailAddresses)+len(c.URIs))
+	ss = append(ss, c.DNSNames...)
+	for _, ip := range c.IPAddresses {
+		ss = append(ss, ip.String())
+	}
+	ss = append(ss, c.EmailAddresses...)
+	for _, u := range c.URIs {
+		ss = append(ss, u.String())
+	}
+
+	return ss
+}
+
+func certExpiry(days int) string {
+	switch {
+	case days < 0:
+		return fmt.Sprintf("expired %d day(s) ago", -days)
+	case days == 0:
+		return "expires today"
+	default:
+		return fmt.Sprintf("expires in %d day(s)", days)
+	}
+}
+
+func sortBundles(bb []CertBundle) {
+	sort.Slice(bb, func(i, j int) bool {
+		return bb[i].Key < bb[j].Key
+	})
+}
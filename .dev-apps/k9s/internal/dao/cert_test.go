@@ -0,0 +1,344 @@
+package dao
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var certNow = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestCertThresholds(t *testing.T) {
+	uu := map[string]struct {
+		notAfter time.Time
+		days     int
+		status   string
+	}{
+		"ok": {
+			notAfter: certNow.Add(90 * 24 * time.Hour),
+			days:     90,
+			status:   CertOK,
+		},
+		"warn": {
+			notAfter: certNow.Add(29 * 24 * time.Hour),
+			days:     29,
+			status:   CertWarn,
+		},
+		"critical": {
+			notAfter: certNow.Add(2 * time.Hour),
+			status:   CertCritical,
+		},
+		"expired": {
+			notAfter: certNow.Add(-49 * time.Hour),
+			days:     -3,
+			status:   CertExpired,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			days, status := CertThresholds(u.notAfter, certNow, config.NewCerts())
+			assert.Equal(t, u.days, days)
+			assert.Equal(t, u.status, status)
+		})
+	}
+}
+
+func TestParsePEMCerts(t *testing.T) {
+	ca := makeCert(t, "ca", nil, 365)
+	leaf := makeCert(t, "fred", ca, 30)
+
+	uu := map[string]struct {
+		raw   []byte
+		count int
+		err   bool
+	}{
+		"single": {
+			raw:   leaf.pem,
+			count: 1,
+		},
+		"chain": {
+			raw:   bytes.Join([][]byte{leaf.pem, leaf.keyPEM, ca.pem}, nil),
+			count: 2,
+		},
+		"none": {
+			raw: []byte("blee"),
+			err: true,
+		},
+		"garbage": {
+			raw: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("blee")}),
+			err: true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			cc, err := ParsePEMCerts(u.raw)
+			if u.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, u.count, len(cc))
+		})
+	}
+}
+
+func TestCertKeyMatch(t *testing.T) {
+	c1, c2 := makeCert(t, "fred", nil, 30), makeCert(t, "blee", nil, 30)
+
+	uu := map[string]struct {
+		key []byte
+		e   bool
+		err bool
+	}{
+		"match": {
+			key: c1.keyPEM,
+			e:   true,
+		},
+		"mismatch": {
+			key: c2.keyPEM,
+		},
+		"invalid": {
+			key: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("blee")}),
+			err: true,
+		},
+		"none": {
+			key: []byte("blee"),
+			err: true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			ok, err := CertKeyMatch(c1.cert, u.key)
+			if u.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, u.e, ok)
+		})
+	}
+}
+
+func TestSecretCertBundles(t *testing.T) {
+	ca, other := makeCert(t, "ca", nil, 365), makeCert(t, "other", nil, 365)
+	leaf := makeCert(t, "fred", ca, 10)
+
+	uu := map[string]struct {
+		data       map[string][]byte
+		chain, key string
+	}{
+		"chain": {
+			data: map[string][]byte{
+				v1.TLSCertKey:       bytes.Join([][]byte{leaf.pem, ca.pem}, nil),
+				v1.TLSPrivateKeyKey: leaf.keyPEM,
+			},
+			chain: "complete (2 certificate(s))",
+			key:   "matches tls.key",
+		},
+		"partial": {
+			data:  map[string][]byte{v1.TLSCertKey: leaf.pem},
+			chain: "ok, issued by ca",
+			key:   "none",
+		},
+		"broken": {
+			data: map[string][]byte{
+				v1.TLSCertKey:       bytes.Join([][]byte{leaf.pem, other.pem}, nil),
+				v1.TLSPrivateKeyKey: other.keyPEM,
+			},
+			chain: "broken, #0 is not signed by #1",
+			key:   "does NOT match tls.key",
+		},
+		"ca": {
+			data:  map[string][]byte{"server.crt": other.pem, "server.key": other.keyPEM},
+			chain: "ca bundle (1 certificate(s))",
+			key:   "matches server.key",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			s := v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "fred"},
+				Data:       u.data,
+			}
+			bb := SecretCertBundles(&s)
+			assert.Equal(t, 1, len(bb))
+			assert.Equal(t, u.chain, bb[0].Chain())
+			r := CertReport(bb, certNow, config.NewCerts())
+			assert.Contains(t, r, "  key:      "+u.key+"\n")
+		})
+	}
+}
+
+func TestConfigMapCertBundles(t *testing.T) {
+	c1, c2 := makeCert(t, "ca1", nil, 365), makeCert(t, "ca2", nil, 5)
+	cm := v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "ca"},
+		Data: map[string]string{
+			"ca.crt":  string(c1.pem) + string(c2.pem),
+			"blee.md": "fred",
+		},
+	}
+
+	bb := ConfigMapCertBundles(&cm)
+	assert.Equal(t, 1, len(bb))
+	assert.Equal(t, "configmap:kube-system/ca:ca.crt", bb[0].ID())
+
+	oo := CertRows(bb[0], certNow, config.NewCerts())
+	assert.Equal(t, 2, len(oo))
+	r1, r2 := oo[0].(render.CertRes), oo[1].(render.CertRes)
+	assert.Equal(t, "configmap:kube-system/ca:ca.crt#1", r2.ID)
+	assert.Equal(t, "ca1", r1.Subject)
+	assert.Equal(t, CertOK, r1.Status)
+	assert.Equal(t, CertCritical, r2.Status)
+	assert.Equal(t, "n/a", r2.KeyMatch)
+	assert.Equal(t, []string{"ca2.example.com", "10.0.0.1"}, r2.SANs)
+}
+
+func TestCertReport(t *testing.T) {
+	ca := makeCert(t, "ca", nil, 365)
+	leaf := makeCert(t, "fred", ca, -2)
+	s := v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "fred"},
+		Data:       map[string][]byte{v1.TLSCertKey: leaf.pem, v1.TLSPrivateKeyKey: leaf.keyPEM},
+	}
+
+	r := CertReport(SecretCertBundles(&s), certNow, config.NewCerts())
+	assert.Contains(t, r, "secret default/fred:tls.crt\n")
+	assert.Contains(t, r, "    subject:  CN=fred\n")
+	assert.Contains(t, r, "    issuer:   CN=ca\n")
+	assert.Contains(t, r, "    sans:     fred.example.com, 10.0.0.1\n")
+	assert.Contains(t, r, "    expiry:   expired 2 day(s) ago (EXPIRED)\n")
+	assert.Equal(t, "No PEM certificates found\n", CertReport(nil, certNow, config.NewCerts()))
+}
+
+func TestCertBundle(t *testing.T) {
+	ca := makeCert(t, "ca", nil, 365)
+	leaf := makeCert(t, "fred", ca, 10)
+	cm := v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "fred"},
+		Data:       map[string]string{"ca.crt": string(leaf.pem) + string(ca.pem)},
+	}
+	o, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&cm)
+	assert.Nil(t, err)
+	var c Cert
+	c.Init(certFactory{o: &unstructured.Unstructured{Object: o}}, client.NewGVR("certs"))
+
+	rr := CertRows(ConfigMapCertBundles(&cm)[0], certNow, config.NewCerts())
+	assert.Equal(t, 2, len(rr))
+
+	uu := map[string]struct {
+		id       string
+		cn, skip string
+		err      string
+	}{
+		"leaf": {
+			id:   rr[0].(render.CertRes).ID,
+			cn:   "CN=fred",
+			skip: "CN=ca",
+		},
+		"ca": {
+			id:   rr[1].(render.CertRes).ID,
+			cn:   "CN=ca",
+			skip: "CN=fred",
+		},
+		"out-of-range": {
+			id:  "configmap:default/fred:ca.crt#2",
+			err: `no certificates found for "configmap:default/fred:ca.crt#2"`,
+		},
+		"invalid": {
+			id:  "configmap:default/fred:ca.crt#blee",
+			err: `invalid certificate id "configmap:default/fred:ca.crt#blee"`,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			r, err := c.Bundle(u.id, config.NewCerts())
+			if u.err != "" {
+				assert.EqualError(t, err, u.err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Contains(t, r, "configmap default/fred:ca.crt\n")
+			assert.Contains(t, r, "    subject:  "+u.cn+"\n")
+			assert.NotContains(t, r, "    subject:  "+u.skip+"\n")
+		})
+	}
+}
+
+// Helpers...
+
+type certFactory struct {
+	Factory
+	o runtime.Object
+}
+
+func (f certFactory) Get(string, string, bool, labels.Selector) (runtime.Object, error) {
+	return f.o, nil
+}
+
+type testCert struct {
+	cert        *x509.Certificate
+	key         *ecdsa.PrivateKey
+	pem, keyPEM []byte
+}
+
+func makeCert(t *testing.T, cn string, parent *testCert, days int) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	tpl := x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		DNSNames:     []string{cn + ".example.com"},
+		IPAddresses:  []net.IP{net.ParseIP("10.0.0.1")},
+		NotBefore:    certNow.Add(-24 * time.Hour),
+		NotAfter:     certNow.Add(time.Duration(days) * 24 * time.Hour),
+	}
+	issuer, signer := &tpl, key
+	if parent == nil {
+		tpl.IsCA, tpl.BasicConstraintsValid = true, true
+		tpl.KeyUsage = x509.KeyUsageCertSign
+	} else {
+		issuer, signer = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &tpl, issuer, &key.PublicKey, signer)
+	assert.Nil(t, err)
+	c, err := x509.ParseCertificate(der)
+	assert.Nil(t, err)
+	raw, err := x509.MarshalECPrivateKey(key)
+	assert.Nil(t, err)
+
+	return &testCert{
+		cert:   c,
+		key:    key,
+		pem:    pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: raw}),
+	}
+}
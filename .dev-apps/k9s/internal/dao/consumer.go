@@ -0,0 +1,271 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/rs/zerolog/log"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// ConsumerEnv tracks a container env var referencing a key.
+	ConsumerEnv = "env"
+
+	// ConsumerEnvFrom tracks a container env sourced from all keys.
+	ConsumerEnvFrom = "envFrom"
+
+	// ConsumerVolume tracks a volume mount.
+	ConsumerVolume = "volume"
+
+	// ConsumerProjected tracks a projected volume source.
+	ConsumerProjected = "projected"
+)
+
+var _ Accessor = (*Consumer)(nil)
+
+// ConsumerOwner represents a restartable workload owning stale consumers.
+type ConsumerOwner struct {
+	GVR, FQN string
+}
+
+// Consumer represents the pods consuming a configmap or secret.
+type Consumer struct {
+	NonResource
+}
+
+// List returns the consumers of the configmap or secret in context.
+func (c *Consumer) List(ctx context.Context, _ string) ([]runtime.Object, error) {
+	gvr, ok := ctx.Value(internal.KeyGVR).(string)
+	if !ok {
+		return nil, errors.New("no context GVR found")
+	}
+	fqn, ok := ctx.Value(internal.KeyPath).(string)
+	if !ok {
+		return nil, errors.New("expecting context Path")
+	}
+
+	cc, err := c.Consumers(gvr, fqn)
+	if err != nil {
+		return nil, err
+	}
+	oo := make([]runtime.Object, 0, len(cc))
+	for _, co := range cc {
+		oo = append(oo, co)
+	}
+
+	return oo, nil
+}
+
+// Consumers returns the pods consuming a given configmap or secret.
+func (c *Consumer) Consumers(gvr, fqn string) ([]render.ConsumerRes, error) {
+	kind, err := consumerKind(gvr)
+	if err != nil {
+		return nil, err
+	}
+	o, err := c.GetFactory().Get(gvr, fqn, true, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	m, ok := o.(metav1.Object)
+	if !ok {
+		return nil, fmt.Errorf("expecting an object but got %T", o)
+	}
+	modified := ConfigModified(m)
+
+	ns, n := client.Namespaced(fqn)
+	oo, err := c.GetFactory().List("v1/pods", ns, true, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	cc := make([]render.ConsumerRes, 0, len(oo))
+	for _, o := range oo {
+		var pod v1.Pod
+		if err := fromUnstructured(o, &pod); err != nil {
+			return nil, err
+		}
+		uu := PodConfigUsages(&pod.Spec, kind, n)
+		if len(uu) == 0 {
+			continue
+		}
+		started := PodStarted(&pod)
+		co := render.ConsumerRes{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			Usages:    uu,
+			Started:   started,
+			Stale:     !started.IsZero() && started.Before(modified),
+		}
+		co.OwnerKind, co.OwnerName, co.OwnerGVR = c.podOwner(&pod)
+		cc = append(cc, co)
+	}
+
+	return cc, nil
+}
+
+// ConfigModified returns a resource last modification time.
+func ConfigModified(m metav1.Object) time.Time {
+	t := m.GetCreationTimestamp().Time
+	for _, f := range m.GetManagedFields() {
+		if f.Time != nil && f.Time.After(t) {
+			t = f.Time.Time
+		}
+	}
+
+	return t
+}
+
+// PodStarted returns the earliest running container start time.
+func PodStarted(pod *v1.Pod) time.Time {
+	var t time.Time
+	for _, s := range pod.Status.ContainerStatuses {
+		if r := s.State.Running; r != nil && (t.IsZero() || r.StartedAt.Time.Before(t)) {
+			t = r.StartedAt.Time
+		}
+	}
+	if t.IsZero() && pod.Status.StartTime != nil && pod.Status.Phase == v1.PodRunning {
+		t = pod.Status.StartTime.Time
+	}
+
+	return t
+}
+
+// PodConfigUsages returns how a pod consumes a given configmap or secret.
+func PodConfigUsages(spec *v1.PodSpec, kind, name string) []string {
+	uu := make(map[string]struct{})
+	cc := make([]v1.Container, 0, len(spec.InitContainers)+len(spec.Containers))
+	cc = append(cc, spec.InitContainers...)
+	cc = append(cc, spec.Containers...)
+	for _, c := range cc {
+		for _, e := range c.Env {
+			if e.ValueFrom != nil && envRefs(e.ValueFrom, kind, name) {
+				uu[ConsumerEnv] = struct{}{}
+			}
+		}
+		for _, e := range c.EnvFrom {
+			if kind == "configmap" && e.ConfigMapRef != nil && e.ConfigMapRef.Name == name ||
+				kind == "secret" && e.SecretRef != nil && e.SecretRef.Name == name {
+				uu[ConsumerEnvFrom] = struct{}{}
+			}
+		}
+	}
+	for _, v := range spec.Volumes {
+		if kind == "configmap" && v.ConfigMap != nil && v.ConfigMap.Name == name ||
+			kind == "secret" && v.Secret != nil && v.Secret.SecretName == name {
+			uu[ConsumerVolume] = struct{}{}
+		}
+		if v.Projected == nil {
+			continue
+		}
+		for _, s := range v.Projected.Sources {
+			if kind == "configmap" && s.ConfigMap != nil && s.ConfigMap.Name == name ||
+				kind == "secret" && s.Secret != nil && s.Secret.Name == name {
+				uu[ConsumerProjected] = struct{}{}
+			}
+		}
+	}
+
+	ss := make([]string, 0, len(uu))
+	for _, u := range []string{ConsumerEnv, ConsumerEnvFrom, ConsumerVolume, ConsumerProjected} {
+		if _, ok := uu[u]; ok {
+			ss = append(ss, u)
+		}
+	}
+
+	return ss
+}
+
+// StaleConsumerOwners returns the restartable owners of stale consumers and the
+// stale pods that can't be restarted.
+func StaleConsumerOwners(cc []render.ConsumerRes) ([]ConsumerOwner, []string) {
+	set := make(map[ConsumerOwner]struct{})
+	var orphans []string
+	for _, c := range cc {
+		if !c.Stale {
+			continue
+		}
+		if c.OwnerGVR == "" {
+			orphans = append(orphans, client.FQN(c.Namespace, c.Name))
+			continue
+		}
+		set[ConsumerOwner{GVR: c.OwnerGVR, FQN: client.FQN(c.Namespace, c.OwnerName)}] = struct{}{}
+	}
+	oo := make([]ConsumerOwner, 0, len(set))
+	for o := range set {
+		oo = append(oo, o)
+	}
+	sort.Slice(oo, func(i, j int) bool {
+		if oo[i].GVR == oo[j].GVR {
+			return oo[i].FQN < oo[j].FQN
+		}
+		return oo[i].GVR < oo[j].GVR
+	})
+	sort.Strings(orphans)
+
+	return oo, orphans
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func consumerKind(gvr string) (string, error) {
+	switch gvr {
+	case "v1/configmaps":
+		return "configmap", nil
+	case "v1/secrets":
+		return "secret", nil
+	default:
+		return "", fmt.Errorf("no consumers for %q", gvr)
+	}
+}
+
+func envRefs(s *v1.EnvVarSource, kind, name string) bool {
+	switch kind {
+	case "configmap":
+		return s.ConfigMapKeyRef != nil && s.ConfigMapKeyRef.Name == name
+	case "secret":
+		return s.SecretKeyRef != nil && s.SecretKeyRef.Name == name
+	default:
+		return false
+	}
+}
+
+// podOwner returns a pod top level owner kind, name and gvr if restartable.
+func (c *Consumer) podOwner(pod *v1.Pod) (string, string, string) {
+	ref := metav1.GetControllerOf(pod)
+	if ref == nil {
+		return "", "", ""
+	}
+	switch ref.Kind {
+	case "ReplicaSet":
+		fqn := client.FQN(pod.Namespace, ref.Name)
+		o, err := c.GetFactory().Get("apps/v1/replicasets", fqn, true, labels.Everything())
+		if err != nil {
+			log.Warn().Err(err).Msgf("Unable to resolve replicaset %s", fqn)
+			return ref.Kind, ref.Name, ""
+		}
+		m, ok := o.(metav1.Object)
+		if !ok {
+			return ref.Kind, ref.Name, ""
+		}
+		if dp := metav1.GetControllerOfNoCopy(m); dp != nil && dp.Kind == "Deployment" {
+			return dp.Kind, dp.Name, "apps/v1/deployments"
+		}
+		return ref.Kind, ref.Name, ""
+	case "StatefulSet":
+		return ref.Kind, ref.Name, "apps/v1/statefulsets"
+	case "DaemonSet":
+		return ref.Kind, ref.Name, "apps/v1/daemonsets"
+	default:
+		return ref.Kind, ref.Name, ""
+	}
+}
@@ -0,0 +1,161 @@
+package dao
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/render"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodConfigUsages(t *testing.T) {
+	spec := v1.PodSpec{
+		InitContainers: []v1.Container{
+			{
+				Name: "i1",
+				EnvFrom: []v1.EnvFromSource{
+					{SecretRef: &v1.SecretEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "fred"}}},
+				},
+			},
+		},
+		Containers: []v1.Container{
+			{
+				Name: "c1",
+				Env: []v1.EnvVar{
+					{Name: "a", Value: "blee"},
+					{Name: "b", ValueFrom: &v1.EnvVarSource{
+						ConfigMapKeyRef: &v1.ConfigMapKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "fred"}, Key: "k"},
+					}},
+				},
+				EnvFrom: []v1.EnvFromSource{
+					{ConfigMapRef: &v1.ConfigMapEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "zorg"}}},
+				},
+			},
+		},
+		Volumes: []v1.Volume{
+			{Name: "v1", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: v1.LocalObjectReference{Name: "zorg"}}}},
+			{Name: "v2", VolumeSource: v1.VolumeSource{Projected: &v1.ProjectedVolumeSource{
+				Sources: []v1.VolumeProjection{
+					{ConfigMap: &v1.ConfigMapProjection{LocalObjectReference: v1.LocalObjectReference{Name: "fred"}}},
+					{Secret: &v1.SecretProjection{LocalObjectReference: v1.LocalObjectReference{Name: "zorg"}}},
+				},
+			}}},
+			{Name: "v3", VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: "fred"}}},
+		},
+	}
+
+	uu := map[string]struct {
+		kind, name string
+		e          []string
+	}{
+		"cm-fred": {
+			kind: "configmap",
+			name: "fred",
+			e:    []string{ConsumerEnv, ConsumerProjected},
+		},
+		"cm-zorg": {
+			kind: "configmap",
+			name: "zorg",
+			e:    []string{ConsumerEnvFrom, ConsumerVolume},
+		},
+		"sec-fred": {
+			kind: "secret",
+			name: "fred",
+			e:    []string{ConsumerEnvFrom, ConsumerVolume},
+		},
+		"sec-zorg": {
+			kind: "secret",
+			name: "zorg",
+			e:    []string{ConsumerProjected},
+		},
+		"none": {
+			kind: "configmap",
+			name: "blee",
+			e:    []string{},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, PodConfigUsages(&spec, u.kind, u.name))
+		})
+	}
+}
+
+func TestPodStarted(t *testing.T) {
+	t1, t2 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	uu := map[string]struct {
+		status v1.PodStatus
+		e      time.Time
+	}{
+		"containers": {
+			status: v1.PodStatus{
+				Phase:     v1.PodRunning,
+				StartTime: &metav1.Time{Time: t1},
+				ContainerStatuses: []v1.ContainerStatus{
+					{State: v1.ContainerState{Running: &v1.ContainerStateRunning{StartedAt: metav1.Time{Time: t2}}}},
+					{State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{}}},
+				},
+			},
+			e: t2,
+		},
+		"earliest": {
+			status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{State: v1.ContainerState{Running: &v1.ContainerStateRunning{StartedAt: metav1.Time{Time: t2}}}},
+					{State: v1.ContainerState{Running: &v1.ContainerStateRunning{StartedAt: metav1.Time{Time: t1}}}},
+				},
+			},
+			e: t1,
+		},
+		"running": {
+			status: v1.PodStatus{Phase: v1.PodRunning, StartTime: &metav1.Time{Time: t1}},
+			e:      t1,
+		},
+		"pending": {
+			status: v1.PodStatus{Phase: v1.PodPending, StartTime: &metav1.Time{Time: t1}},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, PodStarted(&v1.Pod{Status: u.status}))
+		})
+	}
+}
+
+func TestConfigModified(t *testing.T) {
+	t1, t2 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	cm := v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Time{Time: t1}}}
+	assert.Equal(t, t1, ConfigModified(&cm))
+
+	cm.ManagedFields = []metav1.ManagedFieldsEntry{
+		{Manager: "kubectl", Time: &metav1.Time{Time: t2}},
+		{Manager: "blee"},
+		{Manager: "fred", Time: &metav1.Time{Time: t1}},
+	}
+	assert.Equal(t, t2, ConfigModified(&cm))
+}
+
+func TestStaleConsumerOwners(t *testing.T) {
+	cc := []render.ConsumerRes{
+		{Namespace: "default", Name: "p1", Stale: true, OwnerKind: "Deployment", OwnerName: "fred", OwnerGVR: "apps/v1/deployments"},
+		{Namespace: "default", Name: "p2", Stale: true, OwnerKind: "Deployment", OwnerName: "fred", OwnerGVR: "apps/v1/deployments"},
+		{Namespace: "default", Name: "p3", Stale: true, OwnerKind: "StatefulSet", OwnerName: "blee", OwnerGVR: "apps/v1/statefulsets"},
+		{Namespace: "default", Name: "p4", Stale: false, OwnerKind: "DaemonSet", OwnerName: "zorg", OwnerGVR: "apps/v1/daemonsets"},
+		{Namespace: "default", Name: "p5", Stale: true, OwnerKind: "Job", OwnerName: "j1"},
+		{Namespace: "default", Name: "p6", Stale: true},
+	}
+
+	oo, orphans := StaleConsumerOwners(cc)
+	assert.Equal(t, []ConsumerOwner{
+		{GVR: "apps/v1/deployments", FQN: "default/fred"},
+		{GVR: "apps/v1/statefulsets", FQN: "default/blee"},
+	}, oo)
+	assert.Equal(t, []string{"default/p5", "default/p6"}, orphans)
+}
@@ -0,0 +1,232 @@
+package dao
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// ProgressFn reports the number of bytes transferred so far.
+type ProgressFn func(bytes int64)
+
+// Exec runs a command in a given container and streams its input and output.
+func (p *Pod) Exec(fqn, co string, cmd []string, in io.Reader, out io.Writer) error {
+	var stderr strings.Builder
+	opts := v1.PodExecOptions{
+		Container: co,
+		Command:   cmd,
+		Stdin:     in != nil,
+		Stdout:    true,
+		Stderr:    true,
+	}
+	err := p.stream(fqn, "exec", &opts, remotecommand.StreamOptions{
+		Stdin:  in,
+		Stdout: out,
+		Stderr: &stderr,
+	})
+	if err != nil && stderr.Len() > 0 {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return err
+}
+
+// WorkingDir returns a container working directory.
+func (p *Pod) WorkingDir(fqn, co string) string {
+	if spec, err := p.GetPodSpec(fqn); err == nil {
+		for _, c := range spec.Containers {
+			if c.Name == co && c.WorkingDir != "" {
+				return c.WorkingDir
+			}
+		}
+	}
+	var out strings.Builder
+	if err := p.Exec(fqn, co, []string{"pwd"}, nil, &out); err == nil {
+		if dir := strings.TrimSpace(out.String()); dir != "" {
+			return dir
+		}
+	}
+
+	return "/"
+}
+
+// Upload copies a local file or directory into a container directory.
+func (p *Pod) Upload(fqn, co, src, dst string, fn ProgressFn) error {
+	r, w := io.Pipe()
+	go func() {
+		_ = w.CloseWithError(TarFiles(src, &progressWriter{w: w, fn: fn}))
+	}()
+	defer r.Close()
+
+	return p.Exec(fqn, co, []string{"tar", "-xmf", "-", "-C", dst}, r, io.Discard)
+}
+
+// Download copies a container file or directory into a local directory.
+func (p *Pod) Download(fqn, co, src, dst string, fn ProgressFn) error {
+	src = path2Remote(src)
+	r, w := io.Pipe()
+	errChan := make(chan error, 1)
+	go func() {
+		err := UntarFiles(&progressReader{r: r, fn: fn}, dst)
+		if err == nil {
+			_, err = io.Copy(io.Discard, r)
+		}
+		_ = r.CloseWithError(err)
+		errChan <- err
+	}()
+	err := p.Exec(fqn, co, []string{"tar", "-cf", "-", "-C", path.Dir(src), path.Base(src)}, nil, w)
+	_ = w.CloseWithError(err)
+	if uerr := <-errChan; err == nil {
+		err = uerr
+	}
+
+	return err
+}
+
+// TarFiles writes a local file or directory as a tar archive.
+func TarFiles(src string, w io.Writer) error {
+	src = filepath.Clean(src)
+	tw := tar.NewWriter(w)
+	base := filepath.Dir(src)
+	err := filepath.Walk(src, func(fpath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() && !info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(base, fpath)
+		if err != nil {
+			return err
+		}
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		f, err := os.Open(fpath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return tw.Close()
+}
+
+// UntarFiles extracts a tar archive into a local directory.
+func UntarFiles(r io.Reader, dst string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		target, err := untarPath(dst, hdr.Name)
+		if err != nil {
+			return err
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := untarFile(tr, target, os.FileMode(hdr.Mode).Perm()); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func untarFile(r io.Reader, target string, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(f, r)
+
+	return err
+}
+
+// untarPath guards against archive entries escaping the destination directory.
+func untarPath(dst, name string) (string, error) {
+	target := filepath.Join(dst, filepath.FromSlash(name))
+	rel, err := filepath.Rel(dst, target)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid archive entry %q", name)
+	}
+
+	return target, nil
+}
+
+func path2Remote(p string) string {
+	p = path.Clean("/" + strings.TrimPrefix(p, "/"))
+	if p == "/" {
+		return "/."
+	}
+
+	return p
+}
+
+type progressWriter struct {
+	w     io.Writer
+	fn    ProgressFn
+	count int64
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.count += int64(n)
+	if p.fn != nil {
+		p.fn(p.count)
+	}
+
+	return n, err
+}
+
+type progressReader struct {
+	r     io.Reader
+	fn    ProgressFn
+	count int64
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.count += int64(n)
+	if p.fn != nil {
+		p.fn(p.count)
+	}
+
+	return n, err
+}
@@ -0,0 +1,61 @@
+package dao
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTarUntarFiles(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(src, "fred", "blee"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(src, "fred", "a.txt"), []byte("hello"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(src, "fred", "blee", "b.txt"), []byte("world"), 0600))
+
+	var buff bytes.Buffer
+	assert.NoError(t, TarFiles(filepath.Join(src, "fred"), &buff))
+	assert.NoError(t, UntarFiles(&buff, dst))
+
+	raw, err := os.ReadFile(filepath.Join(dst, "fred", "a.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(raw))
+	raw, err = os.ReadFile(filepath.Join(dst, "fred", "blee", "b.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "world", string(raw))
+	info, err := os.Stat(filepath.Join(dst, "fred", "blee", "b.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestUntarFilesEscape(t *testing.T) {
+	var buff bytes.Buffer
+	tw := tar.NewWriter(&buff)
+	assert.NoError(t, tw.WriteHeader(&tar.Header{Name: "../../evil", Mode: 0644, Size: 1, Typeflag: tar.TypeReg}))
+	_, err := tw.Write([]byte("x"))
+	assert.NoError(t, err)
+	assert.NoError(t, tw.Close())
+
+	assert.Error(t, UntarFiles(&buff, t.TempDir()))
+}
+
+func TestPath2Remote(t *testing.T) {
+	uu := map[string]struct {
+		p, e string
+	}{
+		"root":     {p: "/", e: "/."},
+		"file":     {p: "/tmp/heap.hprof", e: "/tmp/heap.hprof"},
+		"relative": {p: "etc/conf/", e: "/etc/conf"},
+		"dots":     {p: "/tmp/../etc", e: "/etc"},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, path2Remote(u.p))
+		})
+	}
+}
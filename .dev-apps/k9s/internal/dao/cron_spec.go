@@ -0,0 +1,86 @@
+package dao
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// CronSpec represents a cron schedule parsed the same way the cronjob
+// controller does.
+type CronSpec struct {
+	schedule cron.Schedule
+
+	// Location is the time zone the schedule is evaluated in.
+	Location *time.Location
+}
+
+// ParseCronSpec parses a standard 5 fields cron schedule evaluated in the given
+// location. A TZ= or CRON_TZ= schedule prefix overrides the location.
+func ParseCronSpec(spec string, loc *time.Location) (*CronSpec, error) {
+	spec = strings.TrimSpace(spec)
+	if loc == nil {
+		loc = time.Local
+	}
+	sched, err := cron.ParseStandard(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", spec, err)
+	}
+	if ss, ok := sched.(*cron.SpecSchedule); ok {
+		if !strings.HasPrefix(spec, "TZ=") && !strings.HasPrefix(spec, "CRON_TZ=") {
+			ss.Location = loc
+		}
+		loc = ss.Location
+	}
+
+	return &CronSpec{schedule: sched, Location: loc}, nil
+}
+
+// Next returns the first run strictly after t or a zero time if the schedule
+// never fires.
+func (s *CronSpec) Next(t time.Time) time.Time {
+	n := s.schedule.Next(t)
+	if n.IsZero() {
+		return n
+	}
+
+	return n.In(s.Location)
+}
+
+// NextN returns up to n runs strictly after t.
+func (s *CronSpec) NextN(t time.Time, n int) []time.Time {
+	tt := make([]time.Time, 0, n)
+	for len(tt) < n {
+		if t = s.Next(t); t.IsZero() {
+			break
+		}
+		tt = append(tt, t)
+	}
+
+	return tt
+}
+
+// Between returns the most recent max runs in (from, to] sorted by time along
+// with the total number of runs in that window.
+func (s *CronSpec) Between(from, to time.Time, max int) ([]time.Time, int) {
+	if max <= 0 {
+		return nil, 0
+	}
+	tt := make([]time.Time, 0, max)
+	var n int
+	for t := s.Next(from); !t.IsZero() && !t.After(to); t = s.Next(t) {
+		if len(tt) < max {
+			tt = append(tt, t)
+		} else {
+			tt[n%max] = t
+		}
+		n++
+	}
+	if i := n % max; n > max && i > 0 {
+		tt = append(tt[i:], tt[:i]...)
+	}
+
+	return tt, n
+}
@@ -0,0 +1,147 @@
+package dao
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCronSpecNext(t *testing.T) {
+	// Monday.
+	from := time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
+
+	uu := map[string]struct {
+		spec string
+		e    []time.Time
+	}{
+		"every-minute": {
+			spec: "* * * * *",
+			e: []time.Time{
+				time.Date(2024, 1, 1, 10, 31, 0, 0, time.UTC),
+				time.Date(2024, 1, 1, 10, 32, 0, 0, time.UTC),
+			},
+		},
+		"steps": {
+			spec: "*/20 9-11 * * *",
+			e: []time.Time{
+				time.Date(2024, 1, 1, 10, 40, 0, 0, time.UTC),
+				time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC),
+			},
+		},
+		"nightly": {
+			spec: "@daily",
+			e: []time.Time{
+				time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		"names": {
+			spec: "0 2 * feb mon-wed",
+			e: []time.Time{
+				time.Date(2024, 2, 5, 2, 0, 0, 0, time.UTC),
+				time.Date(2024, 2, 6, 2, 0, 0, 0, time.UTC),
+			},
+		},
+		"every": {
+			spec: "@every 15m",
+			e: []time.Time{
+				time.Date(2024, 1, 1, 10, 45, 0, 0, time.UTC),
+				time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC),
+			},
+		},
+		"dom-or-dow": {
+			spec: "0 0 15 * fri",
+			e: []time.Time{
+				time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		"leap-day": {
+			spec: "0 0 29 2 *",
+			e: []time.Time{
+				time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+				time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		"never": {
+			spec: "0 0 30 2 *",
+			e:    []time.Time{},
+		},
+		"tz-prefix": {
+			spec: "CRON_TZ=Asia/Tokyo 0 9 * * *",
+			e: []time.Time{
+				time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			s, err := ParseCronSpec(u.spec, time.UTC)
+			assert.Nil(t, err)
+			tt := s.NextN(from, len(u.e))
+			assert.Equal(t, len(u.e), len(tt))
+			for i := range tt {
+				assert.True(t, u.e[i].Equal(tt[i]), "expected %v but got %v", u.e[i], tt[i])
+			}
+		})
+	}
+}
+
+func TestCronSpecLocation(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	assert.Nil(t, err)
+	s, err := ParseCronSpec("30 2 * * *", loc)
+	assert.Nil(t, err)
+
+	n := s.Next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, "2024-01-01T17:30:00Z", n.UTC().Format(time.RFC3339))
+	assert.Equal(t, loc, n.Location())
+}
+
+func TestCronSpecBetween(t *testing.T) {
+	s, err := ParseCronSpec("0 * * * *", time.UTC)
+	assert.Nil(t, err)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tt, n := s.Between(from, from.Add(3*time.Hour), 10)
+	assert.Equal(t, 3, len(tt))
+	assert.Equal(t, 3, n)
+
+	tt, n = s.Between(from, from.Add(5*time.Hour), 2)
+	assert.Equal(t, 5, n)
+	assert.Equal(t, []time.Time{from.Add(4 * time.Hour), from.Add(5 * time.Hour)}, tt)
+
+	tt, n = s.Between(from, from.Add(4*time.Hour), 2)
+	assert.Equal(t, 4, n)
+	assert.Equal(t, []time.Time{from.Add(3 * time.Hour), from.Add(4 * time.Hour)}, tt)
+
+	tt, n = s.Between(from, from.Add(59*time.Minute), 10)
+	assert.Equal(t, 0, len(tt))
+	assert.Equal(t, 0, n)
+}
+
+func TestParseCronSpecFail(t *testing.T) {
+	uu := map[string]string{
+		"fields":     "* * * *",
+		"descriptor": "@fortnightly",
+		"range":      "60 * * * *",
+		"inverted":   "0 5-2 * * *",
+		"step":       "*/0 * * * *",
+		"name":       "0 0 * blee *",
+		"dow":        "0 0 * * 8",
+		"sunday-7":   "0 0 * * 7",
+		"tz":         "TZ=Blee/Duh 0 0 * * *",
+	}
+
+	for k := range uu {
+		spec := uu[k]
+		t.Run(k, func(t *testing.T) {
+			_, err := ParseCronSpec(spec, time.UTC)
+			assert.Error(t, err)
+		})
+	}
+}
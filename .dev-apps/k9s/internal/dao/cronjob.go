@@ -8,6 +8,7 @@ import (
 	"github.com/derailed/k9s/internal/client"
 	"github.com/rs/zerolog/log"
 	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
@@ -22,8 +23,9 @@ const (
 )
 
 var (
-	_ Accessor = (*CronJob)(nil)
-	_ Runnable = (*CronJob)(nil)
+	_ Accessor  = (*CronJob)(nil)
+	_ Runnable  = (*CronJob)(nil)
+	_ PodSpecer = (*CronJob)(nil)
 )
 
 // CronJob represents a cronjob K8s resource.
@@ -142,6 +144,21 @@ func (c *CronJob) ToggleSuspend(ctx context.Context, path string) error {
 	return err
 }
 
+// GetPodSpec returns a pod spec given a resource.
+func (c *CronJob) GetPodSpec(path string) (*v1.PodSpec, error) {
+	o, err := c.GetFactory().Get(c.gvr.String(), path, true, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var cj batchv1.CronJob
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(o.(*unstructured.Unstructured).Object, &cj)
+	if err != nil {
+		return nil, errors.New("expecting CronJob resource")
+	}
+	podSpec := cj.Spec.JobTemplate.Spec.Template.Spec
+	return &podSpec, nil
+}
+
 // Scan scans for cluster resource refs.
 func (c *CronJob) Scan(ctx context.Context, gvr, fqn string, wait bool) (Refs, error) {
 	ns, n := client.Namespaced(fqn)
@@ -0,0 +1,251 @@
+package dao
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// CronRunComplete tracks a successful run.
+	CronRunComplete = "Complete"
+
+	// CronRunFailed tracks a failed run.
+	CronRunFailed = "Failed"
+
+	// CronRunActive tracks a running job.
+	CronRunActive = "Running"
+
+	// CronRunPending tracks a job with no running pods yet.
+	CronRunPending = "Pending"
+
+	// CronRunMissed tracks a schedule no job was started for.
+	CronRunMissed = "Missed"
+
+	// cronMissLimit mirrors the controller which emits a TooManyMissedTimes
+	// warning past that many missed start times.
+	cronMissLimit = 100
+
+	// cronMissGrace leaves the controller time to start a due job.
+	cronMissGrace = time.Minute
+
+	cronScheduledAnnotation = "batch.kubernetes.io/cronjob-scheduled-timestamp"
+)
+
+// CronJobRun represents a cronjob run.
+type CronJobRun struct {
+	Job                          string
+	Scheduled, Started, Finished time.Time
+	Status                       string
+}
+
+// Duration returns the run duration so far.
+func (r CronJobRun) Duration(now time.Time) time.Duration {
+	switch {
+	case r.Started.IsZero():
+		return 0
+	case r.Finished.IsZero():
+		return now.Sub(r.Started)
+	default:
+		return r.Finished.Sub(r.Started)
+	}
+}
+
+// CronJobReport represents a cronjob schedule and runs history.
+type CronJobReport struct {
+	Schedule, TimeZone string
+	Suspended          bool
+	Policy             string
+	Deadline           time.Duration
+	LastSchedule       time.Time
+	LastSuccess        time.Time
+
+	// Next lists the upcoming runs.
+	Next []time.Time
+
+	// Missed lists the most recent schedules since the last run no job was started for.
+	Missed []time.Time
+
+	// MissedCount tracks the total number of missed schedules.
+	MissedCount int
+
+	// Runs lists the retained jobs sorted by schedule time.
+	Runs []CronJobRun
+
+	// Issues lists reasons runs may not happen as expected.
+	Issues []string
+}
+
+// Report returns a cronjob schedule and runs report.
+func (c *CronJob) Report(path string, now time.Time, next int) (*CronJobReport, error) {
+	o, err := c.GetFactory().Get(c.GVR(), path, true, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var cj batchv1.CronJob
+	if err := fromUnstructured(o, &cj); err != nil {
+		return nil, err
+	}
+	oo, err := c.GetFactory().List(jobGVR, cj.Namespace, true, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	jj := make([]batchv1.Job, 0, len(oo))
+	for _, o := range oo {
+		var j batchv1.Job
+		if err := fromUnstructured(o, &j); err != nil {
+			return nil, err
+		}
+		jj = append(jj, j)
+	}
+
+	return NewCronJobReport(&cj, jj, now, next), nil
+}
+
+// NewCronJobReport returns a cronjob report given its jobs.
+func NewCronJobReport(cj *batchv1.CronJob, jj []batchv1.Job, now time.Time, next int) *CronJobReport {
+	r := CronJobReport{
+		Schedule:  cj.Spec.Schedule,
+		TimeZone:  "UTC",
+		Suspended: cj.Spec.Suspend != nil && *cj.Spec.Suspend,
+		Policy:    string(cj.Spec.ConcurrencyPolicy),
+	}
+	if r.Policy == "" {
+		r.Policy = string(batchv1.AllowConcurrent)
+	}
+	if cj.Spec.StartingDeadlineSeconds != nil {
+		r.Deadline = time.Duration(*cj.Spec.StartingDeadlineSeconds) * time.Second
+	}
+	if t := cj.Status.LastScheduleTime; t != nil {
+		r.LastSchedule = t.Time
+	}
+	if t := cj.Status.LastSuccessfulTime; t != nil {
+		r.LastSuccess = t.Time
+	}
+	r.Runs = cronJobRuns(cj, jj)
+
+	loc := time.UTC
+	if tz := cj.Spec.TimeZone; tz != nil && *tz != "" {
+		r.TimeZone = *tz
+		l, err := time.LoadLocation(*tz)
+		if err != nil {
+			r.Issues = append(r.Issues, fmt.Sprintf("unknown time zone %q, the controller won't schedule any job", *tz))
+			return &r
+		}
+		loc = l
+	}
+	spec, err := ParseCronSpec(cj.Spec.Schedule, loc)
+	if err != nil {
+		r.Issues = append(r.Issues, err.Error())
+		return &r
+	}
+	r.TimeZone = spec.Location.String()
+	r.Next = spec.NextN(now, next)
+
+	if r.Suspended {
+		r.Issues = append(r.Issues, "cronjob is suspended, no jobs will be scheduled")
+		return &r
+	}
+	from := r.LastSchedule
+	if from.IsZero() {
+		from = cj.CreationTimestamp.Time
+	}
+	grace := cronMissGrace
+	if r.Deadline > 0 {
+		grace = r.Deadline
+	}
+	r.Missed, r.MissedCount = spec.Between(from, now.Add(-grace), cronMissLimit)
+	r.Issues = append(r.Issues, r.diagnose(cj)...)
+
+	return &r
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func (r *CronJobReport) diagnose(cj *batchv1.CronJob) []string {
+	var ii []string
+	switch {
+	case r.MissedCount > cronMissLimit && r.Deadline == 0:
+		ii = append(ii, fmt.Sprintf("more than %d missed schedules, the controller emits a TooManyMissedTimes warning and only starts the most recent one", cronMissLimit))
+	case r.MissedCount > 0 && r.Policy == string(batchv1.ForbidConcurrent) && len(cj.Status.Active) > 0:
+		ii = append(ii, fmt.Sprintf("%d schedule(s) skipped while job %s is still active (concurrencyPolicy Forbid)", r.MissedCount, cj.Status.Active[0].Name))
+	case r.MissedCount > 0 && r.Deadline > 0:
+		ii = append(ii, fmt.Sprintf("%d schedule(s) missed, jobs could not start within the %v starting deadline", r.MissedCount, r.Deadline))
+	case r.MissedCount > 0:
+		ii = append(ii, fmt.Sprintf("%d schedule(s) missed, check the controller manager health and clock skew", r.MissedCount))
+	}
+	if r.LastSchedule.IsZero() && len(r.Runs) == 0 {
+		ii = append(ii, "cronjob was never scheduled")
+	}
+	if n := len(r.Runs); n > 0 && r.Runs[n-1].Status == CronRunFailed {
+		ii = append(ii, fmt.Sprintf("last run %s failed", r.Runs[n-1].Job))
+	}
+
+	return ii
+}
+
+func cronJobRuns(cj *batchv1.CronJob, jj []batchv1.Job) []CronJobRun {
+	rr := make([]CronJobRun, 0, len(jj))
+	for i := range jj {
+		if !isOwnedBy(&jj[i], cj) {
+			continue
+		}
+		rr = append(rr, cronJobRun(&jj[i]))
+	}
+	sort.Slice(rr, func(i, j int) bool {
+		return rr[i].Scheduled.Before(rr[j].Scheduled)
+	})
+
+	return rr
+}
+
+func isOwnedBy(j *batchv1.Job, cj *batchv1.CronJob) bool {
+	for _, r := range j.OwnerReferences {
+		if r.Kind == "CronJob" && (r.UID == cj.UID || r.UID == "" && r.Name == cj.Name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func cronJobRun(j *batchv1.Job) CronJobRun {
+	r := CronJobRun{
+		Job:       client.FQN(j.Namespace, j.Name),
+		Scheduled: j.CreationTimestamp.Time,
+		Status:    CronRunPending,
+	}
+	if s, ok := j.Annotations[cronScheduledAnnotation]; ok {
+		if t, err := time.Parse(time.RFC3339, s); err == nil {
+			r.Scheduled = t
+		}
+	}
+	if j.Status.StartTime != nil {
+		r.Started = j.Status.StartTime.Time
+	}
+	if j.Status.Active > 0 {
+		r.Status = CronRunActive
+	}
+	for _, c := range j.Status.Conditions {
+		if c.Status != v1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			r.Status, r.Finished = CronRunComplete, c.LastTransitionTime.Time
+			if j.Status.CompletionTime != nil {
+				r.Finished = j.Status.CompletionTime.Time
+			}
+		case batchv1.JobFailed:
+			r.Status, r.Finished = CronRunFailed, c.LastTransitionTime.Time
+		}
+	}
+
+	return r
+}
@@ -0,0 +1,187 @@
+package dao
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var cronNow = time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+
+func TestNewCronJobReport(t *testing.T) {
+	uu := map[string]struct {
+		cj     batchv1.CronJob
+		next   string
+		missed int
+		issues []string
+	}{
+		"healthy": {
+			cj:   makeCronJob("0 2 * * *", cronNow.Add(-10*time.Hour), nil),
+			next: "2024-01-11T02:00:00Z",
+		},
+		"tz": {
+			cj: func() batchv1.CronJob {
+				cj := makeCronJob("0 2 * * *", cronNow.Add(-10*time.Hour), nil)
+				tz := "Asia/Tokyo"
+				cj.Spec.TimeZone = &tz
+				return cj
+			}(),
+			next: "2024-01-10T17:00:00Z",
+		},
+		"missed": {
+			cj:     makeCronJob("0 2 * * *", cronNow.Add(-58*time.Hour), nil),
+			next:   "2024-01-11T02:00:00Z",
+			missed: 2,
+			issues: []string{"2 schedule(s) missed, check the controller manager health and clock skew"},
+		},
+		"deadline": {
+			cj: func() batchv1.CronJob {
+				d := int64(3600)
+				return makeCronJob("0 2 * * *", cronNow.Add(-58*time.Hour), &d)
+			}(),
+			next:   "2024-01-11T02:00:00Z",
+			missed: 2,
+			issues: []string{"2 schedule(s) missed, jobs could not start within the 1h0m0s starting deadline"},
+		},
+		"forbid": {
+			cj: func() batchv1.CronJob {
+				cj := makeCronJob("0 2 * * *", cronNow.Add(-34*time.Hour), nil)
+				cj.Spec.ConcurrencyPolicy = batchv1.ForbidConcurrent
+				cj.Status.Active = []v1.ObjectReference{{Name: "fred-1"}}
+				return cj
+			}(),
+			next:   "2024-01-11T02:00:00Z",
+			missed: 1,
+			issues: []string{"1 schedule(s) skipped while job fred-1 is still active (concurrencyPolicy Forbid)"},
+		},
+		"too-many": {
+			cj:     makeCronJob("* * * * *", cronNow.Add(-3*time.Hour), nil),
+			next:   "2024-01-10T12:01:00Z",
+			missed: cronMissLimit,
+			issues: []string{"more than 100 missed schedules, the controller emits a TooManyMissedTimes warning and only starts the most recent one"},
+		},
+		"suspended": {
+			cj: func() batchv1.CronJob {
+				cj := makeCronJob("0 2 * * *", cronNow.Add(-58*time.Hour), nil)
+				s := true
+				cj.Spec.Suspend = &s
+				return cj
+			}(),
+			next:   "2024-01-11T02:00:00Z",
+			issues: []string{"cronjob is suspended, no jobs will be scheduled"},
+		},
+		"bad-tz": {
+			cj: func() batchv1.CronJob {
+				cj := makeCronJob("0 2 * * *", cronNow.Add(-58*time.Hour), nil)
+				tz := "Blee/Duh"
+				cj.Spec.TimeZone = &tz
+				return cj
+			}(),
+			issues: []string{`unknown time zone "Blee/Duh", the controller won't schedule any job`},
+		},
+		"bad-schedule": {
+			cj:     makeCronJob("0 2 * *", cronNow, nil),
+			issues: []string{`invalid schedule "0 2 * *": expected exactly 5 fields, found 4: [0 2 * *]`},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			r := NewCronJobReport(&u.cj, nil, cronNow, 3)
+			if u.next == "" {
+				assert.Equal(t, 0, len(r.Next))
+			} else {
+				assert.Equal(t, 3, len(r.Next))
+				assert.Equal(t, u.next, r.Next[0].UTC().Format(time.RFC3339))
+			}
+			assert.Equal(t, u.missed, len(r.Missed))
+			assert.Equal(t, u.issues, r.Issues)
+		})
+	}
+}
+
+func TestCronJobReportRecentMisses(t *testing.T) {
+	cj := makeCronJob("* * * * *", cronNow.Add(-3*time.Hour), nil)
+	r := NewCronJobReport(&cj, nil, cronNow, 1)
+
+	assert.Equal(t, 179, r.MissedCount)
+	assert.Equal(t, cronMissLimit, len(r.Missed))
+	assert.Equal(t, cronNow.Add(-cronMissGrace), r.Missed[len(r.Missed)-1].UTC())
+	assert.Equal(t, cronNow.Add(-cronMissGrace-(cronMissLimit-1)*time.Minute), r.Missed[0].UTC())
+}
+
+func TestCronJobReportRuns(t *testing.T) {
+	uid := types.UID("cj-1")
+	cj := makeCronJob("0 * * * *", cronNow.Add(-time.Hour), nil)
+	cj.UID = uid
+	start := cronNow.Add(-3 * time.Hour)
+	jj := []batchv1.Job{
+		makeJob("fred-3", uid, start.Add(2*time.Hour), 0, batchv1.JobFailed),
+		makeJob("fred-1", uid, start, 2*time.Minute, batchv1.JobComplete),
+		makeJob("fred-2", uid, start.Add(time.Hour), 0, ""),
+		makeJob("blee-1", "blee", start, time.Minute, batchv1.JobComplete),
+	}
+	jj[0].Annotations = map[string]string{cronScheduledAnnotation: start.Add(2 * time.Hour).Format(time.RFC3339)}
+
+	r := NewCronJobReport(&cj, jj, cronNow, 1)
+	assert.Equal(t, 3, len(r.Runs))
+	assert.Equal(t, []string{"default/fred-1", "default/fred-2", "default/fred-3"}, []string{r.Runs[0].Job, r.Runs[1].Job, r.Runs[2].Job})
+	assert.Equal(t, []string{CronRunComplete, CronRunActive, CronRunFailed}, []string{r.Runs[0].Status, r.Runs[1].Status, r.Runs[2].Status})
+	assert.Equal(t, 2*time.Minute, r.Runs[0].Duration(cronNow))
+	assert.Equal(t, 2*time.Hour, r.Runs[1].Duration(cronNow))
+	assert.Equal(t, []string{"last run default/fred-3 failed"}, r.Issues)
+}
+
+// Helpers...
+
+func makeCronJob(spec string, last time.Time, deadline *int64) batchv1.CronJob {
+	return batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "default",
+			Name:              "fred",
+			CreationTimestamp: metav1.Time{Time: last.Add(-24 * time.Hour)},
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:                spec,
+			StartingDeadlineSeconds: deadline,
+		},
+		Status: batchv1.CronJobStatus{
+			LastScheduleTime: &metav1.Time{Time: last},
+		},
+	}
+}
+
+func makeJob(n string, owner types.UID, created time.Time, d time.Duration, cond batchv1.JobConditionType) batchv1.Job {
+	j := batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "default",
+			Name:              n,
+			CreationTimestamp: metav1.Time{Time: created},
+			OwnerReferences:   []metav1.OwnerReference{{Kind: "CronJob", Name: "fred", UID: owner}},
+		},
+		Status: batchv1.JobStatus{
+			StartTime: &metav1.Time{Time: created},
+		},
+	}
+	switch cond {
+	case "":
+		j.Status.Active = 1
+	case batchv1.JobComplete:
+		j.Status.CompletionTime = &metav1.Time{Time: created.Add(d)}
+		fallthrough
+	default:
+		j.Status.Conditions = []batchv1.JobCondition{{
+			Type:               cond,
+			Status:             v1.ConditionTrue,
+			LastTransitionTime: metav1.Time{Time: created.Add(d)},
+		}}
+	}
+
+	return j
+}
@@ -0,0 +1,111 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/derailed/k9s/internal/client"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DebugContainerPrefix tracks k9s ephemeral debug containers name prefix.
+const DebugContainerPrefix = "k9s-debug"
+
+// DebugOpts represents an ephemeral debug container options.
+type DebugOpts struct {
+	Image   string
+	Target  string
+	Command []string
+	Args    []string
+}
+
+// Debug adds an ephemeral debug container to a running pod and returns its name.
+func (p *Pod) Debug(ctx context.Context, path string, opts DebugOpts) (string, error) {
+	ns, n := client.Namespaced(path)
+	auth, err := p.Client().CanI(ns, "v1/pods:ephemeralcontainers", []string{client.UpdateVerb})
+	if err != nil {
+		return "", err
+	}
+	if !auth {
+		return "", fmt.Errorf("user is not authorized to add ephemeral containers to pod %s", path)
+	}
+
+	dial, err := p.Client().Dial()
+	if err != nil {
+		return "", err
+	}
+	po, err := dial.CoreV1().Pods(ns).Get(ctx, n, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	co := NewEphemeralContainer(DebugContainerName(po), opts)
+	po.Spec.EphemeralContainers = append(po.Spec.EphemeralContainers, co)
+	if _, err := dial.CoreV1().Pods(ns).UpdateEphemeralContainers(ctx, n, po, metav1.UpdateOptions{}); err != nil {
+		return "", err
+	}
+
+	return co.Name, nil
+}
+
+// NewEphemeralContainer returns an interactive ephemeral container spec.
+func NewEphemeralContainer(name string, opts DebugOpts) v1.EphemeralContainer {
+	return v1.EphemeralContainer{
+		EphemeralContainerCommon: v1.EphemeralContainerCommon{
+			Name:                     name,
+			Image:                    opts.Image,
+			Command:                  opts.Command,
+			Args:                     opts.Args,
+			Stdin:                    true,
+			TTY:                      true,
+			TerminationMessagePolicy: v1.TerminationMessageReadFile,
+			ImagePullPolicy:          v1.PullIfNotPresent,
+		},
+		TargetContainerName: opts.Target,
+	}
+}
+
+// DebugContainerName returns the next available debug container name for a pod.
+// Ephemeral containers can not be removed, hence names are never reused.
+func DebugContainerName(po *v1.Pod) string {
+	used := make(map[string]struct{}, len(po.Spec.EphemeralContainers))
+	for _, co := range po.Spec.EphemeralContainers {
+		used[co.Name] = struct{}{}
+	}
+	name := DebugContainerPrefix
+	for i := 1; ; i++ {
+		if _, ok := used[name]; !ok {
+			return name
+		}
+		name = fmt.Sprintf("%s-%d", DebugContainerPrefix, i)
+	}
+}
+
+// EphemeralContainerRunning checks if a given ephemeral container is running.
+// It errors out if the container is not going to start.
+func EphemeralContainerRunning(po *v1.Pod, co string) (bool, error) {
+	for _, s := range po.Status.EphemeralContainerStatuses {
+		if s.Name != co {
+			continue
+		}
+		switch {
+		case s.State.Running != nil:
+			return true, nil
+		case s.State.Terminated != nil:
+			return false, fmt.Errorf("debug container %s terminated: %s", co, s.State.Terminated.Reason)
+		case s.State.Waiting != nil && isFatalWait(s.State.Waiting.Reason):
+			return false, fmt.Errorf("debug container %s failed: %s", co, s.State.Waiting.Message)
+		}
+	}
+
+	return false, nil
+}
+
+func isFatalWait(reason string) bool {
+	switch reason {
+	case "ErrImagePull", "ImagePullBackOff", "InvalidImageName", "CreateContainerError", "CreateContainerConfigError":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,90 @@
+package dao
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestDebugContainerName(t *testing.T) {
+	uu := map[string]struct {
+		cc []string
+		e  string
+	}{
+		"none": {
+			e: "k9s-debug",
+		},
+		"taken": {
+			cc: []string{"k9s-debug"},
+			e:  "k9s-debug-1",
+		},
+		"gap": {
+			cc: []string{"k9s-debug", "k9s-debug-2", "fred"},
+			e:  "k9s-debug-1",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			var po v1.Pod
+			for _, c := range u.cc {
+				po.Spec.EphemeralContainers = append(po.Spec.EphemeralContainers, v1.EphemeralContainer{
+					EphemeralContainerCommon: v1.EphemeralContainerCommon{Name: c},
+				})
+			}
+			assert.Equal(t, u.e, DebugContainerName(&po))
+		})
+	}
+}
+
+func TestNewEphemeralContainer(t *testing.T) {
+	co := NewEphemeralContainer("k9s-debug", DebugOpts{Image: "busybox", Target: "c1", Command: []string{"sh"}})
+
+	assert.Equal(t, "k9s-debug", co.Name)
+	assert.Equal(t, "busybox", co.Image)
+	assert.Equal(t, "c1", co.TargetContainerName)
+	assert.Equal(t, []string{"sh"}, co.Command)
+	assert.True(t, co.Stdin)
+	assert.True(t, co.TTY)
+}
+
+func TestEphemeralContainerRunning(t *testing.T) {
+	uu := map[string]struct {
+		state v1.ContainerState
+		ok    bool
+		err   bool
+	}{
+		"pending": {},
+		"creating": {
+			state: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ContainerCreating"}},
+		},
+		"running": {
+			state: v1.ContainerState{Running: &v1.ContainerStateRunning{}},
+			ok:    true,
+		},
+		"pull-failed": {
+			state: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+			err:   true,
+		},
+		"terminated": {
+			state: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "Error"}},
+			err:   true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			var po v1.Pod
+			po.Status.EphemeralContainerStatuses = []v1.ContainerStatus{
+				{Name: "fred"},
+				{Name: "k9s-debug", State: u.state},
+			}
+			ok, err := EphemeralContainerRunning(&po, "k9s-debug")
+			assert.Equal(t, u.err, err != nil)
+			assert.Equal(t, u.ok, ok)
+		})
+	}
+}
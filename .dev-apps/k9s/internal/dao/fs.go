@@ -0,0 +1,128 @@
+package dao
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxFileView tracks the max number of bytes read when viewing a container file.
+const MaxFileView = 256 * 1024
+
+// FileEntry represents a container file system entry.
+type FileEntry struct {
+	Name string
+	Mode string
+	Size int64
+	Link string
+	Dir  bool
+}
+
+// Path returns the entry full path given its parent directory.
+func (f FileEntry) Path(dir string) string {
+	return path.Join(dir, f.Name)
+}
+
+// ListDir lists a container directory.
+func (p *Pod) ListDir(fqn, co, dir string) ([]FileEntry, error) {
+	var out bytes.Buffer
+	if err := p.Exec(fqn, co, []string{"ls", "-lan", dir}, nil, &out); err != nil {
+		return nil, fmt.Errorf("unable to list %s (the image may not ship ls): %w", dir, err)
+	}
+
+	return ParseLs(out.String()), nil
+}
+
+// Stat returns a container file stats.
+func (p *Pod) Stat(fqn, co, file string) (string, error) {
+	var out bytes.Buffer
+	if err := p.Exec(fqn, co, []string{"stat", file}, nil, &out); err != nil {
+		return "", err
+	}
+
+	return out.String(), nil
+}
+
+// ReadFile reads the head of a container file.
+func (p *Pod) ReadFile(fqn, co, file string) ([]byte, error) {
+	var out bytes.Buffer
+	cmd := []string{"head", "-c", strconv.Itoa(MaxFileView), file}
+	if err := p.Exec(fqn, co, cmd, nil, &out); err != nil {
+		return nil, err
+	}
+
+	return out.Bytes(), nil
+}
+
+// ParseLs parses a long directory listing.
+func ParseLs(out string) []FileEntry {
+	ee := make([]FileEntry, 0, 10)
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		e, ok := parseLsLine(scanner.Text())
+		if !ok || e.Name == "." || e.Name == ".." {
+			continue
+		}
+		ee = append(ee, e)
+	}
+	sort.Slice(ee, func(i, j int) bool {
+		if ee[i].Dir != ee[j].Dir {
+			return ee[i].Dir
+		}
+		return ee[i].Name < ee[j].Name
+	})
+
+	return ee
+}
+
+// IsText checks if a file content is displayable.
+func IsText(b []byte) bool {
+	if bytes.IndexByte(b, 0) >= 0 {
+		return false
+	}
+	// Content may be truncated mid rune.
+	for i := 0; i < utf8.UTFMax && len(b) > 0 && !utf8.Valid(b); i++ {
+		b = b[:len(b)-1]
+	}
+
+	return utf8.Valid(b)
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+// parseLsLine parses an `ls -ln` line ie mode links uid gid size month day time name.
+func parseLsLine(l string) (FileEntry, bool) {
+	ff := strings.Fields(l)
+	if len(ff) < 9 || len(ff[0]) < 10 {
+		return FileEntry{}, false
+	}
+	sizeIdx := 4
+	// Device files list major, minor in lieu of size.
+	if strings.HasSuffix(ff[sizeIdx], ",") {
+		sizeIdx++
+		if len(ff) < 10 {
+			return FileEntry{}, false
+		}
+	}
+	nameIdx := sizeIdx + 4
+	size, _ := strconv.ParseInt(ff[sizeIdx], 10, 64)
+	e := FileEntry{
+		Mode: ff[0],
+		Size: size,
+		Dir:  ff[0][0] == 'd',
+		Name: strings.Join(ff[nameIdx:], " "),
+	}
+	if ff[0][0] == 'l' {
+		if tokens := strings.SplitN(e.Name, " -> ", 2); len(tokens) == 2 {
+			e.Name, e.Link = tokens[0], tokens[1]
+		}
+	}
+
+	return e, true
+}
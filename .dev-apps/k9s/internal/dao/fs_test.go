@@ -0,0 +1,49 @@
+package dao
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLs(t *testing.T) {
+	out := `total 24
+drwxr-xr-x    1 0        0             4096 Oct 18 10:00 .
+drwxr-xr-x    1 0        0             4096 Oct 18 10:00 ..
+-rw-r--r--    1 1000     1000           512 Oct 18 10:00 app.conf
+drwxr-xr-x    2 0        0             4096 Jan  2  2023 bin
+lrwxrwxrwx    1 0        0                7 Oct 18 10:00 lib -> usr/lib
+crw-rw-rw-    1 0        0           1,   3 Oct 18 10:00 null
+-rw-r--r--    1 0        0               10 Oct 18 10:00 my notes.txt
+`
+
+	ee := ParseLs(out)
+	assert.Equal(t, []FileEntry{
+		{Name: "bin", Mode: "drwxr-xr-x", Size: 4096, Dir: true},
+		{Name: "app.conf", Mode: "-rw-r--r--", Size: 512},
+		{Name: "lib", Mode: "lrwxrwxrwx", Size: 7, Link: "usr/lib"},
+		{Name: "my notes.txt", Mode: "-rw-r--r--", Size: 10},
+		{Name: "null", Mode: "crw-rw-rw-", Size: 3},
+	}, ee)
+	assert.Equal(t, "/etc/app.conf", ee[1].Path("/etc"))
+}
+
+func TestIsText(t *testing.T) {
+	uu := map[string]struct {
+		b []byte
+		e bool
+	}{
+		"empty":     {b: []byte{}, e: true},
+		"text":      {b: []byte("hello\nworld"), e: true},
+		"binary":    {b: []byte{0x7f, 'E', 'L', 'F', 0x00}},
+		"invalid":   {b: []byte{0xff, 0xfe, 0xfd, 0xfc, 0xfb, 'a'}},
+		"truncated": {b: append([]byte("caf"), 0xc3), e: true},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, IsText(u.b))
+		})
+	}
+}
@@ -6,13 +6,18 @@ import (
 
 	"github.com/derailed/k9s/internal"
 	"github.com/derailed/k9s/internal/client"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 )
 
-var _ Describer = (*Generic)(nil)
+var (
+	_ Describer   = (*Generic)(nil)
+	_ MetaPatcher = (*Generic)(nil)
+)
 
 // Generic represents a generic resource.
 type Generic struct {
@@ -120,6 +125,47 @@ func (g *Generic) Delete(ctx context.Context, path string, propagation *metav1.D
 	return dial.Namespace(ns).Delete(ctx, n, opts)
 }
 
+// PatchMeta patches a resource labels and annotations.
+func (g *Generic) PatchMeta(ctx context.Context, path string, edits MetaEdits, pt types.PatchType) error {
+	ns, n := client.Namespaced(path)
+	auth, err := g.Client().CanI(ns, g.gvr.String(), []string{client.PatchVerb})
+	if err != nil {
+		return err
+	}
+	if !auth {
+		return fmt.Errorf("user is not authorized to patch %s", path)
+	}
+
+	o, err := g.Get(ctx, path)
+	if err != nil {
+		return err
+	}
+	m, err := meta.Accessor(o)
+	if err != nil {
+		return err
+	}
+	data, err := edits.Patch(pt, m)
+	if err != nil {
+		return err
+	}
+	// Strategic merge patches are not supported on custom resources.
+	if res, err := MetaAccess.MetaFor(g.gvr); err == nil && IsCRD(res) && pt == types.StrategicMergePatchType {
+		pt = types.MergePatchType
+	}
+
+	dial, err := g.dynClient()
+	if err != nil {
+		return err
+	}
+	if client.IsClusterScoped(ns) {
+		_, err = dial.Patch(ctx, n, pt, data, metav1.PatchOptions{})
+		return err
+	}
+	_, err = dial.Namespace(ns).Patch(ctx, n, pt, data, metav1.PatchOptions{})
+
+	return err
+}
+
 func (g *Generic) dynClient() (dynamic.NamespaceableResourceInterface, error) {
 	dial, err := g.Client().DynDial()
 	if err != nil {
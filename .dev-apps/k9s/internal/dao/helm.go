@@ -130,8 +130,14 @@ func (h *Helm) Delete(_ context.Context, path string, _ *metav1.DeletionPropagat
 
 // EnsureHelmConfig return a new configuration.
 func (h *Helm) EnsureHelmConfig(ns string) (*action.Configuration, error) {
+	return h.EnsureHelmConfigWithLog(ns, helmLogger)
+}
+
+// EnsureHelmConfigWithLog return a new configuration reporting helm debug
+// messages to the given logger.
+func (h *Helm) EnsureHelmConfigWithLog(ns string, l action.DebugLog) (*action.Configuration, error) {
 	cfg := new(action.Configuration)
-	err := cfg.Init(h.Client().Config().Flags(), ns, os.Getenv("HELM_DRIVER"), helmLogger)
+	err := cfg.Init(h.Client().Config().Flags(), ns, os.Getenv("HELM_DRIVER"), l)
 
 	return cfg, err
 }
@@ -0,0 +1,268 @@
+package dao
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/derailed/k9s/internal/client"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage/driver"
+)
+
+const helmChartArchiveExt = ".tgz"
+
+// HelmChartOpts represents a local chart deployment options.
+type HelmChartOpts struct {
+	// Chart is a chart directory or packaged chart path.
+	Chart string
+
+	// Release is the release name.
+	Release string
+
+	// Namespace is the release namespace.
+	Namespace string
+
+	// ValuesFile optionally overrides the chart default values.
+	ValuesFile string
+
+	// DryRun renders the release without deploying it.
+	DryRun bool
+
+	// Log reports the deployment progress.
+	Log action.DebugLog
+}
+
+// HelmChartResult represents a local chart deployment outcome.
+type HelmChartResult struct {
+	// Release is the installed, upgraded or rendered release.
+	Release *release.Release
+
+	// Current is the release revision prior to an upgrade.
+	Current *release.Release
+
+	// DryRun indicates the release was rendered but not deployed.
+	DryRun bool
+}
+
+// Upgrade returns true if the deployment upgrades an existing release.
+func (r *HelmChartResult) Upgrade() bool {
+	return r.Current != nil
+}
+
+// Diff returns a unified diff between the current and the new release manifests.
+func (r *HelmChartResult) Diff() (string, error) {
+	if r.Release == nil {
+		return "", errors.New("no release to diff")
+	}
+	cur := r.Current
+	if cur == nil {
+		cur = &release.Release{Name: r.Release.Name, Namespace: r.Release.Namespace}
+	}
+
+	return DiffHelmReleases(cur, r.Release, false)
+}
+
+// String returns a deployment report.
+func (r *HelmChartResult) String() string {
+	if r.Release == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	rel := r.Release
+	status := "unknown"
+	if rel.Info != nil {
+		status = rel.Info.Status.String()
+	}
+	if r.DryRun {
+		status += " (dry run)"
+	}
+	fmt.Fprintf(&b, "release:   %s\n", rel.Name)
+	fmt.Fprintf(&b, "namespace: %s\n", rel.Namespace)
+	if rel.Chart != nil && rel.Chart.Metadata != nil {
+		fmt.Fprintf(&b, "chart:     %s-%s\n", rel.Chart.Metadata.Name, rel.Chart.Metadata.Version)
+	}
+	fmt.Fprintf(&b, "revision:  %d\n", rel.Version)
+	fmt.Fprintf(&b, "status:    %s\n", status)
+	if len(rel.Hooks) > 0 {
+		b.WriteString("hooks:\n")
+		w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+		for _, h := range rel.Hooks {
+			ee := make([]string, 0, len(h.Events))
+			for _, e := range h.Events {
+				ee = append(ee, e.String())
+			}
+			phase := h.LastRun.Phase.String()
+			if phase == "" {
+				phase = "-"
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", h.Name, h.Kind, strings.Join(ee, ","), phase)
+		}
+		_ = w.Flush()
+	}
+	if rel.Info != nil && rel.Info.Notes != "" {
+		b.WriteString("notes:\n" + indentLines(rel.Info.Notes) + "\n")
+	}
+	if r.DryRun {
+		b.WriteString("manifest:\n" + indentLines(rel.Manifest) + "\n")
+	}
+
+	return b.String()
+}
+
+// HelmChart deploys local helm charts.
+type HelmChart struct {
+	NonResource
+}
+
+// Deploy installs a chart or upgrades its release if it already exists.
+func (h *HelmChart) Deploy(opts HelmChartOpts) (*HelmChartResult, error) {
+	cfg, err := h.helmConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return DeployHelmChart(cfg, opts)
+}
+
+// Values returns the values to seed a chart deployment with.
+func (h *HelmChart) Values(opts HelmChartOpts) ([]byte, error) {
+	cfg, err := h.helmConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return HelmChartValues(cfg, opts)
+}
+
+// IsHelmChart checks if a path is a chart directory or a packaged chart.
+func IsHelmChart(path string) bool {
+	if filepath.Ext(path) == helmChartArchiveExt {
+		fi, err := os.Stat(path)
+		return err == nil && !fi.IsDir()
+	}
+	ok, _ := chartutil.IsChartDir(path)
+
+	return ok
+}
+
+// HelmChartName returns a chart name or the path base name when the chart
+// can't be loaded.
+func HelmChartName(path string) string {
+	ch, err := loader.Load(path)
+	if err != nil || ch.Metadata == nil || ch.Metadata.Name == "" {
+		return strings.TrimSuffix(filepath.Base(path), helmChartArchiveExt)
+	}
+
+	return ch.Metadata.Name
+}
+
+// DeployHelmChart installs a local chart or upgrades its release if it already
+// exists.
+func DeployHelmChart(cfg *action.Configuration, opts HelmChartOpts) (*HelmChartResult, error) {
+	if opts.Release == "" {
+		return nil, errors.New("a release name is required")
+	}
+	ch, err := loader.Load(opts.Chart)
+	if err != nil {
+		return nil, err
+	}
+	vals, err := helmChartValues(opts.ValuesFile)
+	if err != nil {
+		return nil, err
+	}
+	cur, err := currentRelease(cfg, opts.Release)
+	if err != nil {
+		return nil, err
+	}
+
+	res := HelmChartResult{Current: cur, DryRun: opts.DryRun}
+	if cur == nil {
+		i := action.NewInstall(cfg)
+		i.Namespace, i.ReleaseName, i.DryRun = opts.Namespace, opts.Release, opts.DryRun
+		res.Release, err = i.Run(ch, vals)
+	} else {
+		u := action.NewUpgrade(cfg)
+		u.Namespace, u.DryRun = opts.Namespace, opts.DryRun
+		res.Release, err = u.Run(opts.Release, ch, vals)
+	}
+
+	return &res, err
+}
+
+// HelmChartValues returns the deployed release user supplied values or the
+// chart default values when there are none.
+func HelmChartValues(cfg *action.Configuration, opts HelmChartOpts) ([]byte, error) {
+	cur, err := currentRelease(cfg, opts.Release)
+	if err != nil {
+		return nil, err
+	}
+	if cur != nil && len(cur.Config) > 0 {
+		vals, err := helmValues(cur)
+		return []byte(vals), err
+	}
+	ch, err := loader.Load(opts.Chart)
+	if err != nil {
+		return nil, err
+	}
+
+	return defaultValues(ch), nil
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func (h *HelmChart) helmConfig(opts HelmChartOpts) (*action.Configuration, error) {
+	var hh Helm
+	hh.Init(h.GetFactory(), client.NewGVR("helm"))
+	if opts.Log == nil {
+		return hh.EnsureHelmConfig(opts.Namespace)
+	}
+
+	return hh.EnsureHelmConfigWithLog(opts.Namespace, opts.Log)
+}
+
+func currentRelease(cfg *action.Configuration, n string) (*release.Release, error) {
+	r, err := action.NewGet(cfg).Run(n)
+	if errors.Is(err, driver.ErrReleaseNotFound) {
+		return nil, nil
+	}
+
+	return r, err
+}
+
+func helmChartValues(path string) (map[string]interface{}, error) {
+	if path == "" {
+		return map[string]interface{}{}, nil
+	}
+
+	return chartutil.ReadValuesFile(path)
+}
+
+// defaultValues returns the chart values file as is to preserve its comments.
+func defaultValues(ch *chart.Chart) []byte {
+	for _, f := range ch.Raw {
+		if f.Name == chartutil.ValuesfileName {
+			return f.Data
+		}
+	}
+
+	return nil
+}
+
+func indentLines(s string) string {
+	ll := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	for i, l := range ll {
+		ll[i] = "  " + l
+	}
+
+	return strings.Join(ll, "\n")
+}
@@ -0,0 +1,154 @@
+package dao
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chartutil"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+)
+
+func TestIsHelmChart(t *testing.T) {
+	dir := t.TempDir()
+	chart := makeChart(t, dir)
+	archive := filepath.Join(dir, "fred-0.1.0.tgz")
+	assert.Nil(t, os.WriteFile(archive, []byte("blee"), 0600))
+
+	uu := map[string]struct {
+		path string
+		e    bool
+	}{
+		"chart": {
+			path: chart,
+			e:    true,
+		},
+		"archive": {
+			path: archive,
+			e:    true,
+		},
+		"templates": {
+			path: filepath.Join(chart, "templates"),
+		},
+		"missing": {
+			path: filepath.Join(dir, "zorg.tgz"),
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, IsHelmChart(u.path))
+		})
+	}
+}
+
+func TestDeployHelmChart(t *testing.T) {
+	dir := t.TempDir()
+	cfg, chart := makeChartConfig(), makeChart(t, dir)
+	opts := HelmChartOpts{Chart: chart, Release: "fred", Namespace: "default", DryRun: true}
+
+	res, err := DeployHelmChart(cfg, opts)
+	assert.Nil(t, err)
+	assert.False(t, res.Upgrade())
+	assert.Contains(t, res.String(), "status:    pending-install (dry run)\n")
+	assert.Contains(t, res.String(), "manifest:\n  ---\n")
+	_, err = action.NewGet(cfg).Run("fred")
+	assert.ErrorIs(t, err, driver.ErrReleaseNotFound)
+
+	opts.DryRun = false
+	res, err = DeployHelmChart(cfg, opts)
+	assert.Nil(t, err)
+	assert.False(t, res.Upgrade())
+	assert.Equal(t, 1, res.Release.Version)
+	s := res.String()
+	assert.Contains(t, s, "status:    deployed\n")
+	assert.Contains(t, s, "  fred-test  Pod  test  -\n")
+	assert.Contains(t, s, "notes:\n  Enjoy fred!\n")
+	assert.NotContains(t, s, "manifest:")
+
+	vals, err := HelmChartValues(cfg, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, "# Number of replicas.\nreplicas: 1\n", string(vals))
+
+	opts.ValuesFile = filepath.Join(dir, "values.yaml")
+	assert.Nil(t, os.WriteFile(opts.ValuesFile, []byte("replicas: 3\n"), 0600))
+	opts.DryRun = true
+	res, err = DeployHelmChart(cfg, opts)
+	assert.Nil(t, err)
+	assert.True(t, res.Upgrade())
+	diff, err := res.Diff()
+	assert.Nil(t, err)
+	assert.Contains(t, diff, "-  replicas: \"1\"\n+  replicas: \"3\"\n")
+
+	opts.DryRun = false
+	res, err = DeployHelmChart(cfg, opts)
+	assert.Nil(t, err)
+	assert.True(t, res.Upgrade())
+	assert.Equal(t, 2, res.Release.Version)
+
+	vals, err = HelmChartValues(cfg, opts)
+	assert.Nil(t, err)
+	assert.Equal(t, "replicas: 3\n", string(vals))
+}
+
+func TestDeployHelmChartFail(t *testing.T) {
+	uu := map[string]struct {
+		opts HelmChartOpts
+	}{
+		"no-release": {
+			opts: HelmChartOpts{Chart: "testdata"},
+		},
+		"no-chart": {
+			opts: HelmChartOpts{Chart: "testdata/zorg", Release: "fred"},
+		},
+		"no-values": {
+			opts: HelmChartOpts{Chart: makeChart(t, t.TempDir()), Release: "fred", ValuesFile: "testdata/zorg.yaml"},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			_, err := DeployHelmChart(makeChartConfig(), u.opts)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// Helpers...
+
+func makeChartConfig() *action.Configuration {
+	mem := driver.NewMemory()
+	mem.SetNamespace("default")
+
+	return &action.Configuration{
+		Releases:     storage.Init(mem),
+		KubeClient:   &kubefake.PrintingKubeClient{Out: io.Discard},
+		Capabilities: chartutil.DefaultCapabilities,
+		Log:          func(string, ...interface{}) {},
+	}
+}
+
+func makeChart(t *testing.T, dir string) string {
+	ff := map[string]string{
+		"Chart.yaml":          "apiVersion: v2\nname: fred\nversion: 0.1.0\n",
+		"values.yaml":         "# Number of replicas.\nreplicas: 1\n",
+		"templates/cm.yaml":   "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: fred\ndata:\n  replicas: {{ .Values.replicas | quote }}\n",
+		"templates/test.yaml": "apiVersion: v1\nkind: Pod\nmetadata:\n  name: fred-test\n  annotations:\n    helm.sh/hook: test\nspec:\n  containers:\n  - name: c1\n    image: busybox\n",
+		"templates/NOTES.txt": "Enjoy {{ .Release.Name }}!\n",
+	}
+	root := filepath.Join(dir, "fred")
+	for n, s := range ff {
+		p := filepath.Join(root, n)
+		assert.Nil(t, os.MkdirAll(filepath.Dir(p), 0700))
+		assert.Nil(t, os.WriteFile(p, []byte(s), 0600))
+	}
+
+	return root
+}
@@ -0,0 +1,190 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/pmezard/go-difflib/difflib"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/release"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// HelmTimeout represents Helm's default timeout for hooks and waits.
+const HelmTimeout = 5 * time.Minute
+
+var _ Accessor = (*HelmHistory)(nil)
+
+// HelmHistory represents a helm release history.
+type HelmHistory struct {
+	NonResource
+}
+
+// List returns the revisions of the release in context.
+func (h *HelmHistory) List(ctx context.Context, _ string) ([]runtime.Object, error) {
+	fqn, ok := ctx.Value(internal.KeyPath).(string)
+	if !ok || fqn == "" {
+		return nil, fmt.Errorf("no context path for %q", h.gvr)
+	}
+
+	rr, err := h.History(fqn)
+	if err != nil {
+		return nil, err
+	}
+	oo := make([]runtime.Object, 0, len(rr))
+	for _, r := range rr {
+		oo = append(oo, render.HelmRes{Release: r})
+	}
+
+	return oo, nil
+}
+
+// History returns a release revisions sorted by revision number.
+func (h *HelmHistory) History(fqn string) ([]*release.Release, error) {
+	ns, n := client.Namespaced(fqn)
+	cfg, err := h.helmConfig(ns)
+	if err != nil {
+		return nil, err
+	}
+
+	return HelmReleaseHistory(cfg, n)
+}
+
+// Revision returns a given release revision.
+func (h *HelmHistory) Revision(fqn string, rev int) (*release.Release, error) {
+	ns, n := client.Namespaced(fqn)
+	cfg, err := h.helmConfig(ns)
+	if err != nil {
+		return nil, err
+	}
+	get := action.NewGet(cfg)
+	get.Version = rev
+
+	return get.Run(n)
+}
+
+// Notes returns a release revision notes.
+func (h *HelmHistory) Notes(fqn string, rev int) (string, error) {
+	r, err := h.Revision(fqn, rev)
+	if err != nil {
+		return "", err
+	}
+	if r.Info == nil || r.Info.Notes == "" {
+		return fmt.Sprintf("No release notes for %s revision %d", fqn, rev), nil
+	}
+
+	return r.Info.Notes, nil
+}
+
+// Diff returns a unified diff of the manifests or values of two release revisions.
+func (h *HelmHistory) Diff(fqn string, from, to int, values bool) (string, error) {
+	f, err := h.Revision(fqn, from)
+	if err != nil {
+		return "", err
+	}
+	t, err := h.Revision(fqn, to)
+	if err != nil {
+		return "", err
+	}
+
+	return DiffHelmReleases(f, t, values)
+}
+
+// Rollback rolls a release back to a given revision.
+func (h *HelmHistory) Rollback(fqn string, rev int) error {
+	ns, n := client.Namespaced(fqn)
+	cfg, err := h.helmConfig(ns)
+	if err != nil {
+		return err
+	}
+
+	return RollbackHelmRelease(cfg, n, rev)
+}
+
+// HelmReleaseHistory returns a release revisions sorted by revision number.
+func HelmReleaseHistory(cfg *action.Configuration, n string) ([]*release.Release, error) {
+	rr, err := action.NewHistory(cfg).Run(n)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(rr, func(i, j int) bool {
+		return rr[i].Version < rr[j].Version
+	})
+
+	return rr, nil
+}
+
+// RollbackHelmRelease rolls a release back to a given revision. The rollback
+// hooks and waits use Helm's default timeout.
+func RollbackHelmRelease(cfg *action.Configuration, n string, rev int) error {
+	if rev <= 0 {
+		return errors.New("a revision is required")
+	}
+	rb := action.NewRollback(cfg)
+	rb.Version = rev
+	rb.Timeout = HelmTimeout
+
+	return rb.Run(n)
+}
+
+// DiffHelmReleases returns a unified diff of two releases manifests or user
+// supplied values.
+func DiffHelmReleases(from, to *release.Release, values bool) (string, error) {
+	f, t := from.Manifest, to.Manifest
+	if values {
+		var err error
+		if f, err = helmValues(from); err != nil {
+			return "", err
+		}
+		if t, err = helmValues(to); err != nil {
+			return "", err
+		}
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        diffLines(f),
+		B:        diffLines(t),
+		FromFile: "revision " + strconv.Itoa(from.Version),
+		ToFile:   "revision " + strconv.Itoa(to.Version),
+		Context:  3,
+	})
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func (h *HelmHistory) helmConfig(ns string) (*action.Configuration, error) {
+	var hh Helm
+	hh.Init(h.GetFactory(), client.NewGVR("helm"))
+
+	return hh.EnsureHelmConfig(ns)
+}
+
+func diffLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	return difflib.SplitLines(strings.TrimSuffix(s, "\n"))
+}
+
+func helmValues(r *release.Release) (string, error) {
+	if len(r.Config) == 0 {
+		return "", nil
+	}
+	raw, err := yaml.Marshal(r.Config)
+	if err != nil {
+		return "", err
+	}
+
+	return string(raw), nil
+}
@@ -0,0 +1,115 @@
+package dao
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	helmtime "helm.sh/helm/v3/pkg/time"
+)
+
+func TestDiffHelmReleases(t *testing.T) {
+	r1 := makeRelease(1, release.StatusSuperseded, "kind: Service\nname: fred\n", map[string]interface{}{"replicas": 1})
+	r2 := makeRelease(2, release.StatusDeployed, "kind: Service\nname: blee\n", map[string]interface{}{"replicas": 2})
+
+	uu := map[string]struct {
+		from, to *release.Release
+		values   bool
+		e        string
+	}{
+		"manifest": {
+			from: r1,
+			to:   r2,
+			e:    "--- revision 1\n+++ revision 2\n@@ -1,2 +1,2 @@\n kind: Service\n-name: fred\n+name: blee\n",
+		},
+		"values": {
+			from:   r1,
+			to:     r2,
+			values: true,
+			e:      "--- revision 1\n+++ revision 2\n@@ -1 +1 @@\n-replicas: 1\n+replicas: 2\n",
+		},
+		"same": {
+			from: r1,
+			to:   r1,
+		},
+		"no-values": {
+			from:   makeRelease(1, release.StatusSuperseded, "", nil),
+			to:     r2,
+			values: true,
+			e:      "--- revision 1\n+++ revision 2\n@@ -0,0 +1 @@\n+replicas: 2\n",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			diff, err := DiffHelmReleases(u.from, u.to, u.values)
+			assert.Nil(t, err)
+			assert.Equal(t, u.e, diff)
+		})
+	}
+}
+
+func TestHelmHistoryRollback(t *testing.T) {
+	cfg := makeHelmConfig(t,
+		makeRelease(2, release.StatusDeployed, "name: blee\n", nil),
+		makeRelease(1, release.StatusSuperseded, "name: fred\n", nil),
+	)
+
+	rr, err := HelmReleaseHistory(cfg, "fred")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(rr))
+	assert.Equal(t, 1, rr[0].Version)
+
+	assert.Error(t, RollbackHelmRelease(cfg, "fred", 0))
+	assert.Nil(t, RollbackHelmRelease(cfg, "fred", 1))
+
+	rr, err = HelmReleaseHistory(cfg, "fred")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(rr))
+	assert.Equal(t, release.StatusDeployed, rr[2].Info.Status)
+	assert.Equal(t, "name: fred\n", rr[2].Manifest)
+	assert.Equal(t, release.StatusSuperseded, rr[1].Info.Status)
+}
+
+// Helpers...
+
+func makeHelmConfig(t *testing.T, rr ...*release.Release) *action.Configuration {
+	cfg := action.Configuration{
+		Releases:     storage.Init(driver.NewMemory()),
+		KubeClient:   &kubefake.PrintingKubeClient{Out: io.Discard},
+		Capabilities: chartutil.DefaultCapabilities,
+		Log:          func(string, ...interface{}) {},
+	}
+	for _, r := range rr {
+		assert.Nil(t, cfg.Releases.Create(r))
+	}
+
+	return &cfg
+}
+
+func makeRelease(rev int, status release.Status, manifest string, vals map[string]interface{}) *release.Release {
+	return &release.Release{
+		Name:      "fred",
+		Namespace: "default",
+		Version:   rev,
+		Manifest:  manifest,
+		Config:    vals,
+		Info: &release.Info{
+			Status:       status,
+			Description:  "Upgrade complete",
+			LastDeployed: helmtime.Time{Time: time.Now()},
+		},
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "fred", Version: "1.0.0", AppVersion: "2.0.0", APIVersion: chart.APIVersionV2},
+		},
+	}
+}
@@ -0,0 +1,356 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/rs/zerolog/log"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	latestTag   = "latest"
+	digestDrift = "digest"
+	tagDrift    = "tag"
+)
+
+// imageGVRs tracks resources scanned for container images.
+var imageGVRs = []string{
+	"apps/v1/deployments",
+	"apps/v1/statefulsets",
+	"apps/v1/daemonsets",
+	"batch/v1/jobs",
+	"batch/v1/cronjobs",
+	"v1/pods",
+}
+
+var (
+	_ Accessor = (*Image)(nil)
+	_ Accessor = (*ImageConsumer)(nil)
+)
+
+// Image represents a cluster container images inventory.
+type Image struct {
+	NonResource
+}
+
+// List returns the container images referenced in a given namespace.
+func (i *Image) List(ctx context.Context, ns string) ([]runtime.Object, error) {
+	inv, err := scanImages(i.Factory, ns)
+	if err != nil {
+		return nil, err
+	}
+	ii := inv.Images()
+	oo := make([]runtime.Object, 0, len(ii))
+	for _, img := range ii {
+		oo = append(oo, img)
+	}
+
+	return oo, nil
+}
+
+// ImageConsumer represents the workloads using a given container image.
+type ImageConsumer struct {
+	NonResource
+}
+
+// List returns the workloads using the image in context.
+func (i *ImageConsumer) List(ctx context.Context, ns string) ([]runtime.Object, error) {
+	img, ok := ctx.Value(internal.KeyPath).(string)
+	if !ok {
+		return nil, fmt.Errorf("no context image for %q", i.gvr)
+	}
+	inv, err := scanImages(i.Factory, ns)
+	if err != nil {
+		return nil, err
+	}
+	for _, res := range inv.Images() {
+		if res.Image != img {
+			continue
+		}
+		oo := make([]runtime.Object, 0, len(res.Consumers))
+		for _, c := range res.Consumers {
+			oo = append(oo, c)
+		}
+		return oo, nil
+	}
+
+	return nil, nil
+}
+
+// ParseImage splits a container image reference into repository, tag and digest.
+func ParseImage(ref string) (repo, tag, digest string) {
+	repo = ref
+	if i := strings.Index(repo, "@"); i >= 0 {
+		repo, digest = repo[:i], repo[i+1:]
+	}
+	if i := strings.LastIndex(repo, ":"); i > strings.LastIndex(repo, "/") {
+		repo, tag = repo[:i], repo[i+1:]
+	}
+	if tag == "" && digest == "" {
+		tag = latestTag
+	}
+
+	return
+}
+
+// ImageInventory aggregates container images referenced by pod specs and running pods.
+type ImageInventory struct {
+	images    map[string]*render.ImageRes
+	consumers map[string]map[string]*render.ImageConsumerRes
+	tags      map[string]map[string]map[string]struct{}
+	digests   map[string]map[string]map[string]struct{}
+}
+
+// NewImageInventory returns a new inventory.
+func NewImageInventory() *ImageInventory {
+	return &ImageInventory{
+		images:    make(map[string]*render.ImageRes),
+		consumers: make(map[string]map[string]*render.ImageConsumerRes),
+		tags:      make(map[string]map[string]map[string]struct{}),
+		digests:   make(map[string]map[string]map[string]struct{}),
+	}
+}
+
+// AddSpec records the images referenced by a workload pod spec.
+func (i *ImageInventory) AddSpec(gvr, ns, n string, spec *v1.PodSpec) {
+	for _, co := range allContainers(spec) {
+		i.add(gvr, ns, n, co.Name, co.Image, string(co.ImagePullPolicy))
+	}
+}
+
+// AddPod records the images and digests actually running in a pod.
+func (i *ImageInventory) AddPod(po *v1.Pod) {
+	gvr, n := podWorkload(po)
+	images := make(map[string]v1.Container)
+	for _, co := range allContainers(&po.Spec) {
+		images[co.Name] = co
+	}
+	ss := make([]v1.ContainerStatus, 0, len(po.Status.InitContainerStatuses)+len(po.Status.ContainerStatuses))
+	ss = append(ss, po.Status.InitContainerStatuses...)
+	ss = append(ss, po.Status.ContainerStatuses...)
+	for _, s := range ss {
+		co, ok := images[s.Name]
+		if !ok {
+			continue
+		}
+		c := i.add(gvr, po.Namespace, n, co.Name, co.Image, string(co.ImagePullPolicy))
+		if d := imageDigest(s.ImageID); d != "" {
+			c.Digests = appendUnique(c.Digests, d)
+			key := imageKey(co.Image)
+			wk := workloadKey(gvr, po.Namespace, n)
+			if i.digests[key] == nil {
+				i.digests[key] = make(map[string]map[string]struct{})
+			}
+			if i.digests[key][wk] == nil {
+				i.digests[key][wk] = make(map[string]struct{})
+			}
+			i.digests[key][wk][d] = struct{}{}
+		}
+	}
+}
+
+// Images returns the inventory images sorted by name.
+func (i *ImageInventory) Images() []render.ImageRes {
+	rr := make([]render.ImageRes, 0, len(i.images))
+	for key, img := range i.images {
+		res := *img
+		res.Consumers = nil
+		for wk, c := range i.consumers[key] {
+			sort.Strings(c.Containers)
+			sort.Strings(c.Digests)
+			res.Consumers = append(res.Consumers, *c)
+			res.Namespaces = appendUnique(res.Namespaces, c.Namespace)
+			if c.PullPolicy != "" {
+				res.PullPolicies = appendUnique(res.PullPolicies, c.PullPolicy)
+			}
+			for _, d := range c.Digests {
+				res.Digests = appendUnique(res.Digests, d)
+			}
+			if len(i.digests[key][wk]) > 1 {
+				res.Drift = appendUnique(res.Drift, digestDrift)
+			}
+			if len(i.tags[wk][res.Repo]) > 1 {
+				res.Drift = appendUnique(res.Drift, tagDrift)
+			}
+		}
+		sort.Slice(res.Consumers, func(a, b int) bool {
+			ca, cb := res.Consumers[a], res.Consumers[b]
+			if ca.Namespace != cb.Namespace {
+				return ca.Namespace < cb.Namespace
+			}
+			if ca.Name != cb.Name {
+				return ca.Name < cb.Name
+			}
+			return ca.GVR < cb.GVR
+		})
+		sort.Strings(res.Namespaces)
+		sort.Strings(res.PullPolicies)
+		sort.Strings(res.Digests)
+		sort.Strings(res.Drift)
+		rr = append(rr, res)
+	}
+	sort.Slice(rr, func(a, b int) bool {
+		return rr[a].Image < rr[b].Image
+	})
+
+	return rr
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func scanImages(f Factory, ns string) (*ImageInventory, error) {
+	inv := NewImageInventory()
+	for _, gvr := range imageGVRs {
+		acc, err := AccessorFor(f, client.NewGVR(gvr))
+		if err != nil {
+			return nil, err
+		}
+		ps, ok := acc.(PodSpecer)
+		if !ok {
+			return nil, fmt.Errorf("expecting a PodSpecer for %q but got %T", gvr, acc)
+		}
+		oo, err := f.List(gvr, ns, false, labels.Everything())
+		if err != nil {
+			log.Warn().Err(err).Msgf("Image scan skipped %q", gvr)
+			continue
+		}
+		for _, o := range oo {
+			u, ok := o.(*unstructured.Unstructured)
+			if !ok {
+				return nil, fmt.Errorf("expecting unstructured resource but got %T", o)
+			}
+			if gvr == "v1/pods" {
+				var po v1.Pod
+				if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &po); err != nil {
+					return nil, err
+				}
+				inv.AddPod(&po)
+				if metav1.GetControllerOf(&po) != nil {
+					continue
+				}
+			}
+			fqn := client.FQN(u.GetNamespace(), u.GetName())
+			spec, err := ps.GetPodSpec(fqn)
+			if err != nil {
+				log.Warn().Err(err).Msgf("Image scan skipped %s %q", gvr, fqn)
+				continue
+			}
+			inv.AddSpec(gvr, u.GetNamespace(), u.GetName(), spec)
+		}
+	}
+
+	return inv, nil
+}
+
+func (i *ImageInventory) add(gvr, ns, n, co, image, policy string) *render.ImageConsumerRes {
+	key := imageKey(image)
+	repo, tag, _ := ParseImage(image)
+	if _, ok := i.images[key]; !ok {
+		i.images[key] = &render.ImageRes{
+			Image:  key,
+			Repo:   repo,
+			Tag:    tag,
+			Latest: tag == latestTag,
+		}
+		i.consumers[key] = make(map[string]*render.ImageConsumerRes)
+	}
+
+	wk := workloadKey(gvr, ns, n)
+	c, ok := i.consumers[key][wk]
+	if !ok {
+		c = &render.ImageConsumerRes{GVR: gvr, Namespace: ns, Name: n, PullPolicy: policy}
+		i.consumers[key][wk] = c
+	}
+	c.Containers = appendUnique(c.Containers, co)
+
+	if i.tags[wk] == nil {
+		i.tags[wk] = make(map[string]map[string]struct{})
+	}
+	if i.tags[wk][repo] == nil {
+		i.tags[wk][repo] = make(map[string]struct{})
+	}
+	i.tags[wk][repo][tag] = struct{}{}
+
+	return c
+}
+
+func imageKey(image string) string {
+	repo, tag, digest := ParseImage(image)
+	key := repo
+	if tag != "" {
+		key += ":" + tag
+	}
+	if digest != "" {
+		key += "@" + digest
+	}
+
+	return key
+}
+
+func imageDigest(id string) string {
+	if i := strings.LastIndex(id, "@"); i >= 0 {
+		return id[i+1:]
+	}
+	if strings.HasPrefix(id, "sha256:") {
+		return id
+	}
+
+	return ""
+}
+
+func workloadKey(gvr, ns, n string) string {
+	return gvr + ":" + client.FQN(ns, n)
+}
+
+// podWorkload returns the workload owning a given pod.
+func podWorkload(po metav1.Object) (string, string) {
+	ref := metav1.GetControllerOf(po)
+	if ref == nil {
+		return "v1/pods", po.GetName()
+	}
+	switch ref.Kind {
+	case "ReplicaSet":
+		hash := po.GetLabels()[appsv1.DefaultDeploymentUniqueLabelKey]
+		if hash != "" && strings.HasSuffix(ref.Name, "-"+hash) {
+			return "apps/v1/deployments", strings.TrimSuffix(ref.Name, "-"+hash)
+		}
+		return "apps/v1/replicasets", ref.Name
+	case "StatefulSet":
+		return "apps/v1/statefulsets", ref.Name
+	case "DaemonSet":
+		return "apps/v1/daemonsets", ref.Name
+	case "Job":
+		return "batch/v1/jobs", ref.Name
+	default:
+		return "v1/pods", po.GetName()
+	}
+}
+
+func allContainers(spec *v1.PodSpec) []v1.Container {
+	cc := make([]v1.Container, 0, len(spec.InitContainers)+len(spec.Containers))
+	cc = append(cc, spec.InitContainers...)
+
+	return append(cc, spec.Containers...)
+}
+
+func appendUnique(ss []string, s string) []string {
+	for _, v := range ss {
+		if v == s {
+			return ss
+		}
+	}
+
+	return append(ss, s)
+}
@@ -0,0 +1,220 @@
+package dao
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestParseImage(t *testing.T) {
+	uu := map[string]struct {
+		ref, repo, tag, digest string
+	}{
+		"plain":    {ref: "nginx", repo: "nginx", tag: "latest"},
+		"tag":      {ref: "nginx:1.25", repo: "nginx", tag: "1.25"},
+		"registry": {ref: "reg.io:5000/fred/blee", repo: "reg.io:5000/fred/blee", tag: "latest"},
+		"full":     {ref: "reg.io:5000/fred/blee:1.0@sha256:abc", repo: "reg.io:5000/fred/blee", tag: "1.0", digest: "sha256:abc"},
+		"digest":   {ref: "fred@sha256:abc", repo: "fred", digest: "sha256:abc"},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			repo, tag, digest := ParseImage(u.ref)
+			assert.Equal(t, u.repo, repo)
+			assert.Equal(t, u.tag, tag)
+			assert.Equal(t, u.digest, digest)
+		})
+	}
+}
+
+func TestImageInventory(t *testing.T) {
+	inv := NewImageInventory()
+	inv.AddSpec("apps/v1/deployments", "blee", "fred", &v1.PodSpec{
+		InitContainers: []v1.Container{{Name: "init", Image: "busybox", ImagePullPolicy: v1.PullAlways}},
+		Containers:     []v1.Container{{Name: "fred", Image: "fred:2", ImagePullPolicy: v1.PullIfNotPresent}},
+	})
+	inv.AddSpec("v1/pods", "zorg", "duh", &v1.PodSpec{
+		Containers: []v1.Container{{Name: "duh", Image: "busybox:latest", ImagePullPolicy: v1.PullAlways}},
+	})
+	inv.AddPod(makeImagePod("fred-abc-1", "fred:2", "docker-pullable://fred@sha256:111"))
+	inv.AddPod(makeImagePod("fred-abc-2", "fred:2", "docker-pullable://fred@sha256:222"))
+	inv.AddPod(makeImagePod("fred-abc-3", "fred:1", "docker-pullable://fred@sha256:000"))
+
+	ii := inv.Images()
+	assert.Equal(t, 3, len(ii))
+
+	assert.Equal(t, "busybox:latest", ii[0].Image)
+	assert.True(t, ii[0].Latest)
+	assert.Equal(t, []string{"blee", "zorg"}, ii[0].Namespaces)
+	assert.Equal(t, []string{"Always"}, ii[0].PullPolicies)
+	assert.Equal(t, 2, len(ii[0].Consumers))
+	assert.Empty(t, ii[0].Drift)
+
+	assert.Equal(t, "fred:1", ii[1].Image)
+	assert.Equal(t, []string{"tag"}, ii[1].Drift)
+
+	assert.Equal(t, "fred:2", ii[2].Image)
+	assert.False(t, ii[2].Latest)
+	assert.Equal(t, []string{"sha256:111", "sha256:222"}, ii[2].Digests)
+	assert.Equal(t, []string{"digest", "tag"}, ii[2].Drift)
+	assert.Equal(t, []render.ImageConsumerRes{
+		{
+			GVR:        "apps/v1/deployments",
+			Namespace:  "blee",
+			Name:       "fred",
+			Containers: []string{"fred"},
+			PullPolicy: "IfNotPresent",
+			Digests:    []string{"sha256:111", "sha256:222"},
+		},
+	}, ii[2].Consumers)
+}
+
+func TestImagePodSpec(t *testing.T) {
+	template := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "fred", "image": "fred:1"},
+			},
+		},
+	}
+	uu := map[string]struct {
+		gvr string
+		o   map[string]interface{}
+		e   []string
+	}{
+		"job": {
+			gvr: "batch/v1/jobs",
+			o: map[string]interface{}{
+				"spec": map[string]interface{}{"template": template},
+			},
+			e: []string{"fred:1"},
+		},
+		"cronjob": {
+			gvr: "batch/v1/cronjobs",
+			o: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"jobTemplate": map[string]interface{}{
+						"spec": map[string]interface{}{"template": template},
+					},
+				},
+			},
+			e: []string{"fred:1"},
+		},
+		"pod": {
+			gvr: "v1/pods",
+			o:   template,
+			e:   []string{"fred:1"},
+		},
+		"missing": {
+			gvr: "batch/v1/cronjobs",
+			o:   map[string]interface{}{"spec": map[string]interface{}{}},
+			e:   []string{},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			o := &unstructured.Unstructured{Object: u.o}
+			o.SetNamespace("blee")
+			o.SetName("fred")
+			acc, err := AccessorFor(certFactory{o: o}, client.NewGVR(u.gvr))
+			assert.Nil(t, err)
+			ps, ok := acc.(PodSpecer)
+			assert.True(t, ok)
+			spec, err := ps.GetPodSpec("blee/fred")
+			assert.Nil(t, err)
+			ii := []string{}
+			for _, co := range allContainers(spec) {
+				ii = append(ii, co.Image)
+			}
+			assert.Equal(t, u.e, ii)
+		})
+	}
+}
+
+func TestScanImagesSkipsBrokenWorkloads(t *testing.T) {
+	dp := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "fred", "namespace": "blee"},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "fred", "image": "fred:1"},
+					},
+				},
+			},
+		},
+	}}
+	broken := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "zorg", "namespace": "blee"},
+	}}
+	f := imageFactory{
+		rows: map[string][]runtime.Object{
+			"apps/v1/deployments": {dp},
+			"batch/v1/jobs":       {broken},
+		},
+	}
+
+	inv, err := scanImages(f, "blee")
+	assert.Nil(t, err)
+	ii := inv.Images()
+	assert.Equal(t, 1, len(ii))
+	assert.Equal(t, "fred:1", ii[0].Image)
+}
+
+// Helpers...
+
+type imageFactory struct {
+	Factory
+	rows map[string][]runtime.Object
+}
+
+func (f imageFactory) List(gvr, _ string, _ bool, _ labels.Selector) ([]runtime.Object, error) {
+	return f.rows[gvr], nil
+}
+
+func (f imageFactory) Get(gvr, path string, _ bool, _ labels.Selector) (runtime.Object, error) {
+	for _, o := range f.rows[gvr] {
+		u := o.(*unstructured.Unstructured)
+		if client.FQN(u.GetNamespace(), u.GetName()) != path {
+			continue
+		}
+		if _, ok := u.Object["spec"]; !ok {
+			return nil, fmt.Errorf("unable to load %s", path)
+		}
+		return o, nil
+	}
+
+	return nil, fmt.Errorf("%s not found", path)
+}
+
+func makeImagePod(n, image, id string) *v1.Pod {
+	ok := true
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      n,
+			Namespace: "blee",
+			Labels:    map[string]string{appsv1.DefaultDeploymentUniqueLabelKey: "abc"},
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "ReplicaSet", Name: "fred-abc", Controller: &ok},
+			},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "fred", Image: image, ImagePullPolicy: v1.PullIfNotPresent}},
+		},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{{Name: "fred", ImageID: id}},
+		},
+	}
+}
@@ -9,15 +9,17 @@ import (
 	"github.com/derailed/k9s/internal/client"
 	"github.com/rs/zerolog/log"
 	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
 var (
-	_ Accessor = (*Job)(nil)
-	_ Nuker    = (*Job)(nil)
-	_ Loggable = (*Job)(nil)
+	_ Accessor  = (*Job)(nil)
+	_ Nuker     = (*Job)(nil)
+	_ Loggable  = (*Job)(nil)
+	_ PodSpecer = (*Job)(nil)
 )
 
 // Job represents a K8s job resource.
@@ -152,3 +154,18 @@ func (j *Job) Scan(ctx context.Context, gvr, fqn string, wait bool) (Refs, error
 
 	return refs, nil
 }
+
+// GetPodSpec returns a pod spec given a resource.
+func (j *Job) GetPodSpec(path string) (*v1.PodSpec, error) {
+	o, err := j.GetFactory().Get(j.gvr.String(), path, true, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var job batchv1.Job
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(o.(*unstructured.Unstructured).Object, &job)
+	if err != nil {
+		return nil, errors.New("expecting a job resource")
+	}
+	podSpec := job.Spec.Template.Spec
+	return &podSpec, nil
+}
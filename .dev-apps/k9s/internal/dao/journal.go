@@ -0,0 +1,318 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/rs/zerolog/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// JournalDelete tracks a resource deletion.
+	JournalDelete = "delete"
+
+	// JournalEdit tracks a resource modification.
+	JournalEdit = "edit"
+
+	journalExt        = ".yml"
+	journalTimeFormat = "20060102T150405.000000000"
+)
+
+var (
+	_ Accessor = (*Journal)(nil)
+	_ Nuker    = (*Journal)(nil)
+)
+
+// JournalEntry represents a resource snapshot taken prior to a destructive action.
+type JournalEntry struct {
+	Action    string                 `json:"action"`
+	GVR       string                 `json:"gvr"`
+	Path      string                 `json:"path"`
+	Context   string                 `json:"context"`
+	Cluster   string                 `json:"cluster,omitempty"`
+	User      string                 `json:"user,omitempty"`
+	Timestamp time.Time              `json:"timestamp"`
+	Redacted  bool                   `json:"redacted,omitempty"`
+	Object    map[string]interface{} `json:"object"`
+}
+
+// LoadJournalEntry loads a journal entry from disk.
+func LoadJournalEntry(path string) (*JournalEntry, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var e JournalEntry
+	if err := yaml.Unmarshal(raw, &e); err != nil {
+		return nil, fmt.Errorf("invalid journal entry %s: %w", path, err)
+	}
+
+	return &e, nil
+}
+
+// Unstructured returns the journaled resource.
+func (e *JournalEntry) Unstructured() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: runtime.DeepCopyJSON(e.Object)}
+}
+
+// Journal represents the resources undo journal.
+type Journal struct {
+	NonResource
+}
+
+// Delete removes a journal entry.
+func (j *Journal) Delete(_ context.Context, path string, _ *metav1.DeletionPropagation, _ bool) error {
+	return os.Remove(path)
+}
+
+// Get returns the journaled resource.
+func (j *Journal) Get(_ context.Context, path string) (runtime.Object, error) {
+	e, err := LoadJournalEntry(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.Unstructured(), nil
+}
+
+// List returns a collection of journal entries.
+func (j *Journal) List(ctx context.Context, _ string) ([]runtime.Object, error) {
+	dir, ok := ctx.Value(internal.KeyDir).(string)
+	if !ok {
+		return nil, errors.New("no journal dir found in context")
+	}
+
+	ff, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	oo := make([]runtime.Object, 0, len(ff))
+	for _, f := range ff {
+		if f.IsDir() || filepath.Ext(f.Name()) != journalExt {
+			continue
+		}
+		path := filepath.Join(dir, f.Name())
+		e, err := LoadJournalEntry(path)
+		if err != nil {
+			log.Warn().Err(err).Msgf("Skipping journal entry")
+			continue
+		}
+		ns, n := client.Namespaced(e.Path)
+		oo = append(oo, render.JournalRes{
+			Path:      path,
+			Action:    e.Action,
+			GVR:       e.GVR,
+			Kind:      e.Unstructured().GetKind(),
+			Namespace: ns,
+			Name:      n,
+			Context:   e.Context,
+			User:      e.User,
+			Timestamp: e.Timestamp,
+		})
+	}
+
+	return oo, nil
+}
+
+// Record snapshots a resource in the journal prior to a destructive action.
+func (j *Journal) Record(ctx context.Context, dir string, cfg *config.Journal, action string, gvr client.GVR, path string) error {
+	var g Generic
+	g.Init(j.Factory, gvr)
+	o, err := g.Get(ctx, path)
+	if err != nil {
+		return err
+	}
+	u, ok := o.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("expecting unstructured resource but got %T", o)
+	}
+	if cfg == nil {
+		cfg = config.NewJournal()
+	}
+
+	e := JournalEntry{
+		Action:    action,
+		GVR:       gvr.String(),
+		Path:      path,
+		Timestamp: time.Now(),
+		Object:    SanitizeSnapshot(u).Object,
+	}
+	if gvr.String() == "v1/secrets" && !cfg.Secrets {
+		e.Redacted = RedactSnapshot(e.Object)
+	}
+	kcfg := j.Client().Config()
+	if e.Context, err = kcfg.CurrentContextName(); err != nil {
+		log.Warn().Err(err).Msgf("Journal unable to resolve current context")
+	}
+	e.Cluster, _ = kcfg.CurrentClusterName()
+	e.User, _ = kcfg.CurrentUserName()
+
+	raw, err := yaml.Marshal(e)
+	if err != nil {
+		return err
+	}
+	config.EnsureFullPath(dir, config.DefaultDirMod)
+	name := strings.Join([]string{
+		e.Timestamp.UTC().Format(journalTimeFormat),
+		action,
+		config.SanitizeFilename(strings.ReplaceAll(gvr.R()+"_"+path, "/", "_")),
+	}, "_") + journalExt
+	if err := os.WriteFile(filepath.Join(dir, name), raw, config.DefaultFileMod); err != nil {
+		return err
+	}
+
+	return PruneJournal(dir, cfg.MaxEntries, cfg.MaxAge())
+}
+
+// Recreate creates the journaled resource anew.
+func (j *Journal) Recreate(ctx context.Context, path string) error {
+	e, err := LoadJournalEntry(path)
+	if err != nil {
+		return err
+	}
+	if e.Redacted {
+		return fmt.Errorf("journaled %s data was redacted and can't be restored", e.Path)
+	}
+	ns, _ := client.Namespaced(e.Path)
+	auth, err := j.Client().CanI(ns, e.GVR, []string{client.CreateVerb})
+	if err != nil {
+		return err
+	}
+	if !auth {
+		return fmt.Errorf("user is not authorized to create %s", e.Path)
+	}
+
+	var g Generic
+	g.Init(j.Factory, client.NewGVR(e.GVR))
+	dial, err := g.dynClient()
+	if err != nil {
+		return err
+	}
+	if client.IsClusterScoped(ns) {
+		_, err = dial.Create(ctx, e.Unstructured(), metav1.CreateOptions{})
+		return err
+	}
+	_, err = dial.Namespace(ns).Create(ctx, e.Unstructured(), metav1.CreateOptions{})
+
+	return err
+}
+
+// Rollback reverts a live resource to its journaled state.
+func (j *Journal) Rollback(ctx context.Context, path string) error {
+	e, err := LoadJournalEntry(path)
+	if err != nil {
+		return err
+	}
+	if e.Redacted {
+		return fmt.Errorf("journaled %s data was redacted and can't be restored", e.Path)
+	}
+	ns, _ := client.Namespaced(e.Path)
+	auth, err := j.Client().CanI(ns, e.GVR, []string{client.UpdateVerb})
+	if err != nil {
+		return err
+	}
+	if !auth {
+		return fmt.Errorf("user is not authorized to update %s", e.Path)
+	}
+
+	var g Generic
+	g.Init(j.Factory, client.NewGVR(e.GVR))
+	o, err := g.Get(ctx, e.Path)
+	if err != nil {
+		return fmt.Errorf("unable to locate live resource %s. Try re-creating it instead: %w", e.Path, err)
+	}
+	live, ok := o.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("expecting unstructured resource but got %T", o)
+	}
+	u := e.Unstructured()
+	u.SetResourceVersion(live.GetResourceVersion())
+
+	dial, err := g.dynClient()
+	if err != nil {
+		return err
+	}
+	if client.IsClusterScoped(ns) {
+		_, err = dial.Update(ctx, u, metav1.UpdateOptions{})
+		return err
+	}
+	_, err = dial.Namespace(ns).Update(ctx, u, metav1.UpdateOptions{})
+
+	return err
+}
+
+// SanitizeSnapshot strips out server managed fields from a resource.
+func SanitizeSnapshot(u *unstructured.Unstructured) *unstructured.Unstructured {
+	s := u.DeepCopy()
+	for _, f := range []string{
+		"uid",
+		"resourceVersion",
+		"generation",
+		"creationTimestamp",
+		"deletionTimestamp",
+		"deletionGracePeriodSeconds",
+		"managedFields",
+		"selfLink",
+	} {
+		unstructured.RemoveNestedField(s.Object, "metadata", f)
+	}
+	unstructured.RemoveNestedField(s.Object, "status")
+
+	return s
+}
+
+// RedactSnapshot strips out secret values from a resource. It returns true if
+// any values were removed.
+func RedactSnapshot(o map[string]interface{}) bool {
+	var redacted bool
+	for _, f := range []string{"data", "stringData"} {
+		if _, ok := o[f]; ok {
+			delete(o, f)
+			redacted = true
+		}
+	}
+
+	return redacted
+}
+
+// PruneJournal enforces journal retention limits.
+func PruneJournal(dir string, maxEntries int, maxAge time.Duration) error {
+	ff, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(ff))
+	for _, f := range ff {
+		if !f.IsDir() && filepath.Ext(f.Name()) == journalExt {
+			names = append(names, f.Name())
+		}
+	}
+	// Entries are prefixed by their timestamp so most recent entries sort last.
+	sort.Strings(names)
+
+	cutoff := time.Now().Add(-maxAge).UTC().Format(journalTimeFormat)
+	for i, n := range names {
+		if len(names)-i <= maxEntries && n >= cutoff {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, n)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package dao
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestSanitizeSnapshot(t *testing.T) {
+	u := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":              "fred",
+			"namespace":         "blee",
+			"uid":               "123",
+			"resourceVersion":   "10",
+			"creationTimestamp": "2022-01-01T00:00:00Z",
+			"managedFields":     []interface{}{},
+			"labels":            map[string]interface{}{"app": "fred"},
+		},
+		"data":   map[string]interface{}{"a": "b"},
+		"status": map[string]interface{}{"phase": "blee"},
+	}}
+
+	s := SanitizeSnapshot(&u)
+
+	assert.Equal(t, map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":      "fred",
+			"namespace": "blee",
+			"labels":    map[string]interface{}{"app": "fred"},
+		},
+		"data": map[string]interface{}{"a": "b"},
+	}, s.Object)
+	assert.Equal(t, "123", string(u.GetUID()), "original should not be modified")
+}
+
+func TestRedactSnapshot(t *testing.T) {
+	uu := map[string]struct {
+		o        map[string]interface{}
+		redacted bool
+	}{
+		"secret": {
+			o: map[string]interface{}{
+				"kind":       "Secret",
+				"data":       map[string]interface{}{"password": "YmxlZQ=="},
+				"stringData": map[string]interface{}{"user": "fred"},
+			},
+			redacted: true,
+		},
+		"none": {
+			o: map[string]interface{}{"kind": "Secret", "type": "Opaque"},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.redacted, RedactSnapshot(u.o))
+			assert.NotContains(t, u.o, "data")
+			assert.NotContains(t, u.o, "stringData")
+			assert.Equal(t, "Secret", u.o["kind"])
+		})
+	}
+}
+
+func TestJournalRedactedRestore(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "fred.yml")
+	assert.Nil(t, os.WriteFile(path, []byte("action: delete\ngvr: v1/secrets\npath: default/fred\nredacted: true\nobject:\n  kind: Secret\n"), 0600))
+
+	var j Journal
+	assert.EqualError(t, j.Recreate(context.Background(), path), "journaled default/fred data was redacted and can't be restored")
+	assert.EqualError(t, j.Rollback(context.Background(), path), "journaled default/fred data was redacted and can't be restored")
+}
+
+func TestPruneJournal(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now().UTC()
+	ff := []string{
+		now.Add(-30*24*time.Hour).Format(journalTimeFormat) + "_delete_old.yml",
+		now.Add(-3*time.Hour).Format(journalTimeFormat) + "_delete_a.yml",
+		now.Add(-2*time.Hour).Format(journalTimeFormat) + "_edit_b.yml",
+		now.Add(-1*time.Hour).Format(journalTimeFormat) + "_delete_c.yml",
+		"README",
+	}
+	for _, f := range ff {
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, f), []byte("blee"), 0600))
+	}
+
+	assert.Nil(t, PruneJournal(dir, 2, 14*24*time.Hour))
+
+	ee, err := os.ReadDir(dir)
+	assert.Nil(t, err)
+	names := make([]string, 0, len(ee))
+	for _, e := range ee {
+		names = append(names, e.Name())
+	}
+	assert.Equal(t, []string{ff[2], ff[3], "README"}, names)
+}
@@ -0,0 +1,65 @@
+package dao
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// JSONPathFind returns the raw values matching a JSONPath expression.
+// Missing keys yield no values.
+func JSONPathFind(expr string, o map[string]interface{}) ([]interface{}, error) {
+	_, vv, err := jsonPathFind(expr, o)
+	if err != nil {
+		return nil, err
+	}
+	ii := make([]interface{}, 0, len(vv))
+	for _, v := range vv {
+		ii = append(ii, v.Interface())
+	}
+
+	return ii, nil
+}
+
+// JSONPathValues returns the printed values matching a JSONPath expression.
+func JSONPathValues(expr string, o map[string]interface{}) ([]string, error) {
+	jp, vv, err := jsonPathFind(expr, o)
+	if err != nil {
+		return nil, err
+	}
+	ss := make([]string, 0, len(vv))
+	for _, v := range vv {
+		var buff bytes.Buffer
+		if err := jp.PrintResults(&buff, []reflect.Value{v}); err != nil {
+			return nil, err
+		}
+		ss = append(ss, buff.String())
+	}
+
+	return ss, nil
+}
+
+func jsonPathFind(expr string, o map[string]interface{}) (*jsonpath.JSONPath, []reflect.Value, error) {
+	jp := jsonpath.New("k9s").AllowMissingKeys(true)
+	if err := jp.Parse(expr); err != nil {
+		return nil, nil, fmt.Errorf("invalid jsonPath %q: %w", expr, err)
+	}
+	rr, err := jp.FindResults(o)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	vv := make([]reflect.Value, 0, len(rr))
+	for _, r := range rr {
+		for _, v := range r {
+			if !v.IsValid() || !v.CanInterface() || v.Interface() == nil {
+				continue
+			}
+			vv = append(vv, v)
+		}
+	}
+
+	return jp, vv, nil
+}
@@ -0,0 +1,128 @@
+package dao
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/derailed/k9s/internal/client"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// ManifestRef represents a resource declared in a manifest.
+type ManifestRef struct {
+	APIVersion, Kind, Namespace, Name string
+}
+
+// ManifestRefs lists the resources declared in a manifest file or in the
+// manifests of a directory.
+func ManifestRefs(path string, recursive bool) ([]ManifestRef, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.IsDir() {
+		return manifestFileRefs(path)
+	}
+
+	var rr []ManifestRef
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != path && !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !isManifestFile(p) {
+			return nil
+		}
+		refs, err := manifestFileRefs(p)
+		if err != nil {
+			return err
+		}
+		rr = append(rr, refs...)
+
+		return nil
+	})
+
+	return rr, err
+}
+
+// ParseManifestRefs lists the resources declared in yaml or json documents.
+func ParseManifestRefs(r io.Reader) ([]ManifestRef, error) {
+	var rr []ManifestRef
+	dec := utilyaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		var u unstructured.Unstructured
+		if err := dec.Decode(&u.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				return rr, nil
+			}
+			return nil, err
+		}
+		if len(u.Object) == 0 {
+			continue
+		}
+		if !u.IsList() {
+			rr = append(rr, manifestRef(&u))
+			continue
+		}
+		l, err := u.ToList()
+		if err != nil {
+			return nil, err
+		}
+		for i := range l.Items {
+			rr = append(rr, manifestRef(&l.Items[i]))
+		}
+	}
+}
+
+// GVRFor returns the resource meta matching a manifest apiVersion and kind.
+func (m *Meta) GVRFor(apiVersion, kind string) (client.GVR, bool) {
+	m.mx.RLock()
+	defer m.mx.RUnlock()
+	for gvr, meta := range m.resMetas {
+		if IsK8sMeta(meta) && meta.Kind == kind && gvr.GV().String() == apiVersion {
+			return gvr, true
+		}
+	}
+
+	return client.GVR{}, false
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func manifestFileRefs(path string) ([]ManifestRef, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseManifestRefs(bytes.NewReader(raw))
+}
+
+func manifestRef(u *unstructured.Unstructured) ManifestRef {
+	return ManifestRef{
+		APIVersion: u.GetAPIVersion(),
+		Kind:       u.GetKind(),
+		Namespace:  u.GetNamespace(),
+		Name:       u.GetName(),
+	}
+}
+
+func isManifestFile(path string) bool {
+	switch filepath.Ext(path) {
+	case ".yml", ".yaml", ".json":
+		return true
+	default:
+		return false
+	}
+}
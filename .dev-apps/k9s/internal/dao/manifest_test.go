@@ -0,0 +1,109 @@
+package dao
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseManifestRefs(t *testing.T) {
+	uu := map[string]struct {
+		raw string
+		e   []ManifestRef
+		err bool
+	}{
+		"multi": {
+			raw: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: fred
+  namespace: blee
+---
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: zorg
+`,
+			e: []ManifestRef{
+				{APIVersion: "v1", Kind: "ConfigMap", Namespace: "blee", Name: "fred"},
+				{APIVersion: "apps/v1", Kind: "Deployment", Name: "zorg"},
+			},
+		},
+		"list": {
+			raw: `{"apiVersion": "v1", "kind": "List", "items": [
+  {"apiVersion": "v1", "kind": "Secret", "metadata": {"name": "s1"}},
+  {"apiVersion": "v1", "kind": "Namespace", "metadata": {"name": "ns1"}}
+]}`,
+			e: []ManifestRef{
+				{APIVersion: "v1", Kind: "Secret", Name: "s1"},
+				{APIVersion: "v1", Kind: "Namespace", Name: "ns1"},
+			},
+		},
+		"invalid": {
+			raw: "kind: [",
+			err: true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			rr, err := ParseManifestRefs(strings.NewReader(u.raw))
+			if u.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, u.e, rr)
+		})
+	}
+}
+
+func TestManifestRefs(t *testing.T) {
+	dir := t.TempDir()
+	sub := filepath.Join(dir, "sub")
+	assert.Nil(t, os.MkdirAll(sub, 0700))
+	write := func(path, n string) {
+		assert.Nil(t, os.WriteFile(path, []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: "+n+"\n"), 0600))
+	}
+	write(filepath.Join(dir, "cm1.yaml"), "cm1")
+	write(filepath.Join(sub, "cm2.yml"), "cm2")
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# blee"), 0600))
+
+	uu := map[string]struct {
+		path      string
+		recursive bool
+		e         []string
+	}{
+		"file": {
+			path: filepath.Join(sub, "cm2.yml"),
+			e:    []string{"cm2"},
+		},
+		"dir": {
+			path: dir,
+			e:    []string{"cm1"},
+		},
+		"recursive": {
+			path:      dir,
+			recursive: true,
+			e:         []string{"cm1", "cm2"},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			rr, err := ManifestRefs(u.path, u.recursive)
+			assert.Nil(t, err)
+			nn := make([]string, 0, len(rr))
+			for _, r := range rr {
+				nn = append(nn, r.Name)
+			}
+			assert.Equal(t, u.e, nn)
+		})
+	}
+}
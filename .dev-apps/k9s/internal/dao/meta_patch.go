@@ -0,0 +1,196 @@
+package dao
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// LabelsMeta represents resource labels.
+	LabelsMeta MetaKind = "labels"
+
+	// AnnotationsMeta represents resource annotations.
+	AnnotationsMeta MetaKind = "annotations"
+)
+
+// MetaKind represents a kind of resource metadata.
+type MetaKind string
+
+// MetaEdit represents a label or annotation change.
+type MetaEdit struct {
+	Kind   MetaKind
+	Key    string
+	Value  string
+	Remove bool
+}
+
+// MetaEdits represents a collection of metadata changes.
+type MetaEdits []MetaEdit
+
+// JSONOp represents a json patch operation.
+type JSONOp struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// ParseMetaEdits parses kubectl style specs ie k1=v1,k2- into metadata edits.
+func ParseMetaEdits(kind MetaKind, spec string) (MetaEdits, error) {
+	ee := make(MetaEdits, 0, 5)
+	for _, t := range strings.Split(spec, ",") {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		var e MetaEdit
+		if kv := strings.SplitN(t, "=", 2); len(kv) == 2 {
+			e = MetaEdit{Kind: kind, Key: strings.TrimSpace(kv[0]), Value: strings.TrimSpace(kv[1])}
+		} else if strings.HasSuffix(t, "-") {
+			e = MetaEdit{Kind: kind, Key: strings.TrimSuffix(t, "-"), Remove: true}
+		} else {
+			return nil, fmt.Errorf("invalid %s spec %q. Expecting key=value or key-", kind, t)
+		}
+		if err := e.Validate(); err != nil {
+			return nil, err
+		}
+		ee = append(ee, e)
+	}
+
+	return ee, nil
+}
+
+// Validate checks the edit key and value syntax.
+func (e MetaEdit) Validate() error {
+	if errs := validation.IsQualifiedName(e.Key); len(errs) > 0 {
+		return fmt.Errorf("invalid %s key %q: %s", e.Kind, e.Key, strings.Join(errs, "; "))
+	}
+	if e.Kind != LabelsMeta || e.Remove {
+		return nil
+	}
+	if errs := validation.IsValidLabelValue(e.Value); len(errs) > 0 {
+		return fmt.Errorf("invalid label value %q: %s", e.Value, strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// Validate checks all edits syntax.
+func (ee MetaEdits) Validate() error {
+	for _, e := range ee {
+		if err := e.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Patch builds a patch of the given type for a resource with the given metadata.
+func (ee MetaEdits) Patch(pt types.PatchType, m metav1.Object) ([]byte, error) {
+	switch pt {
+	case types.StrategicMergePatchType, types.MergePatchType:
+		return ee.MergePatch()
+	case types.JSONPatchType:
+		return ee.JSONPatch(m)
+	default:
+		return nil, fmt.Errorf("unsupported patch type %q", pt)
+	}
+}
+
+// MergePatch returns a merge patch for the edits.
+func (ee MetaEdits) MergePatch() ([]byte, error) {
+	meta := make(map[MetaKind]map[string]interface{}, 2)
+	for _, e := range ee {
+		if _, ok := meta[e.Kind]; !ok {
+			meta[e.Kind] = make(map[string]interface{})
+		}
+		if e.Remove {
+			meta[e.Kind][e.Key] = nil
+			continue
+		}
+		meta[e.Kind][e.Key] = e.Value
+	}
+
+	return json.Marshal(map[string]interface{}{"metadata": meta})
+}
+
+// JSONPatch returns a json patch for the edits given the resource current metadata.
+func (ee MetaEdits) JSONPatch(m metav1.Object) ([]byte, error) {
+	current := map[MetaKind]map[string]string{
+		LabelsMeta:      copyMeta(m.GetLabels()),
+		AnnotationsMeta: copyMeta(m.GetAnnotations()),
+	}
+	ops := make([]JSONOp, 0, len(ee))
+	for _, e := range ee {
+		mm, ok := current[e.Kind]
+		if !ok {
+			return nil, fmt.Errorf("unsupported metadata kind %q", e.Kind)
+		}
+		root := "/metadata/" + string(e.Kind)
+		path := root + "/" + escapeJSONPointer(e.Key)
+		if e.Remove {
+			if _, ok := mm[e.Key]; ok {
+				ops = append(ops, JSONOp{Op: "remove", Path: path})
+				delete(mm, e.Key)
+			}
+			continue
+		}
+		if mm == nil {
+			ops = append(ops, JSONOp{Op: "add", Path: root, Value: map[string]string{}})
+			mm = make(map[string]string)
+			current[e.Kind] = mm
+		}
+		op := "add"
+		if _, ok := mm[e.Key]; ok {
+			op = "replace"
+		}
+		ops = append(ops, JSONOp{Op: op, Path: path, Value: e.Value})
+		mm[e.Key] = e.Value
+	}
+
+	return json.Marshal(ops)
+}
+
+// Dump returns a human readable representation of the edits.
+func (ee MetaEdits) Dump() string {
+	ss := make([]string, 0, len(ee))
+	for _, e := range ee {
+		if e.Remove {
+			ss = append(ss, fmt.Sprintf("- %s %s", singularMeta(e.Kind), e.Key))
+			continue
+		}
+		ss = append(ss, fmt.Sprintf("+ %s %s=%s", singularMeta(e.Kind), e.Key, e.Value))
+	}
+	sort.Strings(ss)
+
+	return strings.Join(ss, "\n")
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func singularMeta(k MetaKind) string {
+	return strings.TrimSuffix(string(k), "s")
+}
+
+func copyMeta(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	mm := make(map[string]string, len(m))
+	for k, v := range m {
+		mm[k] = v
+	}
+
+	return mm
+}
+
+func escapeJSONPointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,94 @@
+package dao
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestParseMetaEdits(t *testing.T) {
+	uu := map[string]struct {
+		spec string
+		err  bool
+		e    MetaEdits
+	}{
+		"empty": {
+			e: MetaEdits{},
+		},
+		"set": {
+			spec: "app=fred, app.kubernetes.io/tier=be",
+			e: MetaEdits{
+				{Kind: LabelsMeta, Key: "app", Value: "fred"},
+				{Kind: LabelsMeta, Key: "app.kubernetes.io/tier", Value: "be"},
+			},
+		},
+		"remove": {
+			spec: "app-",
+			e: MetaEdits{
+				{Kind: LabelsMeta, Key: "app", Remove: true},
+			},
+		},
+		"bad-spec": {
+			spec: "app",
+			err:  true,
+		},
+		"bad-key": {
+			spec: "-app=fred",
+			err:  true,
+		},
+		"bad-value": {
+			spec: "app=fred blee",
+			err:  true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			ee, err := ParseMetaEdits(LabelsMeta, u.spec)
+			if u.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, u.e, ee)
+		})
+	}
+}
+
+func TestMetaEditsPatch(t *testing.T) {
+	m := metav1.ObjectMeta{
+		Labels: map[string]string{"app": "fred", "a/b": "blee"},
+	}
+	ee := MetaEdits{
+		{Kind: LabelsMeta, Key: "app", Value: "zorg"},
+		{Kind: LabelsMeta, Key: "a/b", Remove: true},
+		{Kind: AnnotationsMeta, Key: "note", Value: "duh"},
+		{Kind: LabelsMeta, Key: "gone", Remove: true},
+	}
+
+	uu := map[string]struct {
+		pt types.PatchType
+		e  string
+	}{
+		"merge": {
+			pt: types.StrategicMergePatchType,
+			e:  `{"metadata":{"annotations":{"note":"duh"},"labels":{"a/b":null,"app":"zorg","gone":null}}}`,
+		},
+		"json": {
+			pt: types.JSONPatchType,
+			e:  `[{"op":"replace","path":"/metadata/labels/app","value":"zorg"},{"op":"remove","path":"/metadata/labels/a~1b"},{"op":"add","path":"/metadata/annotations","value":{}},{"op":"add","path":"/metadata/annotations/note","value":"duh"}]`,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			raw, err := ee.Patch(u.pt, &m)
+			assert.Nil(t, err)
+			assert.JSONEq(t, u.e, string(raw))
+		})
+	}
+}
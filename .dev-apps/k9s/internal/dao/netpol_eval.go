@@ -0,0 +1,263 @@
+package dao
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/derailed/k9s/internal/client"
+	v1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// NetPolPeer represents a traffic source or destination.
+type NetPolPeer struct {
+	// Namespace the peer namespace.
+	Namespace string
+
+	// NSLabels the peer namespace labels.
+	NSLabels map[string]string
+
+	// PodLabels the peer pod labels. A nil value stands for any pod in the
+	// namespace and is deemed to match any pod selector.
+	PodLabels map[string]string
+}
+
+// IsNamespace checks if the peer represents a whole namespace.
+func (p NetPolPeer) IsNamespace() bool {
+	return p.PodLabels == nil
+}
+
+// NetPolPort represents a destination port. A port with neither a number nor
+// a name stands for any port.
+type NetPolPort struct {
+	Port     int32
+	Name     string
+	Protocol v1.Protocol
+}
+
+// IsAny checks if the port stands for any port.
+func (p NetPolPort) IsAny() bool {
+	return p.Port == 0 && p.Name == ""
+}
+
+// String returns a port representation.
+func (p NetPolPort) String() string {
+	proto := p.Protocol
+	if proto == "" {
+		proto = v1.ProtocolTCP
+	}
+	switch {
+	case p.IsAny():
+		return "any/" + string(proto)
+	case p.Port == 0:
+		return p.Name + "/" + string(proto)
+	case p.Name == "":
+		return strconv.Itoa(int(p.Port)) + "/" + string(proto)
+	default:
+		return strconv.Itoa(int(p.Port)) + "(" + p.Name + ")/" + string(proto)
+	}
+}
+
+// ParseNetPolPort parses a port of the form port[/protocol] where port is
+// either a number or a name. A blank port stands for any port.
+func ParseNetPolPort(s string) (NetPolPort, error) {
+	var port NetPolPort
+	s = strings.TrimSpace(s)
+	if i := strings.Index(s, "/"); i >= 0 {
+		port.Protocol = v1.Protocol(strings.ToUpper(s[i+1:]))
+		switch port.Protocol {
+		case v1.ProtocolTCP, v1.ProtocolUDP, v1.ProtocolSCTP:
+		default:
+			return port, fmt.Errorf("invalid protocol %q", s[i+1:])
+		}
+		s = s[:i]
+	}
+	if s == "" || s == "*" {
+		return port, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		port.Name = s
+		return port, nil
+	}
+	if n <= 0 || n > 65535 {
+		return port, fmt.Errorf("invalid port %d", n)
+	}
+	port.Port = int32(n)
+
+	return port, nil
+}
+
+// NetPolVerdict represents a network policies evaluation result.
+type NetPolVerdict struct {
+	// Allowed indicates whether the traffic is allowed.
+	Allowed bool
+
+	// Isolated indicates whether the peer is selected by any policy.
+	Isolated bool
+
+	// Policies lists the policies selecting the peer.
+	Policies []string
+
+	// Allowing lists the policies allowing the traffic.
+	Allowing []string
+}
+
+// NamespaceLabels returns a namespace labels including the well known
+// kubernetes.io/metadata.name label.
+func NamespaceLabels(ns string, ll map[string]string) map[string]string {
+	m := make(map[string]string, len(ll)+1)
+	for k, v := range ll {
+		m[k] = v
+	}
+	m["kubernetes.io/metadata.name"] = ns
+
+	return m
+}
+
+// EvalIngress evaluates whether traffic from a source to a destination pod
+// port is allowed given a set of network policies.
+func EvalIngress(pp []netv1.NetworkPolicy, src, dst NetPolPeer, port NetPolPort) NetPolVerdict {
+	var v NetPolVerdict
+	for _, p := range pp {
+		if p.Namespace != dst.Namespace || !hasPolicyType(p, netv1.PolicyTypeIngress) {
+			continue
+		}
+		if !selectorMatches(&p.Spec.PodSelector, dst.PodLabels, dst.IsNamespace()) {
+			continue
+		}
+		v.Isolated = true
+		fqn := client.FQN(p.Namespace, p.Name)
+		v.Policies = append(v.Policies, fqn)
+		for _, r := range p.Spec.Ingress {
+			if peersMatch(r.From, p.Namespace, src) && portsMatch(r.Ports, port) {
+				v.Allowing = append(v.Allowing, fqn)
+				break
+			}
+		}
+	}
+	v.Allowed = !v.Isolated || len(v.Allowing) > 0
+
+	return v
+}
+
+// EvalEgress evaluates whether traffic from a source pod to a destination
+// port is allowed given a set of network policies.
+func EvalEgress(pp []netv1.NetworkPolicy, src, dst NetPolPeer, port NetPolPort) NetPolVerdict {
+	var v NetPolVerdict
+	for _, p := range pp {
+		if p.Namespace != src.Namespace || !hasPolicyType(p, netv1.PolicyTypeEgress) {
+			continue
+		}
+		if !selectorMatches(&p.Spec.PodSelector, src.PodLabels, src.IsNamespace()) {
+			continue
+		}
+		v.Isolated = true
+		fqn := client.FQN(p.Namespace, p.Name)
+		v.Policies = append(v.Policies, fqn)
+		for _, r := range p.Spec.Egress {
+			if peersMatch(r.To, p.Namespace, dst) && portsMatch(r.Ports, port) {
+				v.Allowing = append(v.Allowing, fqn)
+				break
+			}
+		}
+	}
+	v.Allowed = !v.Isolated || len(v.Allowing) > 0
+
+	return v
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func hasPolicyType(p netv1.NetworkPolicy, t netv1.PolicyType) bool {
+	if len(p.Spec.PolicyTypes) == 0 {
+		return t == netv1.PolicyTypeIngress || len(p.Spec.Egress) > 0
+	}
+	for _, pt := range p.Spec.PolicyTypes {
+		if pt == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// selectorMatches checks a label selector against a set of labels. When
+// anyPod is set, the labels stand for any pod hence the selector is deemed a
+// match.
+func selectorMatches(sel *metav1.LabelSelector, ll map[string]string, anyPod bool) bool {
+	s, err := metav1.LabelSelectorAsSelector(sel)
+	if err != nil {
+		return false
+	}
+	if anyPod {
+		return true
+	}
+
+	return s.Matches(labels.Set(ll))
+}
+
+func peersMatch(pp []netv1.NetworkPolicyPeer, policyNS string, peer NetPolPeer) bool {
+	if len(pp) == 0 {
+		return true
+	}
+	for _, p := range pp {
+		if p.IPBlock != nil {
+			continue
+		}
+		if p.NamespaceSelector == nil {
+			if peer.Namespace != policyNS {
+				continue
+			}
+		} else if !selectorMatches(p.NamespaceSelector, NamespaceLabels(peer.Namespace, peer.NSLabels), false) {
+			continue
+		}
+		if p.PodSelector == nil || selectorMatches(p.PodSelector, peer.PodLabels, peer.IsNamespace()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func portsMatch(pp []netv1.NetworkPolicyPort, port NetPolPort) bool {
+	if len(pp) == 0 {
+		return true
+	}
+	proto := port.Protocol
+	if proto == "" {
+		proto = v1.ProtocolTCP
+	}
+	for _, p := range pp {
+		pproto := v1.ProtocolTCP
+		if p.Protocol != nil {
+			pproto = *p.Protocol
+		}
+		if pproto != proto {
+			continue
+		}
+		if p.Port == nil || port.IsAny() {
+			return true
+		}
+		if p.Port.Type == intstr.String {
+			if p.Port.StrVal == port.Name {
+				return true
+			}
+			continue
+		}
+		end := p.Port.IntVal
+		if p.EndPort != nil {
+			end = *p.EndPort
+		}
+		if port.Port >= p.Port.IntVal && port.Port <= end {
+			return true
+		}
+	}
+
+	return false
+}
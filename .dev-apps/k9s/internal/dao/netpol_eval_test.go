@@ -0,0 +1,317 @@
+package dao
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestEvalIngress(t *testing.T) {
+	var (
+		dst  = NetPolPeer{Namespace: "default", PodLabels: map[string]string{"app": "web"}}
+		ctrl = NetPolPeer{Namespace: "ingress-nginx", NSLabels: map[string]string{"team": "infra"}}
+		port = NetPolPort{Port: 8080, Name: "http"}
+	)
+
+	uu := map[string]struct {
+		pp       []netv1.NetworkPolicy
+		src      NetPolPeer
+		port     NetPolPort
+		allowed  bool
+		isolated bool
+		allowing []string
+	}{
+		"no-policies": {
+			src:     ctrl,
+			port:    port,
+			allowed: true,
+		},
+		"other-pods": {
+			pp:      []netv1.NetworkPolicy{makeNetPol("deny", "default", map[string]string{"app": "db"}, nil)},
+			src:     ctrl,
+			port:    port,
+			allowed: true,
+		},
+		"other-namespace": {
+			pp:      []netv1.NetworkPolicy{makeNetPol("deny", "fred", nil, nil)},
+			src:     ctrl,
+			port:    port,
+			allowed: true,
+		},
+		"deny-all": {
+			pp:       []netv1.NetworkPolicy{makeNetPol("deny", "default", nil, nil)},
+			src:      ctrl,
+			port:     port,
+			isolated: true,
+		},
+		"allow-all": {
+			pp:       []netv1.NetworkPolicy{makeNetPol("allow", "default", nil, []netv1.NetworkPolicyIngressRule{{}})},
+			src:      ctrl,
+			port:     port,
+			allowed:  true,
+			isolated: true,
+			allowing: []string{"default/allow"},
+		},
+		"ns-name": {
+			pp: []netv1.NetworkPolicy{
+				makeNetPol("deny", "default", nil, nil),
+				makeNetPol("ctrl", "default", map[string]string{"app": "web"}, []netv1.NetworkPolicyIngressRule{
+					{From: []netv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "ingress-nginx"}}}}},
+				}),
+			},
+			src:      ctrl,
+			port:     port,
+			allowed:  true,
+			isolated: true,
+			allowing: []string{"default/ctrl"},
+		},
+		"ns-labels-pods": {
+			pp: []netv1.NetworkPolicy{
+				makeNetPol("ctrl", "default", nil, []netv1.NetworkPolicyIngressRule{
+					{From: []netv1.NetworkPolicyPeer{{
+						NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "infra"}},
+						PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nginx"}},
+					}}},
+				}),
+			},
+			src:      ctrl,
+			port:     port,
+			allowed:  true,
+			isolated: true,
+			allowing: []string{"default/ctrl"},
+		},
+		"pod-mismatch": {
+			pp: []netv1.NetworkPolicy{
+				makeNetPol("ctrl", "default", nil, []netv1.NetworkPolicyIngressRule{
+					{From: []netv1.NetworkPolicyPeer{{
+						NamespaceSelector: &metav1.LabelSelector{},
+						PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nginx"}},
+					}}},
+				}),
+			},
+			src:      NetPolPeer{Namespace: "ingress-nginx", PodLabels: map[string]string{"app": "traefik"}},
+			port:     port,
+			isolated: true,
+		},
+		"same-ns-only": {
+			pp: []netv1.NetworkPolicy{
+				makeNetPol("local", "default", nil, []netv1.NetworkPolicyIngressRule{
+					{From: []netv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{}}}},
+				}),
+			},
+			src:      ctrl,
+			port:     port,
+			isolated: true,
+		},
+		"port-number": {
+			pp: []netv1.NetworkPolicy{
+				makeNetPol("ports", "default", nil, []netv1.NetworkPolicyIngressRule{
+					{Ports: []netv1.NetworkPolicyPort{{Port: intPort(9090)}}},
+				}),
+			},
+			src:      ctrl,
+			port:     port,
+			isolated: true,
+		},
+		"port-range": {
+			pp: []netv1.NetworkPolicy{
+				makeNetPol("ports", "default", nil, []netv1.NetworkPolicyIngressRule{
+					{Ports: []netv1.NetworkPolicyPort{{Port: intPort(8000), EndPort: int32Ptr(8100)}}},
+				}),
+			},
+			src:      ctrl,
+			port:     port,
+			allowed:  true,
+			isolated: true,
+			allowing: []string{"default/ports"},
+		},
+		"port-name": {
+			pp: []netv1.NetworkPolicy{
+				makeNetPol("ports", "default", nil, []netv1.NetworkPolicyIngressRule{
+					{Ports: []netv1.NetworkPolicyPort{{Port: &intstr.IntOrString{Type: intstr.String, StrVal: "http"}}}},
+				}),
+			},
+			src:      ctrl,
+			port:     port,
+			allowed:  true,
+			isolated: true,
+			allowing: []string{"default/ports"},
+		},
+		"port-protocol": {
+			pp: []netv1.NetworkPolicy{
+				makeNetPol("ports", "default", nil, []netv1.NetworkPolicyIngressRule{
+					{Ports: []netv1.NetworkPolicyPort{{Protocol: protoPtr(v1.ProtocolUDP)}}},
+				}),
+			},
+			src:      ctrl,
+			port:     port,
+			isolated: true,
+		},
+		"egress-only": {
+			pp: []netv1.NetworkPolicy{func() netv1.NetworkPolicy {
+				p := makeNetPol("egress", "default", nil, nil)
+				p.Spec.PolicyTypes = []netv1.PolicyType{netv1.PolicyTypeEgress}
+				return p
+			}()},
+			src:     ctrl,
+			port:    port,
+			allowed: true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			v := EvalIngress(u.pp, u.src, dst, u.port)
+			assert.Equal(t, u.allowed, v.Allowed)
+			assert.Equal(t, u.isolated, v.Isolated)
+			assert.Equal(t, u.allowing, v.Allowing)
+		})
+	}
+}
+
+func TestEvalEgress(t *testing.T) {
+	var (
+		src  = NetPolPeer{Namespace: "default", PodLabels: map[string]string{"app": "web"}}
+		dst  = NetPolPeer{Namespace: "db", NSLabels: map[string]string{"tier": "data"}, PodLabels: map[string]string{"app": "pg"}}
+		port = NetPolPort{Port: 5432}
+	)
+
+	uu := map[string]struct {
+		pp       []netv1.NetworkPolicy
+		allowed  bool
+		isolated bool
+		allowing []string
+	}{
+		"no-policies": {
+			allowed: true,
+		},
+		"ingress-only": {
+			pp:      []netv1.NetworkPolicy{makeNetPol("deny", "default", nil, nil)},
+			allowed: true,
+		},
+		"deny-all": {
+			pp:       []netv1.NetworkPolicy{makeEgressNetPol("deny", nil)},
+			isolated: true,
+		},
+		"allow-ns": {
+			pp: []netv1.NetworkPolicy{
+				makeEgressNetPol("deny", nil),
+				makeEgressNetPol("db", []netv1.NetworkPolicyEgressRule{
+					{To: []netv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "data"}}}}},
+				}),
+			},
+			allowed:  true,
+			isolated: true,
+			allowing: []string{"default/db"},
+		},
+		"wrong-port": {
+			pp: []netv1.NetworkPolicy{
+				makeEgressNetPol("db", []netv1.NetworkPolicyEgressRule{
+					{
+						To:    []netv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{}}},
+						Ports: []netv1.NetworkPolicyPort{{Port: intPort(3306)}},
+					},
+				}),
+			},
+			isolated: true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			v := EvalEgress(u.pp, src, dst, port)
+			assert.Equal(t, u.allowed, v.Allowed)
+			assert.Equal(t, u.isolated, v.Isolated)
+			assert.Equal(t, u.allowing, v.Allowing)
+		})
+	}
+}
+
+func TestParseNetPolPort(t *testing.T) {
+	uu := map[string]struct {
+		s   string
+		e   NetPolPort
+		err bool
+		str string
+	}{
+		"blank": {
+			str: "any/TCP",
+		},
+		"number": {
+			s:   "8080",
+			e:   NetPolPort{Port: 8080},
+			str: "8080/TCP",
+		},
+		"name": {
+			s:   "http",
+			e:   NetPolPort{Name: "http"},
+			str: "http/TCP",
+		},
+		"protocol": {
+			s:   "53/udp",
+			e:   NetPolPort{Port: 53, Protocol: v1.ProtocolUDP},
+			str: "53/UDP",
+		},
+		"bad-protocol": {
+			s:   "53/icmp",
+			err: true,
+		},
+		"bad-port": {
+			s:   "70000",
+			err: true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			p, err := ParseNetPolPort(u.s)
+			if u.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, u.e, p)
+			assert.Equal(t, u.str, p.String())
+		})
+	}
+}
+
+// Helpers...
+
+func makeNetPol(n, ns string, sel map[string]string, rr []netv1.NetworkPolicyIngressRule) netv1.NetworkPolicy {
+	return netv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: n, Namespace: ns},
+		Spec: netv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: sel},
+			Ingress:     rr,
+		},
+	}
+}
+
+func makeEgressNetPol(n string, rr []netv1.NetworkPolicyEgressRule) netv1.NetworkPolicy {
+	p := makeNetPol(n, "default", nil, nil)
+	p.Spec.PolicyTypes = []netv1.PolicyType{netv1.PolicyTypeEgress}
+	p.Spec.Egress = rr
+
+	return p
+}
+
+func intPort(p int) *intstr.IntOrString {
+	v := intstr.FromInt(p)
+	return &v
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func protoPtr(p v1.Protocol) *v1.Protocol {
+	return &p
+}
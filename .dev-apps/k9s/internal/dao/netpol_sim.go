@@ -0,0 +1,357 @@
+package dao
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/derailed/k9s/internal/client"
+	v1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// NetPolSim simulates network policies reachability between pods.
+type NetPolSim struct {
+	NonResource
+}
+
+// NetPolResult represents a reachability simulation between two peers.
+type NetPolResult struct {
+	Source      string
+	Destination string
+	Port        NetPolPort
+
+	// Egress the source egress verdict. Nil when the source is a namespace.
+	Egress *NetPolVerdict
+
+	// Ingress the destination ingress verdict.
+	Ingress NetPolVerdict
+}
+
+// Allowed checks if the traffic is allowed.
+func (r *NetPolResult) Allowed() bool {
+	return (r.Egress == nil || r.Egress.Allowed) && r.Ingress.Allowed
+}
+
+// Reason returns the policies responsible for the verdict.
+func (r *NetPolResult) Reason() string {
+	var ss []string
+	if r.Egress != nil && !r.Egress.Allowed {
+		ss = append(ss, "egress denied by "+strings.Join(r.Egress.Policies, ", "))
+	}
+	if !r.Ingress.Allowed {
+		ss = append(ss, "ingress denied by "+strings.Join(r.Ingress.Policies, ", "))
+	}
+	if len(ss) > 0 {
+		return strings.Join(ss, "; ")
+	}
+	if r.Egress != nil && len(r.Egress.Allowing) > 0 {
+		ss = append(ss, "egress allowed by "+strings.Join(r.Egress.Allowing, ", "))
+	}
+	if len(r.Ingress.Allowing) > 0 {
+		ss = append(ss, "ingress allowed by "+strings.Join(r.Ingress.Allowing, ", "))
+	}
+	if len(ss) == 0 {
+		return "no policies apply"
+	}
+
+	return strings.Join(ss, "; ")
+}
+
+// String returns a human readable simulation report.
+func (r *NetPolResult) String() string {
+	var b strings.Builder
+	verdict := "ALLOWED"
+	if !r.Allowed() {
+		verdict = "DENIED"
+	}
+	fmt.Fprintf(&b, "Source:      %s\nDestination: %s\nPort:        %s\nVerdict:     %s\n\n",
+		r.Source, r.Destination, r.Port, verdict)
+	if r.Egress == nil {
+		b.WriteString("Egress:  not evaluated for namespace sources\n")
+	} else {
+		writeVerdict(&b, "Egress: ", *r.Egress)
+	}
+	writeVerdict(&b, "Ingress:", r.Ingress)
+
+	return b.String()
+}
+
+// SimulateNetPol evaluates all applicable policies for traffic between a source
+// and a destination port. Egress rules are only evaluated for pod sources.
+func SimulateNetPol(pp []netv1.NetworkPolicy, src, dst NetPolPeer, port NetPolPort) NetPolResult {
+	r := NetPolResult{
+		Port:    port,
+		Ingress: EvalIngress(pp, src, dst, port),
+	}
+	if !src.IsNamespace() {
+		v := EvalEgress(pp, src, dst, port)
+		r.Egress = &v
+	}
+
+	return r
+}
+
+// NetPolMatrix represents pod to pod reachability within a namespace.
+type NetPolMatrix struct {
+	Namespace string
+	Port      NetPolPort
+	Pods      []string
+
+	// Results indexed by source then destination pod.
+	Results [][]NetPolResult
+}
+
+// String returns a human readable reachability matrix.
+func (m *NetPolMatrix) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Namespace: %s\nPort:      %s\n\n", m.Namespace, m.Port)
+	if len(m.Pods) == 0 {
+		b.WriteString("No pods found\n")
+		return b.String()
+	}
+
+	width := len("SRC \\ DST")
+	for _, p := range m.Pods {
+		if len(p) > width {
+			width = len(p)
+		}
+	}
+	fmt.Fprintf(&b, "%-*s", width+4, "SRC \\ DST")
+	for j := range m.Pods {
+		fmt.Fprintf(&b, " %3d", j+1)
+	}
+	b.WriteString("\n")
+	var denied []string
+	for i, src := range m.Pods {
+		fmt.Fprintf(&b, "%3d %-*s", i+1, width, src)
+		for j := range m.Pods {
+			r := m.Results[i][j]
+			switch {
+			case i == j:
+				b.WriteString("   -")
+			case r.Allowed():
+				b.WriteString("   ✓")
+			default:
+				b.WriteString("   ✗")
+				denied = append(denied, fmt.Sprintf("  %s → %s: %s", src, m.Pods[j], r.Reason()))
+			}
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n✓ allowed  ✗ denied  - self\n")
+	if len(denied) > 0 {
+		b.WriteString("\nDenied:\n")
+		b.WriteString(strings.Join(denied, "\n"))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// Simulate checks whether traffic from a source pod (ns/name) or namespace to a
+// destination pod (ns/name) port is allowed.
+func (s *NetPolSim) Simulate(src, dst, port string) (*NetPolResult, error) {
+	p, err := ParseNetPolPort(port)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.Contains(dst, "/") {
+		return nil, fmt.Errorf("expecting a destination pod of the form ns/name but got %q", dst)
+	}
+	dpo, err := s.pod(dst)
+	if err != nil {
+		return nil, err
+	}
+	dstPeer := NetPolPeer{Namespace: dpo.Namespace, NSLabels: s.nsLabels(dpo.Namespace), PodLabels: podLabels(dpo)}
+
+	var srcPeer NetPolPeer
+	if strings.Contains(src, "/") {
+		spo, err := s.pod(src)
+		if err != nil {
+			return nil, err
+		}
+		srcPeer = NetPolPeer{Namespace: spo.Namespace, NSLabels: s.nsLabels(spo.Namespace), PodLabels: podLabels(spo)}
+	} else {
+		if src == "" {
+			return nil, fmt.Errorf("a source pod or namespace is required")
+		}
+		srcPeer = NetPolPeer{Namespace: src, NSLabels: s.nsLabels(src)}
+	}
+
+	pp, err := s.policies(client.AllNamespaces)
+	if err != nil {
+		return nil, err
+	}
+	r := SimulateNetPol(pp, srcPeer, dstPeer, ResolveNetPolPort(dpo, p))
+	r.Source, r.Destination = src, dst
+
+	return &r, nil
+}
+
+// Matrix checks reachability between all pods in a given namespace.
+func (s *NetPolSim) Matrix(ns, port string) (*NetPolMatrix, error) {
+	p, err := ParseNetPolPort(port)
+	if err != nil {
+		return nil, err
+	}
+	if !client.IsNamespaced(ns) {
+		return nil, fmt.Errorf("a namespace is required")
+	}
+	oo, err := s.GetFactory().List("v1/pods", ns, true, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	pods := make([]v1.Pod, 0, len(oo))
+	for _, o := range oo {
+		var po v1.Pod
+		if err := fromUnstructured(o, &po); err != nil {
+			return nil, err
+		}
+		if po.Status.Phase == v1.PodSucceeded || po.Status.Phase == v1.PodFailed {
+			continue
+		}
+		pods = append(pods, po)
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Name < pods[j].Name
+	})
+	pp, err := s.policies(ns)
+	if err != nil {
+		return nil, err
+	}
+
+	return NetPolPodMatrix(pp, ns, s.nsLabels(ns), pods, p), nil
+}
+
+// NetPolPodMatrix evaluates reachability between all given pods.
+func NetPolPodMatrix(pp []netv1.NetworkPolicy, ns string, nsLabels map[string]string, pods []v1.Pod, port NetPolPort) *NetPolMatrix {
+	m := NetPolMatrix{
+		Namespace: ns,
+		Port:      port,
+		Pods:      make([]string, 0, len(pods)),
+		Results:   make([][]NetPolResult, len(pods)),
+	}
+	peers := make([]NetPolPeer, 0, len(pods))
+	for i := range pods {
+		m.Pods = append(m.Pods, pods[i].Name)
+		peers = append(peers, NetPolPeer{Namespace: ns, NSLabels: nsLabels, PodLabels: podLabels(&pods[i])})
+	}
+	for i := range pods {
+		m.Results[i] = make([]NetPolResult, len(pods))
+		for j := range pods {
+			r := SimulateNetPol(pp, peers[i], peers[j], ResolveNetPolPort(&pods[j], port))
+			r.Source, r.Destination = client.FQN(ns, pods[i].Name), client.FQN(ns, pods[j].Name)
+			m.Results[i][j] = r
+		}
+	}
+
+	return &m
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func (s *NetPolSim) pod(fqn string) (*v1.Pod, error) {
+	o, err := s.GetFactory().Get("v1/pods", fqn, true, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var po v1.Pod
+	if err := fromUnstructured(o, &po); err != nil {
+		return nil, err
+	}
+
+	return &po, nil
+}
+
+// nsLabels returns a namespace labels. Namespaces may not be readable by the
+// current user in which case only the well known name label is used.
+func (s *NetPolSim) nsLabels(ns string) map[string]string {
+	o, err := s.GetFactory().Get("v1/namespaces", client.FQN(client.ClusterScope, ns), true, labels.Everything())
+	if err != nil {
+		return nil
+	}
+	u, ok := o.(*unstructured.Unstructured)
+	if !ok {
+		return nil
+	}
+
+	return u.GetLabels()
+}
+
+func (s *NetPolSim) policies(ns string) ([]netv1.NetworkPolicy, error) {
+	oo, err := s.GetFactory().List("networking.k8s.io/v1/networkpolicies", ns, true, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	pp := make([]netv1.NetworkPolicy, 0, len(oo))
+	for _, o := range oo {
+		var p netv1.NetworkPolicy
+		if err := fromUnstructured(o, &p); err != nil {
+			return nil, err
+		}
+		pp = append(pp, p)
+	}
+
+	return pp, nil
+}
+
+// podLabels returns a pod labels. A pod without labels still stands for a
+// single pod hence the labels are never nil.
+func podLabels(po *v1.Pod) map[string]string {
+	if po.Labels == nil {
+		return map[string]string{}
+	}
+
+	return po.Labels
+}
+
+// resolvePort fills in a port number or name given the pod container ports.
+func ResolveNetPolPort(po *v1.Pod, port NetPolPort) NetPolPort {
+	if port.IsAny() || (port.Port != 0 && port.Name != "") {
+		return port
+	}
+	proto := port.Protocol
+	if proto == "" {
+		proto = v1.ProtocolTCP
+	}
+	for _, co := range po.Spec.Containers {
+		for _, p := range co.Ports {
+			pproto := p.Protocol
+			if pproto == "" {
+				pproto = v1.ProtocolTCP
+			}
+			if pproto != proto {
+				continue
+			}
+			if port.Name != "" && p.Name == port.Name {
+				port.Port = p.ContainerPort
+				return port
+			}
+			if port.Port != 0 && p.ContainerPort == port.Port {
+				port.Name = p.Name
+				return port
+			}
+		}
+	}
+
+	return port
+}
+
+func writeVerdict(b *strings.Builder, title string, v NetPolVerdict) {
+	if !v.Isolated {
+		fmt.Fprintf(b, "%s not isolated, no policies apply\n", title)
+		return
+	}
+	fmt.Fprintf(b, "%s isolated by %s\n", title, strings.Join(v.Policies, ", "))
+	if len(v.Allowing) == 0 {
+		b.WriteString("  ✗ no rule allows this traffic\n")
+		return
+	}
+	for _, p := range v.Allowing {
+		fmt.Fprintf(b, "  ✓ allowed by %s\n", p)
+	}
+}
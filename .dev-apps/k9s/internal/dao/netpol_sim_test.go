@@ -0,0 +1,161 @@
+package dao
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestSimulateNetPol(t *testing.T) {
+	var (
+		web  = NetPolPeer{Namespace: "default", PodLabels: map[string]string{"app": "web"}}
+		db   = NetPolPeer{Namespace: "default", PodLabels: map[string]string{"app": "db"}}
+		port = NetPolPort{Port: 5432, Name: "pg"}
+		pp   = []netv1.NetworkPolicy{
+			makeNetPol("db", "default", map[string]string{"app": "db"}, []netv1.NetworkPolicyIngressRule{
+				{From: []netv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}}}},
+			}),
+		}
+	)
+
+	uu := map[string]struct {
+		src, dst NetPolPeer
+		pp       []netv1.NetworkPolicy
+		allowed  bool
+		egress   bool
+		reason   string
+	}{
+		"open": {
+			src:     web,
+			dst:     db,
+			allowed: true,
+			egress:  true,
+			reason:  "no policies apply",
+		},
+		"allowed": {
+			src:     web,
+			dst:     db,
+			pp:      pp,
+			allowed: true,
+			egress:  true,
+			reason:  "ingress allowed by default/db",
+		},
+		"denied": {
+			src:    NetPolPeer{Namespace: "default", PodLabels: map[string]string{"app": "fred"}},
+			dst:    db,
+			pp:     pp,
+			egress: true,
+			reason: "ingress denied by default/db",
+		},
+		"egress-denied": {
+			src:    web,
+			dst:    db,
+			pp:     append(pp, makeEgressNetPol("deny", nil)),
+			egress: true,
+			reason: "egress denied by default/deny",
+		},
+		"namespace": {
+			src:     NetPolPeer{Namespace: "default"},
+			dst:     db,
+			pp:      append(pp, makeEgressNetPol("deny", nil)),
+			allowed: true,
+			reason:  "ingress allowed by default/db",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			r := SimulateNetPol(u.pp, u.src, u.dst, port)
+			assert.Equal(t, u.allowed, r.Allowed())
+			assert.Equal(t, u.egress, r.Egress != nil)
+			assert.Equal(t, u.reason, r.Reason())
+		})
+	}
+}
+
+func TestNetPolPodMatrix(t *testing.T) {
+	pods := []v1.Pod{
+		makeNetPolPod("db", map[string]string{"app": "db"}),
+		makeNetPolPod("web", map[string]string{"app": "web"}),
+	}
+	pp := []netv1.NetworkPolicy{
+		makeNetPol("db", "default", map[string]string{"app": "db"}, []netv1.NetworkPolicyIngressRule{
+			{
+				From:  []netv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}}},
+				Ports: []netv1.NetworkPolicyPort{{Port: &intstr.IntOrString{Type: intstr.String, StrVal: "pg"}}},
+			},
+		}),
+		makeNetPol("web", "default", map[string]string{"app": "web"}, nil),
+	}
+
+	m := NetPolPodMatrix(pp, "default", nil, pods, NetPolPort{Port: 5432})
+	assert.Equal(t, []string{"db", "web"}, m.Pods)
+	assert.False(t, m.Results[0][1].Allowed())
+	assert.True(t, m.Results[1][0].Allowed())
+	assert.Equal(t, "default/web", m.Results[1][0].Source)
+	assert.Equal(t, "pg", m.Results[1][0].Port.Name)
+	assert.Equal(t, `Namespace: default
+Port:      5432/TCP
+
+SRC \ DST       1   2
+  1 db          -   ✗
+  2 web         ✓   -
+
+✓ allowed  ✗ denied  - self
+
+Denied:
+  db → web: ingress denied by default/web
+`, m.String())
+}
+
+func TestResolvePort(t *testing.T) {
+	po := makeNetPolPod("db", nil)
+
+	uu := map[string]struct {
+		port, e NetPolPort
+	}{
+		"any": {},
+		"number": {
+			port: NetPolPort{Port: 5432},
+			e:    NetPolPort{Port: 5432, Name: "pg"},
+		},
+		"name": {
+			port: NetPolPort{Name: "pg"},
+			e:    NetPolPort{Port: 5432, Name: "pg"},
+		},
+		"protocol": {
+			port: NetPolPort{Name: "pg", Protocol: v1.ProtocolUDP},
+			e:    NetPolPort{Name: "pg", Protocol: v1.ProtocolUDP},
+		},
+		"unknown": {
+			port: NetPolPort{Port: 80},
+			e:    NetPolPort{Port: 80},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, ResolveNetPolPort(&po, u.port))
+		})
+	}
+}
+
+// Helpers...
+
+func makeNetPolPod(n string, ll map[string]string) v1.Pod {
+	return v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: n, Namespace: "default", Labels: ll},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{
+				Name:  n,
+				Ports: []v1.ContainerPort{{Name: "pg", ContainerPort: 5432}},
+			}},
+		},
+	}
+}
@@ -98,6 +98,7 @@ func (p *Pod) List(ctx context.Context, ns string) ([]runtime.Object, error) {
 			return res, fmt.Errorf("expecting *unstructured.Unstructured but got `%T", o)
 		}
 		fqn := extractFQN(o)
+		UsageSamples().Record(u, pmx[fqn])
 		if nodeName == "" {
 			res = append(res, &render.PodWithMetrics{Raw: u, MX: pmx[fqn]})
 			continue
@@ -48,10 +48,36 @@ func (readWriteCloser) Close() error {
 
 // List returns a collection of aliases.
 func (p *Popeye) List(ctx context.Context, ns string) ([]runtime.Object, error) {
+	run, err := p.Sanitize(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+	// Only full runs are recorded.
+	report, _ := ctx.Value(internal.KeyPath).(string)
+	if dir, ok := ctx.Value(internal.KeyDir).(string); ok && dir != "" && report == "" {
+		if _, err := NewPopeyeStore(dir).Record(run); err != nil {
+			log.Warn().Err(err).Msgf("Popeye run save failed %s", dir)
+		}
+	}
+
+	oo := make([]runtime.Object, 0, len(run.Report.Sections))
+	for _, s := range run.Report.Sections {
+		if s.Tally.Sum() > 0 {
+			oo = append(oo, s)
+		}
+	}
+
+	return oo, nil
+}
+
+// Sanitize runs the sanitizer and returns a timestamped run. When a report path
+// is set on the context only that section is sanitized.
+func (p *Popeye) Sanitize(ctx context.Context, ns string) (run *PopeyeRun, err error) {
 	defer func(t time.Time) {
 		log.Debug().Msgf("Popeye -- Elapsed %v", time.Since(t))
-		if err := recover(); err != nil {
+		if e := recover(); e != nil {
 			log.Debug().Msgf("POPEYE DIED!")
+			err = fmt.Errorf("popeye sanitize failed: %v", e)
 		}
 	}(time.Now())
 
@@ -65,8 +91,10 @@ func (p *Popeye) List(ctx context.Context, ns string) ([]runtime.Object, error)
 		flags.Sections = &sections
 		flags.ActiveNamespace = &ns
 	}
+	run = &PopeyeRun{Time: time.Now(), Namespace: ns}
 	spinach := filepath.Join(cfg.K9sHome(), "spinach.yml")
 	if c, err := p.GetFactory().Client().Config().CurrentContextName(); err == nil {
+		run.Context = c
 		spinach = filepath.Join(cfg.K9sHome(), fmt.Sprintf("%s_spinach.yml", c))
 	}
 	if _, err := os.Stat(spinach); err == nil {
@@ -93,17 +121,13 @@ func (p *Popeye) List(ctx context.Context, ns string) ([]runtime.Object, error)
 	if err = json.Unmarshal(buff.Bytes(), &b); err != nil {
 		return nil, err
 	}
-
-	oo := make([]runtime.Object, 0, len(b.Report.Sections))
 	sort.Sort(b.Report.Sections)
 	for _, s := range b.Report.Sections {
 		s.Tally.Count = len(s.Outcome)
-		if s.Tally.Sum() > 0 {
-			oo = append(oo, s)
-		}
 	}
+	run.Report = b.Report
 
-	return oo, nil
+	return run, nil
 }
 
 // Get retrieves a resource.
@@ -0,0 +1,219 @@
+package dao
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/derailed/k9s/internal/render"
+	pconfig "github.com/derailed/popeye/pkg/config"
+)
+
+const (
+	// PopeyeExportJSON exports a sanitizer run as JSON.
+	PopeyeExportJSON = "json"
+
+	// PopeyeExportJUnit exports a sanitizer run as JUnit XML.
+	PopeyeExportJUnit = "junit"
+
+	// PopeyeExportHTML exports a sanitizer run as HTML.
+	PopeyeExportHTML = "html"
+)
+
+// PopeyeExportFormats returns the supported export formats.
+func PopeyeExportFormats() []string {
+	return []string{PopeyeExportJSON, PopeyeExportJUnit, PopeyeExportHTML}
+}
+
+// PopeyeExportExt returns the file extension for a given export format.
+func PopeyeExportExt(format string) string {
+	switch format {
+	case PopeyeExportJUnit:
+		return ".xml"
+	case PopeyeExportHTML:
+		return ".html"
+	default:
+		return ".json"
+	}
+}
+
+// ExportPopeye writes out a sanitizer run in the given format.
+func ExportPopeye(w io.Writer, r *PopeyeRun, format string) error {
+	switch format {
+	case PopeyeExportJSON:
+		e := json.NewEncoder(w)
+		e.SetIndent("", "  ")
+		return e.Encode(r)
+	case PopeyeExportJUnit:
+		return exportJUnit(w, r)
+	case PopeyeExportHTML:
+		return popeyeHTML.Execute(w, newHTMLRun(r))
+	default:
+		return fmt.Errorf("invalid export format %q. Must be one of %s", format, strings.Join(PopeyeExportFormats(), "|"))
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+type (
+	junitSuites struct {
+		XMLName  xml.Name     `xml:"testsuites"`
+		Name     string       `xml:"name,attr"`
+		Tests    int          `xml:"tests,attr"`
+		Failures int          `xml:"failures,attr"`
+		Suites   []junitSuite `xml:"testsuite"`
+	}
+
+	junitSuite struct {
+		Name      string      `xml:"name,attr"`
+		Tests     int         `xml:"tests,attr"`
+		Failures  int         `xml:"failures,attr"`
+		Timestamp string      `xml:"timestamp,attr"`
+		Cases     []junitCase `xml:"testcase"`
+	}
+
+	junitCase struct {
+		Name      string        `xml:"name,attr"`
+		ClassName string        `xml:"classname,attr"`
+		Failure   *junitFailure `xml:"failure,omitempty"`
+	}
+
+	junitFailure struct {
+		Message string `xml:"message,attr"`
+		Type    string `xml:"type,attr"`
+		Text    string `xml:",chardata"`
+	}
+)
+
+// exportJUnit writes out a sanitizer run as a JUnit report. Each section is a
+// suite and each resource a test case failing on warnings or errors.
+func exportJUnit(w io.Writer, r *PopeyeRun) error {
+	ss := junitSuites{Name: "popeye"}
+	for _, s := range r.Report.Sections {
+		suite := junitSuite{Name: s.Title, Timestamp: r.Time.UTC().Format("2006-01-02T15:04:05")}
+		for _, res := range sortedOutcome(s.Outcome) {
+			issues := s.Outcome[res]
+			c := junitCase{Name: res, ClassName: s.Title}
+			if l := issues.MaxSeverity(); l >= pconfig.WarnLevel {
+				var mm []string
+				for _, is := range issues {
+					if is.Level >= pconfig.WarnLevel {
+						mm = append(mm, fmt.Sprintf("[%s] %s", popeyeLevel(is.Level), is.Message))
+					}
+				}
+				c.Failure = &junitFailure{
+					Message: fmt.Sprintf("%d issue(s)", len(mm)),
+					Type:    popeyeLevel(l),
+					Text:    strings.Join(mm, "\n"),
+				}
+				suite.Failures++
+			}
+			suite.Cases = append(suite.Cases, c)
+		}
+		suite.Tests = len(suite.Cases)
+		ss.Tests += suite.Tests
+		ss.Failures += suite.Failures
+		ss.Suites = append(ss.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	e := xml.NewEncoder(w)
+	e.Indent("", "  ")
+	if err := e.Encode(ss); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+
+	return err
+}
+
+type (
+	htmlRun struct {
+		*PopeyeRun
+		Date     string
+		Sections []htmlSection
+	}
+
+	htmlSection struct {
+		Title     string
+		Score     int
+		Resources []htmlResource
+	}
+
+	htmlResource struct {
+		Name   string
+		Issues []htmlIssue
+	}
+
+	htmlIssue struct {
+		Level   string
+		Message string
+	}
+)
+
+func newHTMLRun(r *PopeyeRun) htmlRun {
+	h := htmlRun{PopeyeRun: r, Date: r.Time.UTC().Format(popeyeTimeFmt + " MST")}
+	for _, s := range r.Report.Sections {
+		hs := htmlSection{Title: s.Title}
+		if s.Tally != nil {
+			hs.Score = s.Tally.Score()
+		}
+		for _, res := range sortedOutcome(s.Outcome) {
+			hr := htmlResource{Name: res}
+			for _, is := range s.Outcome[res] {
+				if is.Level < pconfig.InfoLevel {
+					continue
+				}
+				hr.Issues = append(hr.Issues, htmlIssue{Level: popeyeLevel(is.Level), Message: is.Message})
+			}
+			if len(hr.Issues) > 0 {
+				hs.Resources = append(hs.Resources, hr)
+			}
+		}
+		h.Sections = append(h.Sections, hs)
+	}
+
+	return h
+}
+
+func sortedOutcome(o render.Outcome) []string {
+	kk := make([]string, 0, len(o))
+	for k := range o {
+		kk = append(kk, k)
+	}
+	sort.Strings(kk)
+
+	return kk
+}
+
+var popeyeHTML = template.Must(template.New("popeye").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Popeye {{.Context}} {{.Date}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; width: 100%; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+.ERROR { color: #c0392b; } .WARN { color: #d35400; } .INFO { color: #2980b9; }
+</style>
+</head>
+<body>
+<h1>Popeye {{.Context}}</h1>
+<p>{{.Date}}{{if .Namespace}} namespace {{.Namespace}}{{end}} &mdash; score {{.Report.Score}} ({{.Report.Grade}})</p>
+{{range .Sections}}<h2>{{.Title}} &mdash; {{.Score}}%</h2>
+{{if .Resources}}<table>
+<tr><th>Resource</th><th>Issues</th></tr>
+{{range .Resources}}<tr><td>{{.Name}}</td><td>{{range .Issues}}<div class="{{.Level}}">[{{.Level}}] {{.Message}}</div>{{end}}</td></tr>
+{{end}}</table>
+{{else}}<p>No issues</p>
+{{end}}{{end}}</body>
+</html>
+`))
@@ -0,0 +1,80 @@
+package dao
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/derailed/k9s/internal/render"
+	pconfig "github.com/derailed/popeye/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportPopeye(t *testing.T) {
+	run := makePopeyeRun(0, 70, map[string]render.Issues{
+		"default/p1": {makeIssue(pconfig.WarnLevel, "no probes"), makeIssue(pconfig.InfoLevel, "<fred>")},
+		"default/p2": {makeIssue(pconfig.OkLevel, "")},
+	})
+
+	uu := map[string]struct {
+		format string
+		err    bool
+		ee     []string
+	}{
+		"junit": {
+			format: PopeyeExportJUnit,
+			ee: []string{
+				`<?xml version="1.0" encoding="UTF-8"?>`,
+				`<testsuites name="popeye" tests="2" failures="1">`,
+				`<testsuite name="pods" tests="2" failures="1" timestamp="2024-01-01T00:00:00">`,
+				`<testcase name="default/p1" classname="pods">`,
+				`<failure message="1 issue(s)" type="WARN">[WARN] no probes</failure>`,
+				`<testcase name="default/p2" classname="pods"></testcase>`,
+			},
+		},
+		"html": {
+			format: PopeyeExportHTML,
+			ee: []string{
+				`<title>Popeye fred 2024-01-01 00:00:00 UTC</title>`,
+				`score 70 (C)`,
+				`<h2>pods &mdash; 50%</h2>`,
+				`<div class="INFO">[INFO] &lt;fred&gt;</div>`,
+			},
+		},
+		"json": {
+			format: PopeyeExportJSON,
+			ee:     []string{`"context": "fred"`, `"score": 70`},
+		},
+		"toast": {
+			format: "yaml",
+			err:    true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			var b bytes.Buffer
+			err := ExportPopeye(&b, run, u.format)
+			if u.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			for _, e := range u.ee {
+				assert.Contains(t, b.String(), e)
+			}
+		})
+	}
+}
+
+func TestExportPopeyeJSONRoundTrip(t *testing.T) {
+	run := makePopeyeRun(0, 90, map[string]render.Issues{"default/p1": {makeIssue(pconfig.ErrorLevel, "crashing")}})
+	var b bytes.Buffer
+	assert.Nil(t, ExportPopeye(&b, run, PopeyeExportJSON))
+
+	var r PopeyeRun
+	assert.Nil(t, json.Unmarshal(b.Bytes(), &r))
+	assert.True(t, DiffPopeye(run, &r).Empty())
+	assert.Equal(t, run.Time, r.Time)
+}
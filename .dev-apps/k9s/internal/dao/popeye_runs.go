@@ -0,0 +1,342 @@
+package dao
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/render"
+	pconfig "github.com/derailed/popeye/pkg/config"
+)
+
+const (
+	// PopeyeMaxRuns tracks the max number of sanitizer runs kept per context.
+	PopeyeMaxRuns = 100
+
+	// PopeyeRunInterval tracks the interval after which an unchanged run is saved again.
+	PopeyeRunInterval = time.Hour
+
+	popeyeRunFmt  = "20060102T150405Z"
+	popeyeTimeFmt = "2006-01-02 15:04:05"
+	popeyeRunExt  = ".json"
+)
+
+// PopeyeRun represents a timestamped sanitizer run.
+type PopeyeRun struct {
+	Time      time.Time     `json:"time"`
+	Context   string        `json:"context,omitempty"`
+	Namespace string        `json:"namespace,omitempty"`
+	Report    render.Report `json:"popeye"`
+}
+
+// Tally returns the run issues tally across all sections.
+func (r *PopeyeRun) Tally() render.Tally {
+	var t render.Tally
+	for _, s := range r.Report.Sections {
+		if s.Tally == nil {
+			continue
+		}
+		t.OK += s.Tally.OK
+		t.Info += s.Tally.Info
+		t.Warning += s.Tally.Warning
+		t.Error += s.Tally.Error
+		t.Count += s.Tally.Count
+	}
+
+	return t
+}
+
+// PopeyeIssue represents a resource issue in a given section.
+type PopeyeIssue struct {
+	Section  string
+	Resource string
+	Level    pconfig.Level
+	Message  string
+}
+
+// String returns an issue representation.
+func (i PopeyeIssue) String() string {
+	return fmt.Sprintf("[%s] %s: %s", popeyeLevel(i.Level), i.Resource, i.Message)
+}
+
+// PopeyeIssues returns all the run warnings and errors sorted by section and resource.
+func (r *PopeyeRun) PopeyeIssues() []PopeyeIssue {
+	var ii []PopeyeIssue
+	for _, s := range r.Report.Sections {
+		for res, issues := range s.Outcome {
+			for _, is := range issues {
+				if is.Level < pconfig.WarnLevel {
+					continue
+				}
+				ii = append(ii, PopeyeIssue{Section: s.Title, Resource: res, Level: is.Level, Message: is.Message})
+			}
+		}
+	}
+	sort.Slice(ii, func(i, j int) bool {
+		if ii[i].Section != ii[j].Section {
+			return ii[i].Section < ii[j].Section
+		}
+		if ii[i].Resource != ii[j].Resource {
+			return ii[i].Resource < ii[j].Resource
+		}
+		return ii[i].Message < ii[j].Message
+	})
+
+	return ii
+}
+
+// PopeyeSectionDiff represents new and resolved issues in a section.
+type PopeyeSectionDiff struct {
+	Section  string
+	New      []PopeyeIssue
+	Resolved []PopeyeIssue
+}
+
+// PopeyeDiff represents the changes between two sanitizer runs.
+type PopeyeDiff struct {
+	From, To *PopeyeRun
+	Sections []PopeyeSectionDiff
+}
+
+// Empty checks if the runs are issues wise identical.
+func (d *PopeyeDiff) Empty() bool {
+	return len(d.Sections) == 0
+}
+
+// String returns a human readable diff.
+func (d *PopeyeDiff) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Changes from %s (score %d) to %s (score %d)\n",
+		d.From.Time.Local().Format(popeyeTimeFmt), d.From.Report.Score,
+		d.To.Time.Local().Format(popeyeTimeFmt), d.To.Report.Score)
+	if d.Empty() {
+		b.WriteString("  No issues changes\n")
+		return b.String()
+	}
+	for _, s := range d.Sections {
+		fmt.Fprintf(&b, "\n%s (+%d -%d)\n", s.Section, len(s.New), len(s.Resolved))
+		for _, i := range s.New {
+			fmt.Fprintf(&b, "  + %s\n", i)
+		}
+		for _, i := range s.Resolved {
+			fmt.Fprintf(&b, "  - %s\n", i)
+		}
+	}
+
+	return b.String()
+}
+
+// DiffPopeye computes new and resolved warnings and errors per section between two runs.
+func DiffPopeye(from, to *PopeyeRun) *PopeyeDiff {
+	d := PopeyeDiff{From: from, To: to}
+	prev, cur := issueSet(from), issueSet(to)
+	sections := make(map[string]*PopeyeSectionDiff)
+	section := func(n string) *PopeyeSectionDiff {
+		if s, ok := sections[n]; ok {
+			return s
+		}
+		s := PopeyeSectionDiff{Section: n}
+		sections[n] = &s
+		return &s
+	}
+	for _, i := range to.PopeyeIssues() {
+		if _, ok := prev[i]; !ok {
+			s := section(i.Section)
+			s.New = append(s.New, i)
+		}
+	}
+	for _, i := range from.PopeyeIssues() {
+		if _, ok := cur[i]; !ok {
+			s := section(i.Section)
+			s.Resolved = append(s.Resolved, i)
+		}
+	}
+	for _, s := range sections {
+		d.Sections = append(d.Sections, *s)
+	}
+	sort.Slice(d.Sections, func(i, j int) bool {
+		return d.Sections[i].Section < d.Sections[j].Section
+	})
+
+	return &d
+}
+
+// PopeyeStore persists sanitizer runs as timestamped JSON files.
+type PopeyeStore struct {
+	dir string
+	mx  sync.Mutex
+}
+
+// NewPopeyeStore returns a new store.
+func NewPopeyeStore(dir string) *PopeyeStore {
+	return &PopeyeStore{dir: dir}
+}
+
+// Dir returns the store directory.
+func (p *PopeyeStore) Dir() string {
+	return p.dir
+}
+
+// Save persists a run and prunes older runs past PopeyeMaxRuns.
+func (p *PopeyeStore) Save(r *PopeyeRun) (string, error) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	config.EnsureFullPath(p.dir, config.DefaultDirMod)
+	raw, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(p.dir, r.Time.UTC().Format(popeyeRunFmt)+popeyeRunExt)
+	if err := os.WriteFile(path, raw, config.DefaultFileMod); err != nil {
+		return "", err
+	}
+
+	return path, p.prune(PopeyeMaxRuns)
+}
+
+// Record saves a run unless it matches the latest run saved within PopeyeRunInterval.
+func (p *PopeyeStore) Record(r *PopeyeRun) (bool, error) {
+	rr, err := p.Runs(1)
+	if err != nil {
+		return false, err
+	}
+	if len(rr) > 0 && r.Time.Sub(rr[0].Time) < PopeyeRunInterval && DiffPopeye(rr[0], r).Empty() && rr[0].Report.Score == r.Report.Score {
+		return false, nil
+	}
+	if _, err := p.Save(r); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Runs returns up to n runs, newest first. A non positive n returns all runs.
+func (p *PopeyeStore) Runs(n int) ([]*PopeyeRun, error) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	ff, err := p.files()
+	if err != nil {
+		return nil, err
+	}
+	if n > 0 && len(ff) > n {
+		ff = ff[:n]
+	}
+	rr := make([]*PopeyeRun, 0, len(ff))
+	for _, f := range ff {
+		r, err := LoadPopeyeRun(filepath.Join(p.dir, f))
+		if err != nil {
+			return nil, err
+		}
+		rr = append(rr, r)
+	}
+
+	return rr, nil
+}
+
+// LoadPopeyeRun loads a saved sanitizer run.
+func LoadPopeyeRun(path string) (*PopeyeRun, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r PopeyeRun
+	if err := json.Unmarshal(raw, &r); err != nil {
+		return nil, fmt.Errorf("invalid popeye run %s: %w", path, err)
+	}
+
+	return &r, nil
+}
+
+// PopeyeHistory returns a human readable runs history along with the changes
+// since the previous run. Runs are expected newest first.
+func PopeyeHistory(rr []*PopeyeRun) string {
+	if len(rr) == 0 {
+		return "No sanitizer runs recorded yet\n"
+	}
+	var b strings.Builder
+	b.WriteString("Runs (newest first)\n")
+	for _, r := range rr {
+		t := r.Tally()
+		fmt.Fprintf(&b, "  %s  score %3d (%s)  errors %-4d warnings %-4d\n",
+			r.Time.Local().Format(popeyeTimeFmt), r.Report.Score, r.Report.Grade, t.Error, t.Warning)
+	}
+	if len(rr) > 1 {
+		b.WriteString("\n")
+		b.WriteString(DiffPopeye(rr[1], rr[0]).String())
+	}
+
+	return b.String()
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+// files returns the saved runs file names, newest first.
+func (p *PopeyeStore) files() ([]string, error) {
+	ee, err := os.ReadDir(p.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	ff := make([]string, 0, len(ee))
+	for _, e := range ee {
+		if e.IsDir() || filepath.Ext(e.Name()) != popeyeRunExt {
+			continue
+		}
+		ff = append(ff, e.Name())
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(ff)))
+
+	return ff, nil
+}
+
+func (p *PopeyeStore) prune(max int) error {
+	ff, err := p.files()
+	if err != nil || len(ff) <= max {
+		return err
+	}
+	for _, f := range ff[max:] {
+		if err := os.Remove(filepath.Join(p.dir, f)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func issueSet(r *PopeyeRun) map[PopeyeIssue]struct{} {
+	ii := r.PopeyeIssues()
+	m := make(map[PopeyeIssue]struct{}, len(ii))
+	for _, i := range ii {
+		m[i] = struct{}{}
+	}
+
+	return m
+}
+
+func popeyeLevel(l pconfig.Level) string {
+	switch l {
+	case pconfig.OkLevel:
+		return "OK"
+	case pconfig.InfoLevel:
+		return "INFO"
+	case pconfig.WarnLevel:
+		return "WARN"
+	case pconfig.ErrorLevel:
+		return "ERROR"
+	default:
+		return "N/A"
+	}
+}
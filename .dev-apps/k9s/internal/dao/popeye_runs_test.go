@@ -0,0 +1,153 @@
+package dao
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/render"
+	pconfig "github.com/derailed/popeye/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffPopeye(t *testing.T) {
+	uu := map[string]struct {
+		from, to *PopeyeRun
+		e        []PopeyeSectionDiff
+	}{
+		"same": {
+			from: makePopeyeRun(0, 90, map[string]render.Issues{"default/p1": {makeIssue(pconfig.WarnLevel, "no probes")}}),
+			to:   makePopeyeRun(1, 90, map[string]render.Issues{"default/p1": {makeIssue(pconfig.WarnLevel, "no probes")}}),
+		},
+		"info-ignored": {
+			from: makePopeyeRun(0, 90, nil),
+			to:   makePopeyeRun(1, 90, map[string]render.Issues{"default/p1": {makeIssue(pconfig.InfoLevel, "blee")}}),
+		},
+		"new-resolved": {
+			from: makePopeyeRun(0, 80, map[string]render.Issues{
+				"default/p1": {makeIssue(pconfig.WarnLevel, "no probes")},
+				"default/p2": {makeIssue(pconfig.ErrorLevel, "crashing")},
+			}),
+			to: makePopeyeRun(1, 70, map[string]render.Issues{
+				"default/p1": {makeIssue(pconfig.WarnLevel, "no probes"), makeIssue(pconfig.ErrorLevel, "no limits")},
+			}),
+			e: []PopeyeSectionDiff{
+				{
+					Section:  "pods",
+					New:      []PopeyeIssue{{Section: "pods", Resource: "default/p1", Level: pconfig.ErrorLevel, Message: "no limits"}},
+					Resolved: []PopeyeIssue{{Section: "pods", Resource: "default/p2", Level: pconfig.ErrorLevel, Message: "crashing"}},
+				},
+			},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			d := DiffPopeye(u.from, u.to)
+			assert.Equal(t, u.e, d.Sections)
+			assert.Equal(t, len(u.e) == 0, d.Empty())
+		})
+	}
+}
+
+func TestPopeyeStore(t *testing.T) {
+	s := NewPopeyeStore(filepath.Join(t.TempDir(), "popeye"))
+
+	rr, err := s.Runs(0)
+	assert.Nil(t, err)
+	assert.Empty(t, rr)
+
+	issues := map[string]render.Issues{"default/p1": {makeIssue(pconfig.WarnLevel, "no probes")}}
+	ok, err := s.Record(makePopeyeRun(0, 90, issues))
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	// Unchanged runs are only saved once within the interval.
+	ok, err = s.Record(makePopeyeRun(1, 90, issues))
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	ok, err = s.Record(makePopeyeRun(2, 80, nil))
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	ok, err = s.Record(makePopeyeRun(int(PopeyeRunInterval/time.Minute)+3, 80, nil))
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	rr, err = s.Runs(2)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(rr))
+	assert.True(t, rr[0].Time.After(rr[1].Time))
+	assert.Equal(t, 80, rr[0].Report.Score)
+
+	rr, err = s.Runs(0)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(rr))
+	assert.Equal(t, 1, rr[2].Tally().Warning)
+}
+
+func TestPopeyeStorePrune(t *testing.T) {
+	s := NewPopeyeStore(t.TempDir())
+	for i := 0; i < PopeyeMaxRuns+2; i++ {
+		_, err := s.Save(makePopeyeRun(i, 90, nil))
+		assert.Nil(t, err)
+	}
+
+	ee, err := os.ReadDir(s.Dir())
+	assert.Nil(t, err)
+	assert.Equal(t, PopeyeMaxRuns, len(ee))
+	rr, err := s.Runs(0)
+	assert.Nil(t, err)
+	assert.Equal(t, makePopeyeRun(PopeyeMaxRuns+1, 90, nil).Time.Unix(), rr[0].Time.Unix())
+}
+
+func TestPopeyeHistory(t *testing.T) {
+	assert.Equal(t, "No sanitizer runs recorded yet\n", PopeyeHistory(nil))
+
+	rr := []*PopeyeRun{
+		makePopeyeRun(1, 70, map[string]render.Issues{"default/p1": {makeIssue(pconfig.ErrorLevel, "no limits")}}),
+		makePopeyeRun(0, 90, nil),
+	}
+	h := PopeyeHistory(rr)
+	assert.Contains(t, h, "score  70 (C)  errors 1    warnings 0")
+	assert.Contains(t, h, "pods (+1 -0)\n  + [ERROR] default/p1: no limits\n")
+}
+
+// Helpers...
+
+func makePopeyeRun(mins, score int, outcome map[string]render.Issues) *PopeyeRun {
+	var tally render.Tally
+	for _, ii := range outcome {
+		switch ii.MaxSeverity() {
+		case pconfig.ErrorLevel:
+			tally.Error++
+		case pconfig.WarnLevel:
+			tally.Warning++
+		default:
+			tally.OK++
+		}
+	}
+	grade := "A"
+	if score < 80 {
+		grade = "C"
+	}
+
+	return &PopeyeRun{
+		Time:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(mins) * time.Minute),
+		Context: "fred",
+		Report: render.Report{
+			Score: score,
+			Grade: grade,
+			Sections: render.Sections{
+				{Title: "pods", GVR: "v1/pods", Tally: &tally, Outcome: outcome},
+			},
+		},
+	}
+}
+
+func makeIssue(l pconfig.Level, msg string) render.Issue {
+	return render.Issue{GVR: "v1/pods", Level: l, Message: msg}
+}
@@ -80,6 +80,11 @@ func AccessorFor(f Factory, gvr client.GVR) (Accessor, error) {
 	m := Accessors{
 		client.NewGVR("contexts"):               &Context{},
 		client.NewGVR("containers"):             &Container{},
+		client.NewGVR("revisions"):              &Revision{},
+		client.NewGVR("images"):                 &Image{},
+		client.NewGVR("imageconsumers"):         &ImageConsumer{},
+		client.NewGVR("rightsizing"):            &Rightsizing{},
+		client.NewGVR("capacity"):               &Capacity{},
 		client.NewGVR("screendumps"):            &ScreenDump{},
 		client.NewGVR("benchmarks"):             &Benchmark{},
 		client.NewGVR("portforwards"):           &PortForward{},
@@ -95,10 +100,14 @@ func AccessorFor(f Factory, gvr client.GVR) (Accessor, error) {
 		client.NewGVR("v1/namespaces"):          &Namespace{},
 		// BOZO!! Revamp with latest...
 		// client.NewGVR("openfaas"):               &OpenFaas{},
-		client.NewGVR("popeye"):    &Popeye{},
-		client.NewGVR("sanitizer"): &Popeye{},
-		client.NewGVR("helm"):      &Helm{},
-		client.NewGVR("dir"):       &Dir{},
+		client.NewGVR("popeye"):       &Popeye{},
+		client.NewGVR("sanitizer"):    &Popeye{},
+		client.NewGVR("helm"):         &Helm{},
+		client.NewGVR("helm-history"): &HelmHistory{},
+		client.NewGVR("dir"):          &Dir{},
+		client.NewGVR("journal"):      &Journal{},
+		client.NewGVR("certs"):        &Cert{},
+		client.NewGVR("consumers"):    &Consumer{},
 	}
 
 	r, ok := m[gvr]
@@ -268,6 +277,28 @@ func loadK9s(m ResourceMetas) {
 		Verbs:        []string{"delete"},
 		Categories:   []string{"k9s"},
 	}
+	m[client.NewGVR("journal")] = metav1.APIResource{
+		Name:         "journal",
+		Kind:         "Journal",
+		SingularName: "journal",
+		ShortNames:   []string{"jo"},
+		Verbs:        []string{"delete"},
+		Categories:   []string{"k9s"},
+	}
+	m[client.NewGVR("consumers")] = metav1.APIResource{
+		Name:         "consumers",
+		Kind:         "Consumers",
+		SingularName: "consumer",
+		Verbs:        []string{},
+		Categories:   []string{"k9s"},
+	}
+	m[client.NewGVR("certs")] = metav1.APIResource{
+		Name:         "certs",
+		Kind:         "Certs",
+		SingularName: "cert",
+		Verbs:        []string{},
+		Categories:   []string{"k9s"},
+	}
 	m[client.NewGVR("benchmarks")] = metav1.APIResource{
 		Name:         "benchmarks",
 		Kind:         "Benchmarks",
@@ -292,6 +323,47 @@ func loadK9s(m ResourceMetas) {
 		Verbs:        []string{},
 		Categories:   []string{"k9s"},
 	}
+	m[client.NewGVR("revisions")] = metav1.APIResource{
+		Name:         "revisions",
+		Kind:         "Revisions",
+		SingularName: "revision",
+		Verbs:        []string{},
+		Categories:   []string{"k9s"},
+	}
+	m[client.NewGVR("images")] = metav1.APIResource{
+		Name:         "images",
+		Namespaced:   true,
+		Kind:         "Images",
+		SingularName: "image",
+		ShortNames:   []string{"img"},
+		Verbs:        []string{},
+		Categories:   []string{"k9s"},
+	}
+	m[client.NewGVR("rightsizing")] = metav1.APIResource{
+		Name:         "rightsizing",
+		Namespaced:   true,
+		Kind:         "Rightsizing",
+		SingularName: "rightsizing",
+		ShortNames:   []string{"rsz"},
+		Verbs:        []string{},
+		Categories:   []string{"k9s"},
+	}
+	m[client.NewGVR("capacity")] = metav1.APIResource{
+		Name:         "capacity",
+		Kind:         "Capacity",
+		SingularName: "capacity",
+		ShortNames:   []string{"cap"},
+		Verbs:        []string{},
+		Categories:   []string{"k9s"},
+	}
+	m[client.NewGVR("imageconsumers")] = metav1.APIResource{
+		Name:         "imageconsumers",
+		Namespaced:   true,
+		Kind:         "ImageConsumers",
+		SingularName: "imageconsumer",
+		Verbs:        []string{},
+		Categories:   []string{"k9s"},
+	}
 }
 
 func loadHelm(m ResourceMetas) {
@@ -302,6 +374,14 @@ func loadHelm(m ResourceMetas) {
 		Verbs:      []string{"delete"},
 		Categories: []string{"helm"},
 	}
+	m[client.NewGVR("helm-history")] = metav1.APIResource{
+		Name:         "helm-history",
+		Kind:         "HelmHistory",
+		SingularName: "helm-history",
+		Namespaced:   true,
+		Verbs:        []string{},
+		Categories:   []string{"helm"},
+	}
 }
 
 // BOZO!! revamp with latest...
@@ -0,0 +1,268 @@
+package dao
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/pmezard/go-difflib/difflib"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/polymorphichelpers"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// RevisionAnnotation tracks a deployment replicaset revision.
+	RevisionAnnotation = "deployment.kubernetes.io/revision"
+
+	// ChangeCauseAnnotation tracks a rollout change cause.
+	ChangeCauseAnnotation = "kubernetes.io/change-cause"
+
+	rsGVR  = "apps/v1/replicasets"
+	revGVR = "apps/v1/controllerrevisions"
+)
+
+var _ Accessor = (*Revision)(nil)
+
+// Revision represents a workload rollout history.
+type Revision struct {
+	NonResource
+}
+
+// List returns the rollout revisions of the workload in context.
+func (r *Revision) List(ctx context.Context, _ string) ([]runtime.Object, error) {
+	gvr, ok := ctx.Value(internal.KeyGVR).(string)
+	if !ok {
+		return nil, errors.New("no context GVR found")
+	}
+	fqn, ok := ctx.Value(internal.KeyPath).(string)
+	if !ok {
+		return nil, fmt.Errorf("no context path for %q", r.gvr)
+	}
+
+	rr, err := r.History(gvr, fqn)
+	if err != nil {
+		return nil, err
+	}
+	oo := make([]runtime.Object, 0, len(rr))
+	for _, rev := range rr {
+		oo = append(oo, rev)
+	}
+
+	return oo, nil
+}
+
+// History returns a workload revisions sorted by revision number.
+func (r *Revision) History(gvr, fqn string) ([]render.RevisionRes, error) {
+	o, err := r.GetFactory().Get(gvr, fqn, true, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	owner, ok := o.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("expecting unstructured resource but got %T", o)
+	}
+
+	var rr []render.RevisionRes
+	switch gvr {
+	case "apps/v1/deployments":
+		rr, err = r.replicaSetHistory(owner)
+	case "apps/v1/statefulsets", "apps/v1/daemonsets":
+		rr, err = r.controllerHistory(owner)
+	default:
+		return nil, fmt.Errorf("no rollout history for %q", gvr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(rr, func(i, j int) bool {
+		return rr[i].Revision < rr[j].Revision
+	})
+	if len(rr) > 0 {
+		rr[len(rr)-1].Current = true
+	}
+
+	return rr, nil
+}
+
+// Revision returns a given workload revision.
+func (r *Revision) Revision(gvr, fqn string, rev int64) (*render.RevisionRes, error) {
+	rr, err := r.History(gvr, fqn)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rr {
+		if rr[i].Revision == rev {
+			return &rr[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("no revision %d found for %s", rev, fqn)
+}
+
+// Diff returns a unified diff of the pod templates of two workload revisions.
+func (r *Revision) Diff(gvr, fqn string, from, to int64) (string, error) {
+	rr, err := r.History(gvr, fqn)
+	if err != nil {
+		return "", err
+	}
+	var f, t *render.RevisionRes
+	for i := range rr {
+		if rr[i].Revision == from {
+			f = &rr[i]
+		}
+		if rr[i].Revision == to {
+			t = &rr[i]
+		}
+	}
+	if f == nil || t == nil {
+		return "", fmt.Errorf("unable to locate revisions %d and %d for %s", from, to, fqn)
+	}
+
+	return DiffTemplates(f, t)
+}
+
+// Rollback rolls a workload back to a given revision.
+func (r *Revision) Rollback(ctx context.Context, gvr, fqn string, rev int64) error {
+	ns, _ := client.Namespaced(fqn)
+	auth, err := r.Client().CanI(ns, gvr, []string{client.PatchVerb})
+	if err != nil {
+		return err
+	}
+	if !auth {
+		return fmt.Errorf("user is not authorized to rollback %s", fqn)
+	}
+
+	o, err := r.GetFactory().Get(gvr, fqn, true, labels.Everything())
+	if err != nil {
+		return err
+	}
+	dial, err := r.Client().Dial()
+	if err != nil {
+		return err
+	}
+	rb, err := polymorphichelpers.RollbackerFor(schema.GroupKind{
+		Group: "apps",
+		Kind:  o.GetObjectKind().GroupVersionKind().Kind,
+	}, dial)
+	if err != nil {
+		return err
+	}
+	_, err = rb.Rollback(o, map[string]string{}, rev, cmdutil.DryRunNone)
+
+	return err
+}
+
+// DiffTemplates returns a unified diff of two revisions pod templates.
+func DiffTemplates(from, to *render.RevisionRes) (string, error) {
+	f, err := yaml.Marshal(from.Template)
+	if err != nil {
+		return "", err
+	}
+	t, err := yaml.Marshal(to.Template)
+	if err != nil {
+		return "", err
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(strings.TrimSuffix(string(f), "\n")),
+		B:        difflib.SplitLines(strings.TrimSuffix(string(t), "\n")),
+		FromFile: "revision " + strconv.FormatInt(from.Revision, 10),
+		ToFile:   "revision " + strconv.FormatInt(to.Revision, 10),
+		Context:  3,
+	})
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func (r *Revision) replicaSetHistory(owner *unstructured.Unstructured) ([]render.RevisionRes, error) {
+	oo, err := r.GetFactory().List(rsGVR, owner.GetNamespace(), false, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	rr := make([]render.RevisionRes, 0, len(oo))
+	for _, o := range oo {
+		var rs appsv1.ReplicaSet
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(o.(*unstructured.Unstructured).Object, &rs); err != nil {
+			return nil, err
+		}
+		if !metav1.IsControlledBy(&rs, owner) {
+			continue
+		}
+		rev, err := strconv.ParseInt(rs.Annotations[RevisionAnnotation], 10, 64)
+		if err != nil {
+			continue
+		}
+		tpl := rs.Spec.Template.DeepCopy()
+		delete(tpl.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+		rr = append(rr, render.RevisionRes{
+			Revision:    rev,
+			Name:        rs.Name,
+			ChangeCause: rs.Annotations[ChangeCauseAnnotation],
+			Ready:       fmt.Sprintf("%d/%d", rs.Status.ReadyReplicas, rs.Status.Replicas),
+			Template:    tpl,
+			Timestamp:   rs.CreationTimestamp,
+		})
+	}
+
+	return rr, nil
+}
+
+func (r *Revision) controllerHistory(owner *unstructured.Unstructured) ([]render.RevisionRes, error) {
+	oo, err := r.GetFactory().List(revGVR, owner.GetNamespace(), false, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	rr := make([]render.RevisionRes, 0, len(oo))
+	for _, o := range oo {
+		var cr appsv1.ControllerRevision
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(o.(*unstructured.Unstructured).Object, &cr); err != nil {
+			return nil, err
+		}
+		if !metav1.IsControlledBy(&cr, owner) {
+			continue
+		}
+		tpl, err := revisionTemplate(&cr)
+		if err != nil {
+			return nil, err
+		}
+		rr = append(rr, render.RevisionRes{
+			Revision:    cr.Revision,
+			Name:        cr.Name,
+			ChangeCause: cr.Annotations[ChangeCauseAnnotation],
+			Ready:       render.NAValue,
+			Template:    tpl,
+			Timestamp:   cr.CreationTimestamp,
+		})
+	}
+
+	return rr, nil
+}
+
+func revisionTemplate(cr *appsv1.ControllerRevision) (*v1.PodTemplateSpec, error) {
+	var patch struct {
+		Spec struct {
+			Template v1.PodTemplateSpec `json:"template"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(cr.Data.Raw, &patch); err != nil {
+		return nil, fmt.Errorf("unable to decode controller revision %s: %w", cr.Name, err)
+	}
+
+	return &patch.Spec.Template, nil
+}
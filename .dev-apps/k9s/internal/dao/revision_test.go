@@ -0,0 +1,80 @@
+package dao
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/render"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestRevisionTemplate(t *testing.T) {
+	cr := appsv1.ControllerRevision{
+		ObjectMeta: metav1.ObjectMeta{Name: "fred-7b9c4"},
+		Data: runtime.RawExtension{
+			Raw: []byte(`{"spec":{"template":{"$patch":"replace","metadata":{"labels":{"app":"fred"}},"spec":{"containers":[{"name":"fred","image":"fred:1"}]}}}}`),
+		},
+		Revision: 2,
+	}
+
+	tpl, err := revisionTemplate(&cr)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"app": "fred"}, tpl.Labels)
+	assert.Equal(t, "fred:1", tpl.Spec.Containers[0].Image)
+
+	cr.Data.Raw = []byte("{")
+	_, err = revisionTemplate(&cr)
+	assert.NotNil(t, err)
+}
+
+func TestDiffTemplates(t *testing.T) {
+	uu := map[string]struct {
+		from, to string
+		e        string
+	}{
+		"same": {
+			from: "fred:1",
+			to:   "fred:1",
+		},
+		"image": {
+			from: "fred:1",
+			to:   "fred:2",
+			e: `--- revision 1
++++ revision 2
+@@ -2,6 +2,6 @@
+   creationTimestamp: null
+ spec:
+   containers:
+-  - image: fred:1
++  - image: fred:2
+     name: fred
+     resources: {}
+`,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			diff, err := DiffTemplates(makeRevision(1, u.from), makeRevision(2, u.to))
+			assert.Nil(t, err)
+			assert.Equal(t, u.e, diff)
+		})
+	}
+}
+
+// Helpers...
+
+func makeRevision(rev int64, img string) *render.RevisionRes {
+	return &render.RevisionRes{
+		Revision: rev,
+		Template: &v1.PodTemplateSpec{
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{{Name: "fred", Image: img}},
+			},
+		},
+	}
+}
@@ -0,0 +1,429 @@
+package dao
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/render"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	mv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+const (
+	minCPUSuggest = 5
+	minMemSuggest = 16 * 1024 * 1024
+	mib           = 1024 * 1024
+	riskRatio     = 0.9
+	overRatio     = 2
+)
+
+var (
+	_ Accessor = (*Rightsizing)(nil)
+
+	usageSampler = NewUsageSampler(time.Hour, RightsizeOpts{MinSamples: 5, Headroom: 20})
+)
+
+// UsageSample represents a container usage sample.
+type UsageSample struct {
+	Time time.Time
+	// CPU usage in millicores.
+	CPU int64
+	// Mem usage in bytes.
+	Mem int64
+}
+
+// UsageSeries represents a workload container usage over time.
+type UsageSeries struct {
+	GVR, Namespace, Name, Container string
+	Resources                       *v1.ResourceRequirements
+	Samples                         []UsageSample
+
+	last map[string]time.Time
+}
+
+// ID returns the series identifier.
+func (s *UsageSeries) ID() string {
+	return s.GVR + ":" + client.FQN(s.Namespace, s.Name) + ":" + s.Container
+}
+
+// UsageSampler tracks containers usage sampled from the metrics server during a session.
+type UsageSampler struct {
+	window time.Duration
+	opts   RightsizeOpts
+	series map[string]*UsageSeries
+	mx     sync.RWMutex
+}
+
+// NewUsageSampler returns a new sampler.
+func NewUsageSampler(window time.Duration, opts RightsizeOpts) *UsageSampler {
+	return &UsageSampler{
+		window: window,
+		opts:   opts,
+		series: make(map[string]*UsageSeries),
+	}
+}
+
+// UsageSamples returns the session usage sampler.
+func UsageSamples() *UsageSampler {
+	return usageSampler
+}
+
+// Configure sets the sampling retention window and recommendations options.
+func (s *UsageSampler) Configure(window time.Duration, opts RightsizeOpts) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.window, s.opts = window, opts
+}
+
+// Reset clears all recorded usage series, ie when switching contexts.
+func (s *UsageSampler) Reset() {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.series = make(map[string]*UsageSeries)
+}
+
+// Options returns the recommendations options.
+func (s *UsageSampler) Options() RightsizeOpts {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	return s.opts
+}
+
+// Record records a pod metrics sample for the workload owning the pod.
+func (s *UsageSampler) Record(po metav1.Object, mx *mv1beta1.PodMetrics) {
+	if mx == nil {
+		return
+	}
+	gvr, n := podWorkload(po)
+	pod := client.FQN(po.GetNamespace(), po.GetName())
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	for _, c := range mx.Containers {
+		se := s.seriesFor(gvr, po.GetNamespace(), n, c.Name)
+		if t, ok := se.last[pod]; ok && !mx.Timestamp.Time.After(t) {
+			continue
+		}
+		se.last[pod] = mx.Timestamp.Time
+		se.Samples = append(se.Samples, UsageSample{
+			Time: mx.Timestamp.Time,
+			CPU:  c.Usage.Cpu().MilliValue(),
+			Mem:  c.Usage.Memory().Value(),
+		})
+	}
+}
+
+// SetResources records a workload container current requests and limits.
+func (s *UsageSampler) SetResources(gvr, ns, n string, co *v1.Container) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.seriesFor(gvr, ns, n, co.Name).Resources = co.Resources.DeepCopy()
+}
+
+// Series returns the usage series for a given namespace pruned to the sampling window.
+func (s *UsageSampler) Series(ns string) []UsageSeries {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	cutoff := time.Now().Add(-s.window)
+	ss := make([]UsageSeries, 0, len(s.series))
+	for _, se := range s.series {
+		kept := se.Samples[:0]
+		for _, sa := range se.Samples {
+			if !sa.Time.Before(cutoff) {
+				kept = append(kept, sa)
+			}
+		}
+		se.Samples = kept
+		for pod, t := range se.last {
+			if t.Before(cutoff) {
+				delete(se.last, pod)
+			}
+		}
+		if !client.IsAllNamespaces(ns) && se.Namespace != ns {
+			continue
+		}
+		c := *se
+		c.Samples = append([]UsageSample(nil), se.Samples...)
+		ss = append(ss, c)
+	}
+
+	return ss
+}
+
+func (s *UsageSampler) seriesFor(gvr, ns, n, co string) *UsageSeries {
+	key := gvr + ":" + client.FQN(ns, n) + ":" + co
+	se, ok := s.series[key]
+	if !ok {
+		se = &UsageSeries{
+			GVR:       gvr,
+			Namespace: ns,
+			Name:      n,
+			Container: co,
+			last:      make(map[string]time.Time),
+		}
+		s.series[key] = se
+	}
+
+	return se
+}
+
+// RightsizeOpts represents recommendations options.
+type RightsizeOpts struct {
+	MinSamples int
+	// Headroom represents a percentage added on top of observed usage.
+	Headroom int
+}
+
+// Rightsize computes a container requests/limits recommendation given its usage.
+func Rightsize(se *UsageSeries, opts RightsizeOpts) render.RightsizingRes {
+	res := render.RightsizingRes{
+		GVR:       se.GVR,
+		Namespace: se.Namespace,
+		Name:      se.Name,
+		Container: se.Container,
+		Samples:   len(se.Samples),
+	}
+	cc, mm := make([]int64, 0, len(se.Samples)), make([]int64, 0, len(se.Samples))
+	for _, s := range se.Samples {
+		cc, mm = append(cc, s.CPU), append(mm, s.Mem)
+	}
+	res.CPU.P95, res.CPU.Max = percentile(cc, 95), percentile(cc, 100)
+	res.Mem.P95, res.Mem.Max = percentile(mm, 95), percentile(mm, 100)
+	if rr := se.Resources; rr != nil {
+		res.CPU.Request, res.CPU.Limit = rr.Requests.Cpu().MilliValue(), rr.Limits.Cpu().MilliValue()
+		res.Mem.Request, res.Mem.Limit = rr.Requests.Memory().Value(), rr.Limits.Memory().Value()
+	}
+
+	h := 1 + float64(opts.Headroom)/100
+	res.CPU.SuggestRequest = maxInt64(withHeadroom(res.CPU.P95, h), minCPUSuggest)
+	if res.CPU.Limit > 0 {
+		res.CPU.SuggestLimit = maxInt64(withHeadroom(res.CPU.Max, h), res.CPU.SuggestRequest)
+	}
+	res.Mem.SuggestRequest = roundMi(maxInt64(withHeadroom(res.Mem.P95, h), minMemSuggest))
+	if res.Mem.Limit > 0 {
+		res.Mem.SuggestLimit = roundMi(maxInt64(withHeadroom(res.Mem.Max, h), res.Mem.SuggestRequest))
+	}
+
+	res.Status, res.Reason = rightsizeStatus(&res, opts)
+
+	return res
+}
+
+// Rightsizing represents containers requests/limits recommendations.
+type Rightsizing struct {
+	NonResource
+}
+
+// List samples the current pods usage and returns recommendations for a given namespace.
+func (r *Rightsizing) List(ctx context.Context, ns string) ([]runtime.Object, error) {
+	if err := r.sample(ctx, ns); err != nil {
+		return nil, err
+	}
+
+	ss := UsageSamples().Series(ns)
+	oo := make([]runtime.Object, 0, len(ss))
+	for i := range ss {
+		if ss[i].Resources == nil {
+			continue
+		}
+		oo = append(oo, Rightsize(&ss[i], UsageSamples().Options()))
+	}
+
+	return oo, nil
+}
+
+// Recommendation returns the current recommendation for a given workload container.
+func (r *Rightsizing) Recommendation(id string) (*render.RightsizingRes, error) {
+	tokens := strings.Split(id, ":")
+	if len(tokens) != 3 {
+		return nil, fmt.Errorf("invalid recommendation id %q", id)
+	}
+	ns, _ := client.Namespaced(tokens[1])
+	for _, se := range UsageSamples().Series(ns) {
+		if se.ID() == id {
+			res := Rightsize(&se, UsageSamples().Options())
+			return &res, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no usage samples found for %q", id)
+}
+
+// Apply patches the owning workload with the recommended requests and limits.
+func (r *Rightsizing) Apply(ctx context.Context, res *render.RightsizingRes) error {
+	switch res.GVR {
+	case "apps/v1/deployments", "apps/v1/statefulsets", "apps/v1/daemonsets":
+	default:
+		return fmt.Errorf("unable to patch resources for %s %s", res.GVR, res.Name)
+	}
+	auth, err := r.Client().CanI(res.Namespace, res.GVR, []string{client.PatchVerb})
+	if err != nil {
+		return err
+	}
+	if !auth {
+		return fmt.Errorf("user is not authorized to patch %s", client.FQN(res.Namespace, res.Name))
+	}
+
+	patch, err := RightsizePatch(res)
+	if err != nil {
+		return err
+	}
+	dial, err := r.Client().DynDial()
+	if err != nil {
+		return err
+	}
+	_, err = dial.Resource(client.NewGVR(res.GVR).GVR()).Namespace(res.Namespace).Patch(
+		ctx,
+		res.Name,
+		types.StrategicMergePatchType,
+		patch,
+		metav1.PatchOptions{},
+	)
+
+	return err
+}
+
+// RightsizePatch returns a pod template patch setting a container recommended resources.
+func RightsizePatch(res *render.RightsizingRes) ([]byte, error) {
+	rr := v1.ResourceRequirements{
+		Requests: v1.ResourceList{
+			v1.ResourceCPU:    *resource.NewMilliQuantity(res.CPU.SuggestRequest, resource.DecimalSI),
+			v1.ResourceMemory: *resource.NewQuantity(res.Mem.SuggestRequest, resource.BinarySI),
+		},
+		Limits: v1.ResourceList{},
+	}
+	if res.CPU.SuggestLimit > 0 {
+		rr.Limits[v1.ResourceCPU] = *resource.NewMilliQuantity(res.CPU.SuggestLimit, resource.DecimalSI)
+	}
+	if res.Mem.Limit > 0 && res.Mem.SuggestLimit > 0 {
+		rr.Limits[v1.ResourceMemory] = *resource.NewQuantity(res.Mem.SuggestLimit, resource.BinarySI)
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []map[string]interface{}{
+						{"name": res.Container, "resources": rr},
+					},
+				},
+			},
+		},
+	})
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func (r *Rightsizing) sample(ctx context.Context, ns string) error {
+	mx, err := client.DialMetrics(r.Client()).FetchPodsMetricsMap(ctx, ns)
+	if err != nil {
+		return err
+	}
+	oo, err := r.GetFactory().List("v1/pods", ns, false, labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, o := range oo {
+		u, ok := o.(*unstructured.Unstructured)
+		if !ok {
+			return fmt.Errorf("expecting unstructured resource but got %T", o)
+		}
+		var po v1.Pod
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &po); err != nil {
+			return err
+		}
+		UsageSamples().Record(&po, mx[client.FQN(po.Namespace, po.Name)])
+		gvr, n := podWorkload(&po)
+		for i := range po.Spec.Containers {
+			UsageSamples().SetResources(gvr, po.Namespace, n, &po.Spec.Containers[i])
+		}
+	}
+
+	return nil
+}
+
+func rightsizeStatus(res *render.RightsizingRes, opts RightsizeOpts) (string, string) {
+	if res.Samples < opts.MinSamples {
+		return render.RightsizeSampling, fmt.Sprintf("%d/%d samples", res.Samples, opts.MinSamples)
+	}
+	if res.CPU.Request == 0 || res.Mem.Request == 0 {
+		return render.RightsizeUnset, "no cpu or memory requests"
+	}
+
+	var rr []string
+	if res.Mem.Limit > 0 && float64(res.Mem.Max) >= riskRatio*float64(res.Mem.Limit) {
+		rr = append(rr, "memory peak near limit")
+	}
+	if res.CPU.Limit > 0 && float64(res.CPU.Max) >= riskRatio*float64(res.CPU.Limit) {
+		rr = append(rr, "cpu peak near limit")
+	}
+	if res.Mem.P95 > res.Mem.Request {
+		rr = append(rr, "memory usage above request")
+	}
+	if res.CPU.P95 > res.CPU.Request {
+		rr = append(rr, "cpu usage above request")
+	}
+	if len(rr) > 0 {
+		return render.RightsizeRisk, strings.Join(rr, ",")
+	}
+
+	if res.CPU.Request > overRatio*res.CPU.SuggestRequest {
+		rr = append(rr, "cpu request over usage")
+	}
+	if res.Mem.Request > overRatio*res.Mem.SuggestRequest {
+		rr = append(rr, "memory request over usage")
+	}
+	if len(rr) > 0 {
+		return render.RightsizeOver, strings.Join(rr, ",")
+	}
+
+	return render.RightsizeOK, ""
+}
+
+func percentile(vv []int64, p int) int64 {
+	if len(vv) == 0 {
+		return 0
+	}
+	ss := append([]int64(nil), vv...)
+	sort.Slice(ss, func(i, j int) bool { return ss[i] < ss[j] })
+	i := int(math.Ceil(float64(p)/100*float64(len(ss)))) - 1
+	if i < 0 {
+		i = 0
+	}
+
+	return ss[i]
+}
+
+func withHeadroom(v int64, h float64) int64 {
+	return int64(math.Ceil(float64(v) * h))
+}
+
+func roundMi(v int64) int64 {
+	return int64(math.Ceil(float64(v)/mib)) * mib
+}
+
+func maxInt64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,189 @@
+package dao
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/render"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	mv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+func TestUsageSamplerRecord(t *testing.T) {
+	s := NewUsageSampler(time.Hour, RightsizeOpts{})
+	po := makeImagePod("fred-abc-1", "fred:1", "")
+	now := time.Now()
+
+	s.Record(po, makePodMX(now, "100m", "10Mi"))
+	s.Record(po, makePodMX(now, "100m", "10Mi"))
+	s.Record(po, makePodMX(now.Add(time.Minute), "200m", "20Mi"))
+	s.Record(po, makePodMX(now.Add(-2*time.Hour), "300m", "30Mi"))
+	s.Record(po, nil)
+	s.SetResources("apps/v1/deployments", "blee", "fred", &po.Spec.Containers[0])
+
+	ss := s.Series("blee")
+	assert.Equal(t, 1, len(ss))
+	assert.Equal(t, "apps/v1/deployments:blee/fred:fred", ss[0].ID())
+	assert.Equal(t, 2, len(ss[0].Samples))
+	assert.Equal(t, int64(200), ss[0].Samples[1].CPU)
+	assert.NotNil(t, ss[0].Resources)
+	assert.Equal(t, 0, len(s.Series("zorg")))
+	assert.Equal(t, 1, len(s.Series("")))
+}
+
+func TestUsageSamplerReset(t *testing.T) {
+	s := NewUsageSampler(time.Hour, RightsizeOpts{MinSamples: 3})
+	po := makeImagePod("fred-abc-1", "fred:1", "")
+	s.Record(po, makePodMX(time.Now(), "100m", "10Mi"))
+	assert.Equal(t, 1, len(s.Series("blee")))
+
+	s.Reset()
+	assert.Equal(t, 0, len(s.Series("")))
+	assert.Equal(t, 3, s.Options().MinSamples)
+
+	s.Record(po, makePodMX(time.Now(), "200m", "20Mi"))
+	ss := s.Series("blee")
+	assert.Equal(t, 1, len(ss))
+	assert.Equal(t, 1, len(ss[0].Samples))
+	assert.Equal(t, int64(200), ss[0].Samples[0].CPU)
+}
+
+func TestRightsize(t *testing.T) {
+	uu := map[string]struct {
+		cpu, mem     []int64
+		rr           v1.ResourceRequirements
+		status       string
+		sugCPU       int64
+		sugCPULim    int64
+		sugMem       int64
+		sugMemLim    int64
+		reasonPrefix string
+	}{
+		"sampling": {
+			cpu:    []int64{10},
+			mem:    []int64{10 * mib},
+			rr:     makeRR("100m", "", "64Mi", ""),
+			status: render.RightsizeSampling,
+			sugCPU: 12, sugMem: 16 * mib,
+		},
+		"unset": {
+			cpu:    []int64{10, 10, 10},
+			mem:    []int64{mib, mib, mib},
+			rr:     makeRR("", "", "64Mi", ""),
+			status: render.RightsizeUnset,
+			sugCPU: 12, sugMem: 16 * mib,
+		},
+		"over": {
+			cpu:    []int64{10, 20, 30},
+			mem:    []int64{40 * mib, 50 * mib, 60 * mib},
+			rr:     makeRR("1", "2", "1Gi", "2Gi"),
+			status: render.RightsizeOver,
+			sugCPU: 36, sugCPULim: 36, sugMem: 72 * mib, sugMemLim: 72 * mib,
+		},
+		"risk": {
+			cpu:    []int64{100, 100, 100},
+			mem:    []int64{100 * mib, 100 * mib, 120 * mib},
+			rr:     makeRR("100m", "", "128Mi", "128Mi"),
+			status: render.RightsizeRisk,
+			sugCPU: 120, sugMem: 144 * mib, sugMemLim: 144 * mib,
+		},
+		"ok": {
+			cpu:    []int64{80, 90, 90},
+			mem:    []int64{90 * mib, 100 * mib, 100 * mib},
+			rr:     makeRR("100m", "", "128Mi", "256Mi"),
+			status: render.RightsizeOK,
+			sugCPU: 108, sugMem: 120 * mib, sugMemLim: 120 * mib,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			se := UsageSeries{GVR: "apps/v1/deployments", Namespace: "blee", Name: "fred", Container: "c1", Resources: &u.rr}
+			for i := range u.cpu {
+				se.Samples = append(se.Samples, UsageSample{CPU: u.cpu[i], Mem: u.mem[i]})
+			}
+			res := Rightsize(&se, RightsizeOpts{MinSamples: 3, Headroom: 20})
+			assert.Equal(t, u.status, res.Status)
+			assert.Equal(t, u.sugCPU, res.CPU.SuggestRequest)
+			assert.Equal(t, u.sugCPULim, res.CPU.SuggestLimit)
+			assert.Equal(t, u.sugMem, res.Mem.SuggestRequest)
+			assert.Equal(t, u.sugMemLim, res.Mem.SuggestLimit)
+		})
+	}
+}
+
+func TestRightsizePatch(t *testing.T) {
+	uu := map[string]struct {
+		cpuLim, memLim int64
+		e              string
+	}{
+		"no-limits": {
+			e: `{"spec":{"template":{"spec":{"containers":[{"name":"c1","resources":{"requests":{"cpu":"120m","memory":"64Mi"}}}]}}}}`,
+		},
+		"mem-limit": {
+			memLim: 256 * mib,
+			e:      `{"spec":{"template":{"spec":{"containers":[{"name":"c1","resources":{"limits":{"memory":"128Mi"},"requests":{"cpu":"120m","memory":"64Mi"}}}]}}}}`,
+		},
+		"limits": {
+			cpuLim: 500,
+			memLim: 256 * mib,
+			e:      `{"spec":{"template":{"spec":{"containers":[{"name":"c1","resources":{"limits":{"cpu":"150m","memory":"128Mi"},"requests":{"cpu":"120m","memory":"64Mi"}}}]}}}}`,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			res := render.RightsizingRes{Container: "c1"}
+			res.CPU.SuggestRequest, res.Mem.SuggestRequest = 120, 64*mib
+			res.CPU.Limit, res.Mem.Limit = u.cpuLim, u.memLim
+			if u.cpuLim > 0 {
+				res.CPU.SuggestLimit = 150
+			}
+			if u.memLim > 0 {
+				res.Mem.SuggestLimit = 128 * mib
+			}
+
+			raw, err := RightsizePatch(&res)
+			assert.Nil(t, err)
+			assert.Equal(t, u.e, string(raw))
+		})
+	}
+}
+
+// Helpers...
+
+func makePodMX(ts time.Time, cpu, mem string) *mv1beta1.PodMetrics {
+	return &mv1beta1.PodMetrics{
+		Timestamp: metav1.NewTime(ts),
+		Containers: []mv1beta1.ContainerMetrics{
+			{
+				Name: "fred",
+				Usage: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse(cpu),
+					v1.ResourceMemory: resource.MustParse(mem),
+				},
+			},
+		},
+	}
+}
+
+func makeRR(cpuReq, cpuLim, memReq, memLim string) v1.ResourceRequirements {
+	rr := v1.ResourceRequirements{Requests: v1.ResourceList{}, Limits: v1.ResourceList{}}
+	for k, v := range map[v1.ResourceName]string{v1.ResourceCPU: cpuReq, v1.ResourceMemory: memReq} {
+		if v != "" {
+			rr.Requests[k] = resource.MustParse(v)
+		}
+	}
+	for k, v := range map[v1.ResourceName]string{v1.ResourceCPU: cpuLim, v1.ResourceMemory: memLim} {
+		if v != "" {
+			rr.Limits[k] = resource.MustParse(v)
+		}
+	}
+
+	return rr
+}
@@ -0,0 +1,309 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/kubectl/pkg/polymorphichelpers"
+	"k8s.io/kubectl/pkg/scheme"
+)
+
+const (
+	// DeadlineExceededReason tracks a rollout that failed to progress in time.
+	DeadlineExceededReason = "ProgressDeadlineExceeded"
+
+	maxRolloutEvents = 20
+)
+
+// RolloutPod represents a pod participating in a rollout.
+type RolloutPod struct {
+	Name, ReplicaSet, Phase string
+	Ready, New              bool
+}
+
+// RolloutEvent represents an event emitted during a rollout.
+type RolloutEvent struct {
+	Type, Reason, Object, Message string
+	Count                         int32
+	Timestamp                     time.Time
+}
+
+// RolloutStatus represents a deployment rollout progress.
+type RolloutStatus struct {
+	Revision                           string
+	Desired, Updated, Ready, Available int32
+	Replicas, Unavailable              int32
+	Paused, Complete, DeadlineExceeded bool
+	ProgressDeadline                   time.Duration
+	LastProgress                       time.Time
+	Reason, Message, NewRS             string
+	OldRS                              []string
+	Pods                               []RolloutPod
+	Events                             []RolloutEvent
+}
+
+// NewRolloutStatus computes a rollout status given a deployment and its dependents.
+func NewRolloutStatus(dp *appsv1.Deployment, rss []appsv1.ReplicaSet, pods []v1.Pod, ee []v1.Event) *RolloutStatus {
+	s := RolloutStatus{
+		Revision:    dp.Annotations[RevisionAnnotation],
+		Desired:     1,
+		Updated:     dp.Status.UpdatedReplicas,
+		Ready:       dp.Status.ReadyReplicas,
+		Available:   dp.Status.AvailableReplicas,
+		Replicas:    dp.Status.Replicas,
+		Unavailable: dp.Status.UnavailableReplicas,
+		Paused:      dp.Spec.Paused,
+	}
+	if dp.Spec.Replicas != nil {
+		s.Desired = *dp.Spec.Replicas
+	}
+	if dp.Spec.ProgressDeadlineSeconds != nil {
+		s.ProgressDeadline = time.Duration(*dp.Spec.ProgressDeadlineSeconds) * time.Second
+	}
+	for _, c := range dp.Status.Conditions {
+		if c.Type != appsv1.DeploymentProgressing {
+			continue
+		}
+		s.Reason, s.Message, s.LastProgress = c.Reason, c.Message, c.LastUpdateTime.Time
+		s.DeadlineExceeded = c.Reason == DeadlineExceededReason
+	}
+	s.Complete = dp.Status.ObservedGeneration >= dp.Generation &&
+		s.Updated == s.Desired && s.Replicas == s.Desired && s.Available == s.Desired
+
+	owned := make(map[types.UID]string, len(rss))
+	var since time.Time
+	for i := range rss {
+		rs := rss[i]
+		if !metav1.IsControlledBy(&rs, dp) {
+			continue
+		}
+		owned[rs.UID] = rs.Name
+		if rs.Annotations[RevisionAnnotation] == s.Revision {
+			s.NewRS, since = rs.Name, rs.CreationTimestamp.Time
+			continue
+		}
+		if rs.Status.Replicas > 0 {
+			s.OldRS = append(s.OldRS, rs.Name)
+		}
+	}
+	sort.Strings(s.OldRS)
+
+	for i := range pods {
+		ref := metav1.GetControllerOf(&pods[i])
+		if ref == nil {
+			continue
+		}
+		rs, ok := owned[ref.UID]
+		if !ok {
+			continue
+		}
+		s.Pods = append(s.Pods, RolloutPod{
+			Name:       pods[i].Name,
+			ReplicaSet: rs,
+			Phase:      string(pods[i].Status.Phase),
+			Ready:      isPodReady(&pods[i]),
+			New:        rs == s.NewRS,
+		})
+	}
+	sort.Slice(s.Pods, func(i, j int) bool {
+		if s.Pods[i].New != s.Pods[j].New {
+			return s.Pods[i].New
+		}
+		return s.Pods[i].Name < s.Pods[j].Name
+	})
+
+	s.Events = rolloutEvents(dp, owned, ee, since)
+
+	return &s
+}
+
+// Rollout returns a deployment rollout status.
+func (d *Deployment) Rollout(ctx context.Context, path string) (*RolloutStatus, error) {
+	dp, err := d.Load(d.Factory, path)
+	if err != nil {
+		return nil, err
+	}
+
+	oo, err := d.GetFactory().List(rsGVR, dp.Namespace, false, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	rss := make([]appsv1.ReplicaSet, 0, len(oo))
+	for _, o := range oo {
+		var rs appsv1.ReplicaSet
+		if err := fromUnstructured(o, &rs); err != nil {
+			return nil, err
+		}
+		rss = append(rss, rs)
+	}
+
+	sel, err := metav1.LabelSelectorAsSelector(dp.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	oo, err = d.GetFactory().List("v1/pods", dp.Namespace, false, sel)
+	if err != nil {
+		return nil, err
+	}
+	pods := make([]v1.Pod, 0, len(oo))
+	for _, o := range oo {
+		var po v1.Pod
+		if err := fromUnstructured(o, &po); err != nil {
+			return nil, err
+		}
+		pods = append(pods, po)
+	}
+
+	oo, err = d.GetFactory().List("v1/events", dp.Namespace, false, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	ee := make([]v1.Event, 0, len(oo))
+	for _, o := range oo {
+		var e v1.Event
+		if err := fromUnstructured(o, &e); err != nil {
+			return nil, err
+		}
+		ee = append(ee, e)
+	}
+
+	return NewRolloutStatus(dp, rss, pods, ee), nil
+}
+
+// Pause pauses a deployment rollout.
+func (d *Deployment) Pause(ctx context.Context, path string) error {
+	return d.togglePause(ctx, path, polymorphichelpers.ObjectPauserFn)
+}
+
+// Resume resumes a paused deployment rollout.
+func (d *Deployment) Resume(ctx context.Context, path string) error {
+	return d.togglePause(ctx, path, polymorphichelpers.ObjectResumerFn)
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func (d *Deployment) togglePause(ctx context.Context, path string, fn func(runtime.Object) ([]byte, error)) error {
+	dp, err := d.Load(d.Factory, path)
+	if err != nil {
+		return err
+	}
+	auth, err := d.Client().CanI(dp.Namespace, "apps/v1/deployments", []string{client.PatchVerb})
+	if err != nil {
+		return err
+	}
+	if !auth {
+		return fmt.Errorf("user is not authorized to patch deployment %s", path)
+	}
+
+	before, err := runtime.Encode(scheme.Codecs.LegacyCodec(appsv1.SchemeGroupVersion), dp)
+	if err != nil {
+		return err
+	}
+	after, err := fn(dp)
+	if err != nil {
+		return err
+	}
+	diff, err := strategicpatch.CreateTwoWayMergePatch(before, after, dp)
+	if err != nil {
+		return err
+	}
+	dial, err := d.Client().Dial()
+	if err != nil {
+		return err
+	}
+	_, err = dial.AppsV1().Deployments(dp.Namespace).Patch(
+		ctx,
+		dp.Name,
+		types.StrategicMergePatchType,
+		diff,
+		metav1.PatchOptions{},
+	)
+
+	return err
+}
+
+func rolloutEvents(dp *appsv1.Deployment, rss map[types.UID]string, ee []v1.Event, since time.Time) []RolloutEvent {
+	rr := make([]RolloutEvent, 0, len(ee))
+	for _, e := range ee {
+		o := e.InvolvedObject
+		_, ownedRS := rss[o.UID]
+		switch {
+		case o.UID == dp.UID, ownedRS:
+		case o.Kind == "Pod" && isRolloutPod(o.Name, rss):
+		default:
+			continue
+		}
+		ts := eventTime(&e)
+		if ts.Before(since) {
+			continue
+		}
+		rr = append(rr, RolloutEvent{
+			Type:      e.Type,
+			Reason:    e.Reason,
+			Object:    o.Kind + "/" + o.Name,
+			Message:   e.Message,
+			Count:     e.Count,
+			Timestamp: ts,
+		})
+	}
+	sort.Slice(rr, func(i, j int) bool {
+		return rr[i].Timestamp.Before(rr[j].Timestamp)
+	})
+	if len(rr) > maxRolloutEvents {
+		rr = rr[len(rr)-maxRolloutEvents:]
+	}
+
+	return rr
+}
+
+func isRolloutPod(name string, rss map[types.UID]string) bool {
+	for _, rs := range rss {
+		if len(name) > len(rs) && name[:len(rs)+1] == rs+"-" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func eventTime(e *v1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	default:
+		return e.CreationTimestamp.Time
+	}
+}
+
+func isPodReady(po *v1.Pod) bool {
+	for _, c := range po.Status.Conditions {
+		if c.Type == v1.PodReady {
+			return c.Status == v1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+func fromUnstructured(o runtime.Object, v interface{}) error {
+	u, ok := o.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("expecting unstructured resource but got %T", o)
+	}
+
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, v)
+}
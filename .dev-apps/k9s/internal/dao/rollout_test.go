@@ -0,0 +1,144 @@
+package dao
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestNewRolloutStatus(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	dp := makeRolloutDP(3, "2")
+	rsOld, rsNew := makeRolloutRS(dp, "fred-1", "1", 1, t0), makeRolloutRS(dp, "fred-2", "2", 2, t0.Add(time.Minute))
+	pods := []v1.Pod{
+		makeRolloutPod(rsOld, "fred-1-a", true),
+		makeRolloutPod(rsNew, "fred-2-b", false),
+		makeRolloutPod(rsNew, "fred-2-a", true),
+	}
+	ee := []v1.Event{
+		makeRolloutEvent("Deployment", "fred", dp.UID, "ScalingReplicaSet", t0.Add(2*time.Minute)),
+		makeRolloutEvent("Pod", "fred-2-b", "p1", "BackOff", t0.Add(3*time.Minute)),
+		makeRolloutEvent("Deployment", "fred", dp.UID, "ScalingReplicaSet", t0),
+		makeRolloutEvent("Pod", "zorg-1-a", "p2", "Pulled", t0.Add(3*time.Minute)),
+	}
+
+	st := NewRolloutStatus(dp, []appsv1.ReplicaSet{rsOld, rsNew}, pods, ee)
+
+	assert.Equal(t, "2", st.Revision)
+	assert.Equal(t, int32(3), st.Desired)
+	assert.Equal(t, "fred-2", st.NewRS)
+	assert.Equal(t, []string{"fred-1"}, st.OldRS)
+	assert.False(t, st.Complete)
+	assert.True(t, st.DeadlineExceeded)
+	assert.Equal(t, 10*time.Minute, st.ProgressDeadline)
+	assert.Equal(t, 3, len(st.Pods))
+	assert.Equal(t, RolloutPod{Name: "fred-2-a", ReplicaSet: "fred-2", Phase: "Running", Ready: true, New: true}, st.Pods[0])
+	assert.Equal(t, "fred-2-b", st.Pods[1].Name)
+	assert.False(t, st.Pods[2].New)
+	assert.Equal(t, 2, len(st.Events))
+	assert.Equal(t, "ScalingReplicaSet", st.Events[0].Reason)
+	assert.Equal(t, "Pod/fred-2-b", st.Events[1].Object)
+}
+
+func TestNewRolloutStatusComplete(t *testing.T) {
+	uu := map[string]struct {
+		updated, available, replicas int32
+		generation                   int64
+		e                            bool
+	}{
+		"complete":   {updated: 3, available: 3, replicas: 3, generation: 2, e: true},
+		"surge":      {updated: 3, available: 3, replicas: 4, generation: 2},
+		"unobserved": {updated: 3, available: 3, replicas: 3, generation: 1},
+		"updating":   {updated: 1, available: 3, replicas: 3, generation: 2},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			dp := makeRolloutDP(3, "2")
+			dp.Status.UpdatedReplicas, dp.Status.AvailableReplicas = u.updated, u.available
+			dp.Status.Replicas, dp.Status.ObservedGeneration = u.replicas, u.generation
+			dp.Status.Conditions = nil
+
+			assert.Equal(t, u.e, NewRolloutStatus(dp, nil, nil, nil).Complete)
+		})
+	}
+}
+
+// Helpers...
+
+func makeRolloutDP(replicas int32, rev string) *appsv1.Deployment {
+	deadline := int32(600)
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "fred",
+			Namespace:   "blee",
+			UID:         "dp",
+			Generation:  2,
+			Annotations: map[string]string{RevisionAnnotation: rev},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas:                &replicas,
+			ProgressDeadlineSeconds: &deadline,
+		},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 2,
+			Replicas:           3,
+			UpdatedReplicas:    2,
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentProgressing, Reason: DeadlineExceededReason},
+			},
+		},
+	}
+}
+
+func makeRolloutRS(dp *appsv1.Deployment, n, rev string, replicas int32, ts time.Time) appsv1.ReplicaSet {
+	return appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              n,
+			Namespace:         dp.Namespace,
+			UID:               types.UID(n),
+			CreationTimestamp: metav1.NewTime(ts),
+			Annotations:       map[string]string{RevisionAnnotation: rev},
+			OwnerReferences:   []metav1.OwnerReference{controllerRef(dp.Name, dp.UID)},
+		},
+		Status: appsv1.ReplicaSetStatus{Replicas: replicas},
+	}
+}
+
+func makeRolloutPod(rs appsv1.ReplicaSet, n string, ready bool) v1.Pod {
+	status := v1.ConditionFalse
+	if ready {
+		status = v1.ConditionTrue
+	}
+	return v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            n,
+			Namespace:       rs.Namespace,
+			OwnerReferences: []metav1.OwnerReference{controllerRef(rs.Name, rs.UID)},
+		},
+		Status: v1.PodStatus{
+			Phase:      v1.PodRunning,
+			Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: status}},
+		},
+	}
+}
+
+func makeRolloutEvent(kind, n string, uid types.UID, reason string, ts time.Time) v1.Event {
+	return v1.Event{
+		InvolvedObject: v1.ObjectReference{Kind: kind, Name: n, UID: uid},
+		Reason:         reason,
+		Type:           "Normal",
+		LastTimestamp:  metav1.NewTime(ts),
+	}
+}
+
+func controllerRef(n string, uid types.UID) metav1.OwnerReference {
+	ok := true
+	return metav1.OwnerReference{Name: n, UID: uid, Controller: &ok}
+}
@@ -65,6 +65,20 @@ func controllerInfo(rs *v1.ReplicaSet) (string, string, string, error) {
 	return "", "", "", fmt.Errorf("Unable to find controller for ReplicaSet %s", rs.ObjectMeta.Name)
 }
 
+// OwnerPath returns the path of the deployment owning a replicaset.
+func (r *ReplicaSet) OwnerPath(fqn string) (string, error) {
+	rs, err := r.Load(r.Factory, fqn)
+	if err != nil {
+		return "", err
+	}
+	name, _, _, err := controllerInfo(rs)
+	if err != nil {
+		return "", err
+	}
+
+	return client.FQN(rs.Namespace, name), nil
+}
+
 // Rollback reverses the last deployment.
 func (r *ReplicaSet) Rollback(fqn string) error {
 	rs, err := r.Load(r.Factory, fqn)
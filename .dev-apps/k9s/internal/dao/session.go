@@ -0,0 +1,80 @@
+package dao
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/derailed/k9s/internal/client"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// SessionOpts represents an interactive container session options.
+type SessionOpts struct {
+	Container string
+	// Command to exec. An empty command attaches to the container.
+	Command   []string
+	In        io.Reader
+	Out       io.Writer
+	SizeQueue remotecommand.TerminalSizeQueue
+}
+
+// Session runs an interactive tty session in a container.
+func (p *Pod) Session(fqn string, opts SessionOpts) error {
+	so := remotecommand.StreamOptions{
+		Stdin:             opts.In,
+		Stdout:            opts.Out,
+		Tty:               true,
+		TerminalSizeQueue: opts.SizeQueue,
+	}
+	if len(opts.Command) == 0 {
+		return p.stream(fqn, "attach", &v1.PodAttachOptions{
+			Container: opts.Container,
+			Stdin:     true,
+			Stdout:    true,
+			TTY:       true,
+		}, so)
+	}
+
+	return p.stream(fqn, "exec", &v1.PodExecOptions{
+		Container: opts.Container,
+		Command:   opts.Command,
+		Stdin:     true,
+		Stdout:    true,
+		TTY:       true,
+	}, so)
+}
+
+func (p *Pod) stream(fqn, sub string, params runtime.Object, so remotecommand.StreamOptions) error {
+	ns, n := client.Namespaced(fqn)
+	auth, err := p.Client().CanI(ns, "v1/pods:"+sub, []string{client.CreateVerb})
+	if err != nil {
+		return err
+	}
+	if !auth {
+		return fmt.Errorf("user is not authorized to %s into pod %s", sub, fqn)
+	}
+
+	dial, err := p.Client().Dial()
+	if err != nil {
+		return err
+	}
+	cfg, err := p.Client().RestConfig()
+	if err != nil {
+		return err
+	}
+	req := dial.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(ns).
+		Name(n).
+		SubResource(sub).
+		VersionedParams(params, scheme.ParameterCodec)
+	exec, err := remotecommand.NewSPDYExecutor(cfg, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+
+	return exec.Stream(so)
+}
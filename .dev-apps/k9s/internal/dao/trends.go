@@ -0,0 +1,349 @@
+package dao
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// TrendRestarts tracks the cluster wide containers restarts sample key.
+	TrendRestarts = "restarts"
+
+	// TrendNodePrefix tracks node metrics sample keys prefix.
+	TrendNodePrefix = "node/"
+
+	trendsFile = "trends.jsonl"
+
+	trendsCompactFile = "trends.compacted"
+)
+
+// TrendSample represents a collection of values sampled at a given time.
+type TrendSample struct {
+	At     int64   `json:"t"`
+	Key    string  `json:"k"`
+	Values []int64 `json:"v"`
+}
+
+// Time returns the sample time.
+func (s TrendSample) Time() time.Time {
+	return time.Unix(s.At, 0)
+}
+
+// Value returns the sample value at the given index.
+func (s TrendSample) Value(i int) int64 {
+	if i < 0 || i >= len(s.Values) {
+		return 0
+	}
+
+	return s.Values[i]
+}
+
+// TrendNodeKey returns a node metrics sample key.
+func TrendNodeKey(name string) string {
+	return TrendNodePrefix + name
+}
+
+// TrendStore persists health trends samples as JSON lines.
+type TrendStore struct {
+	dir string
+	mx  sync.Mutex
+}
+
+// NewTrendStore returns a new store.
+func NewTrendStore(dir string) *TrendStore {
+	return &TrendStore{dir: dir}
+}
+
+// Path returns the store file path.
+func (t *TrendStore) Path() string {
+	return filepath.Join(t.dir, trendsFile)
+}
+
+// Append appends samples to the store.
+func (t *TrendStore) Append(ss []TrendSample) error {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	config.EnsureFullPath(t.dir, config.DefaultDirMod)
+	f, err := os.OpenFile(t.Path(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, config.DefaultFileMod)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Error().Err(err).Msgf("closing trends %s", t.Path())
+		}
+	}()
+
+	return writeTrends(f, ss)
+}
+
+// Load returns samples taken since a given time. When keys are specified only
+// the matching samples are returned.
+func (t *TrendStore) Load(since time.Time, keys ...string) ([]TrendSample, error) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	ss, err := t.load()
+	if err != nil {
+		return nil, err
+	}
+	kk := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		kk[k] = struct{}{}
+	}
+	oo := make([]TrendSample, 0, len(ss))
+	for _, s := range ss {
+		if s.At < since.Unix() {
+			continue
+		}
+		if _, ok := kk[s.Key]; len(kk) > 0 && !ok {
+			continue
+		}
+		oo = append(oo, s)
+	}
+
+	return oo, nil
+}
+
+// Compact enforces the store retention and compaction settings.
+func (t *TrendStore) Compact(now time.Time, cfg *config.Trends) error {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	ss, err := t.load()
+	if err != nil || len(ss) == 0 {
+		return err
+	}
+	ss = CompactTrends(ss, now, cfg.Retention(), cfg.CompactAfter(), cfg.CompactResolution())
+
+	tmp := t.Path() + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, config.DefaultFileMod)
+	if err != nil {
+		return err
+	}
+	if err := writeTrends(f, ss); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp, t.Path()); err != nil {
+		return err
+	}
+
+	return os.WriteFile(t.compactPath(), []byte(strconv.FormatInt(now.Unix(), 10)), config.DefaultFileMod)
+}
+
+// CompactDue checks if the store was last compacted longer than a given interval ago.
+func (t *TrendStore) CompactDue(now time.Time, every time.Duration) bool {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	raw, err := os.ReadFile(t.compactPath())
+	if err != nil {
+		return true
+	}
+	at, err := strconv.ParseInt(strings.TrimSpace(string(raw)), 10, 64)
+	if err != nil {
+		return true
+	}
+
+	return now.Sub(time.Unix(at, 0)) >= every
+}
+
+func (t *TrendStore) compactPath() string {
+	return filepath.Join(t.dir, trendsCompactFile)
+}
+
+func (t *TrendStore) load() ([]TrendSample, error) {
+	f, err := os.Open(t.Path())
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	ss := make([]TrendSample, 0, 100)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var s TrendSample
+		if err := json.Unmarshal(scanner.Bytes(), &s); err != nil {
+			log.Warn().Err(err).Msgf("Skipping invalid trend sample")
+			continue
+		}
+		ss = append(ss, s)
+	}
+
+	return ss, scanner.Err()
+}
+
+// CompactTrends drops samples older than the retention period and averages
+// samples older than compactAfter into buckets of the given resolution.
+func CompactTrends(ss []TrendSample, now time.Time, retention, compactAfter, res time.Duration) []TrendSample {
+	type bucket struct {
+		key string
+		at  int64
+	}
+	type tally struct {
+		sums  []int64
+		count int64
+	}
+
+	var (
+		cutoff, recent = now.Add(-retention).Unix(), now.Add(-compactAfter).Unix()
+		step           = int64(res.Seconds())
+		buckets        = make(map[bucket]*tally)
+		order          = make([]bucket, 0, len(ss))
+		oo             = make([]TrendSample, 0, len(ss))
+	)
+	if step <= 0 {
+		step = 1
+	}
+	for _, s := range ss {
+		if s.At < cutoff {
+			continue
+		}
+		if s.At >= recent {
+			oo = append(oo, s)
+			continue
+		}
+		b := bucket{key: s.Key, at: s.At - s.At%step}
+		t, ok := buckets[b]
+		if !ok {
+			t = &tally{}
+			buckets[b] = t
+			order = append(order, b)
+		}
+		for len(t.sums) < len(s.Values) {
+			t.sums = append(t.sums, 0)
+		}
+		for i, v := range s.Values {
+			t.sums[i] += v
+		}
+		t.count++
+	}
+
+	cc := make([]TrendSample, 0, len(order))
+	for _, b := range order {
+		t := buckets[b]
+		vv := make([]int64, len(t.sums))
+		for i, v := range t.sums {
+			vv[i] = v / t.count
+		}
+		cc = append(cc, TrendSample{At: b.at, Key: b.key, Values: vv})
+	}
+	all := append(cc, oo...)
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].At < all[j].At
+	})
+
+	return all
+}
+
+// TrendSeries returns the samples for a given key.
+func TrendSeries(ss []TrendSample, key string) []TrendSample {
+	oo := make([]TrendSample, 0, len(ss))
+	for _, s := range ss {
+		if s.Key == key {
+			oo = append(oo, s)
+		}
+	}
+
+	return oo
+}
+
+// TrendKeys returns the sorted sample keys matching a given prefix.
+func TrendKeys(ss []TrendSample, prefix string) []string {
+	kk := make(map[string]struct{})
+	for _, s := range ss {
+		if strings.HasPrefix(s.Key, prefix) {
+			kk[s.Key] = struct{}{}
+		}
+	}
+	keys := make([]string, 0, len(kk))
+	for k := range kk {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// TrendIncrease sums up a counter increments over a series within [from, to).
+// Samples are expected to be time ordered. The last sample prior to the window
+// is used as baseline. Counter resets ie pods going away are not deemed
+// decrements.
+func TrendIncrease(ss []TrendSample, idx int, from, to time.Time) int64 {
+	var (
+		total int64
+		prev  *TrendSample
+	)
+	for i := range ss {
+		s := ss[i]
+		if s.At >= to.Unix() {
+			break
+		}
+		if prev != nil && s.At >= from.Unix() {
+			if d := s.Value(idx) - prev.Value(idx); d > 0 {
+				total += d
+			}
+		}
+		prev = &ss[i]
+	}
+
+	return total
+}
+
+// TrendAverage returns a series average value within [from, to).
+func TrendAverage(ss []TrendSample, idx int, from, to time.Time) (int64, bool) {
+	var sum, count int64
+	for _, s := range ss {
+		if s.At < from.Unix() || s.At >= to.Unix() {
+			continue
+		}
+		sum += s.Value(idx)
+		count++
+	}
+	if count == 0 {
+		return 0, false
+	}
+
+	return sum / count, true
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func writeTrends(out io.Writer, ss []TrendSample) error {
+	w := bufio.NewWriter(out)
+	for _, s := range ss {
+		raw, err := json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "%s\n", raw); err != nil {
+			return err
+		}
+	}
+
+	return w.Flush()
+}
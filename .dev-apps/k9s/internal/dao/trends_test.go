@@ -0,0 +1,172 @@
+package dao
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrendStore(t *testing.T) {
+	dir := t.TempDir()
+	s := NewTrendStore(filepath.Join(dir, "fred"))
+	now := time.Now()
+
+	ss, err := s.Load(now.Add(-time.Hour))
+	assert.Nil(t, err)
+	assert.Empty(t, ss)
+
+	assert.Nil(t, s.Append([]TrendSample{
+		{At: now.Add(-2 * time.Hour).Unix(), Key: "v1/pods", Values: []int64{1, 2, 3}},
+		{At: now.Unix(), Key: "v1/pods", Values: []int64{4, 5, 6}},
+	}))
+	assert.Nil(t, s.Append([]TrendSample{
+		{At: now.Unix(), Key: TrendRestarts, Values: []int64{10}},
+	}))
+
+	ss, err = s.Load(now.Add(-3 * time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(ss))
+
+	ss, err = s.Load(now.Add(-time.Hour), "v1/pods")
+	assert.Nil(t, err)
+	assert.Equal(t, []TrendSample{{At: now.Unix(), Key: "v1/pods", Values: []int64{4, 5, 6}}}, ss)
+
+	cfg := config.NewTrends()
+	cfg.CompactAfterHours = 1
+	assert.Nil(t, s.Compact(now.Add(10*24*time.Hour), cfg))
+	ss, err = s.Load(time.Time{})
+	assert.Nil(t, err)
+	assert.Empty(t, ss)
+	_, err = os.Stat(s.Path() + ".tmp")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestTrendStoreCompactDue(t *testing.T) {
+	now := time.Now()
+	s := NewTrendStore(t.TempDir())
+	assert.True(t, s.CompactDue(now, 15*time.Minute))
+
+	assert.Nil(t, s.Append([]TrendSample{{At: now.Unix(), Key: TrendRestarts, Values: []int64{1}}}))
+	assert.Nil(t, s.Compact(now, config.NewTrends()))
+
+	uu := map[string]struct {
+		at time.Time
+		e  bool
+	}{
+		"just-compacted": {
+			at: now.Add(time.Minute),
+		},
+		"due": {
+			at: now.Add(15 * time.Minute),
+			e:  true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, s.CompactDue(u.at, 15*time.Minute))
+		})
+	}
+}
+
+func TestCompactTrends(t *testing.T) {
+	now := time.Unix(100_000, 0)
+	uu := map[string]struct {
+		ss []TrendSample
+		e  []TrendSample
+	}{
+		"empty": {
+			e: []TrendSample{},
+		},
+		"recent": {
+			ss: []TrendSample{
+				{At: 99_990, Key: "a", Values: []int64{1}},
+				{At: 99_995, Key: "a", Values: []int64{3}},
+			},
+			e: []TrendSample{
+				{At: 99_990, Key: "a", Values: []int64{1}},
+				{At: 99_995, Key: "a", Values: []int64{3}},
+			},
+		},
+		"expired": {
+			ss: []TrendSample{
+				{At: 10, Key: "a", Values: []int64{1}},
+			},
+			e: []TrendSample{},
+		},
+		"compacted": {
+			ss: []TrendSample{
+				{At: 90_000, Key: "a", Values: []int64{1, 10}},
+				{At: 90_050, Key: "b", Values: []int64{7}},
+				{At: 90_060, Key: "a", Values: []int64{3, 20}},
+				{At: 90_100, Key: "a", Values: []int64{5, 30}},
+				{At: 99_995, Key: "a", Values: []int64{9, 9}},
+			},
+			e: []TrendSample{
+				{At: 90_000, Key: "a", Values: []int64{2, 15}},
+				{At: 90_000, Key: "b", Values: []int64{7}},
+				{At: 90_100, Key: "a", Values: []int64{5, 30}},
+				{At: 99_995, Key: "a", Values: []int64{9, 9}},
+			},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			cc := CompactTrends(u.ss, now, 24*time.Hour, time.Minute, 100*time.Second)
+			assert.Equal(t, u.e, cc)
+			assert.Equal(t, cc, CompactTrends(cc, now, 24*time.Hour, time.Minute, 100*time.Second))
+		})
+	}
+}
+
+func TestTrendIncrease(t *testing.T) {
+	ss := []TrendSample{
+		{At: 10, Values: []int64{5}},
+		{At: 20, Values: []int64{7}},
+		{At: 30, Values: []int64{2}},
+		{At: 40, Values: []int64{4}},
+		{At: 50, Values: []int64{10}},
+	}
+	uu := map[string]struct {
+		from, to int64
+		e        int64
+	}{
+		"all":    {from: 0, to: 60, e: 10},
+		"window": {from: 20, to: 50, e: 4},
+		"none":   {from: 60, to: 70, e: 0},
+		"single": {from: 10, to: 20, e: 0},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, TrendIncrease(ss, 0, time.Unix(u.from, 0), time.Unix(u.to, 0)))
+		})
+	}
+}
+
+func TestTrendAverage(t *testing.T) {
+	ss := []TrendSample{
+		{At: 10, Values: []int64{2, 100}},
+		{At: 20, Values: []int64{4}},
+		{At: 30, Values: []int64{6, 200}},
+	}
+
+	v, ok := TrendAverage(ss, 0, time.Unix(0, 0), time.Unix(40, 0))
+	assert.True(t, ok)
+	assert.Equal(t, int64(4), v)
+
+	v, ok = TrendAverage(ss, 1, time.Unix(0, 0), time.Unix(40, 0))
+	assert.True(t, ok)
+	assert.Equal(t, int64(100), v)
+
+	_, ok = TrendAverage(ss, 0, time.Unix(40, 0), time.Unix(50, 0))
+	assert.False(t, ok)
+}
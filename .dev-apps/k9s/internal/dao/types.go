@@ -11,6 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/informers"
 	restclient "k8s.io/client-go/rest"
 )
@@ -123,6 +124,12 @@ type Nuker interface {
 	Delete(ctx context.Context, path string, propagation *metav1.DeletionPropagation, force bool) error
 }
 
+// MetaPatcher represents a resource with patchable labels and annotations.
+type MetaPatcher interface {
+	// PatchMeta applies metadata edits to a resource.
+	PatchMeta(ctx context.Context, path string, edits MetaEdits, pt types.PatchType) error
+}
+
 // Switchable represents a switchable resource.
 type Switchable interface {
 	// Switch changes the active context.
@@ -147,10 +154,15 @@ type Logger interface {
 	Logs(path string, opts *v1.PodLogOptions) (*restclient.Request, error)
 }
 
-// ContainsPodSpec represents a resource with a pod template.
-type ContainsPodSpec interface {
+// PodSpecer represents a resource with a readable pod template.
+type PodSpecer interface {
 	// Get PodSpec of a resource
 	GetPodSpec(path string) (*v1.PodSpec, error)
+}
+
+// ContainsPodSpec represents a resource with a pod template.
+type ContainsPodSpec interface {
+	PodSpecer
 
 	// Set Images for a resource
 	SetImages(ctx context.Context, path string, imageSpecs ImageSpecs) error
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/config"
 	"github.com/derailed/k9s/internal/dao"
 	"github.com/derailed/k9s/internal/health"
 	"github.com/rs/zerolog/log"
@@ -32,6 +33,7 @@ type Pulse struct {
 	listeners   []PulseListener
 	refreshRate time.Duration
 	health      *PulseHealth
+	tiles       []config.PulseTile
 	data        health.Checks
 }
 
@@ -40,9 +42,15 @@ func NewPulse(gvr string) *Pulse {
 	return &Pulse{
 		gvr:         gvr,
 		refreshRate: defaultRefreshRate,
+		tiles:       config.NewPulse().Tiles,
 	}
 }
 
+// SetTiles sets the dashboard tiles to track.
+func (p *Pulse) SetTiles(tt []config.PulseTile) {
+	p.tiles, p.health = tt, nil
+}
+
 // Watch monitors pulses.
 func (p *Pulse) Watch(ctx context.Context) {
 	p.Refresh(ctx)
@@ -92,7 +100,7 @@ func (p *Pulse) list(ctx context.Context) ([]runtime.Object, error) {
 		return nil, fmt.Errorf("expected Factory in context but got %T", ctx.Value(internal.KeyFactory))
 	}
 	if p.health == nil {
-		p.health = NewPulseHealth(f)
+		p.health = NewPulseHealth(f, p.tiles)
 	}
 	ctx = context.WithValue(ctx, internal.KeyFields, "")
 	ctx = context.WithValue(ctx, internal.KeyWithMetrics, false)
@@ -101,7 +109,7 @@ func (p *Pulse) list(ctx context.Context) ([]runtime.Object, error) {
 
 func (p *Pulse) reconcile(ctx context.Context) error {
 	oo, err := p.list(ctx)
-	if err != nil {
+	if err != nil && len(oo) == 0 {
 		return err
 	}
 
@@ -114,7 +122,8 @@ func (p *Pulse) reconcile(ctx context.Context) error {
 		p.data = append(p.data, c)
 		p.firePulseChanged(c)
 	}
-	return nil
+
+	return err
 }
 
 // GetNamespace returns the model namespace.
@@ -5,47 +5,65 @@ import (
 	"fmt"
 
 	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
 	"github.com/derailed/k9s/internal/dao"
 	"github.com/derailed/k9s/internal/health"
 	"github.com/derailed/k9s/internal/render"
 	"github.com/rs/zerolog/log"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // PulseHealth tracks resources health.
 type PulseHealth struct {
 	factory dao.Factory
+	tiles   []config.PulseTile
 }
 
 // NewPulseHealth returns a new instance.
-func NewPulseHealth(f dao.Factory) *PulseHealth {
+func NewPulseHealth(f dao.Factory, tt []config.PulseTile) *PulseHealth {
 	return &PulseHealth{
 		factory: f,
+		tiles:   tt,
 	}
 }
 
-// List returns a canned collection of resources health.
+// List returns the dashboard tiles resources health. Failing tiles are skipped
+// and the first error is reported along with the remaining checks.
 func (h *PulseHealth) List(ctx context.Context, ns string) ([]runtime.Object, error) {
-	gvrs := []string{
-		"v1/pods",
-		"v1/events",
-		"apps/v1/replicasets",
-		"apps/v1/deployments",
-		"apps/v1/statefulsets",
-		"apps/v1/daemonsets",
-		"batch/v1/jobs",
-		"v1/persistentvolumes",
-	}
-
-	hh := make([]runtime.Object, 0, 10)
-	for _, gvr := range gvrs {
-		c, err := h.check(ctx, ns, gvr)
+	var (
+		hh       = make([]runtime.Object, 0, len(h.tiles))
+		firstErr error
+		metrics  bool
+	)
+	for _, t := range h.tiles {
+		if t.IsMetrics() {
+			metrics = true
+			continue
+		}
+		var (
+			c   *health.Check
+			err error
+		)
+		if t.HasRules() {
+			c, err = h.checkRules(ns, t)
+		} else {
+			c, err = h.check(ctx, ns, t.GVR)
+		}
 		if err != nil {
-			return nil, err
+			log.Warn().Err(err).Msgf("Pulse check failed for %q", t.GVR)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
 		}
 		hh = append(hh, c)
 	}
+	if !metrics {
+		return hh, firstErr
+	}
 
 	mm, err := h.checkMetrics(ctx)
 	if err != nil {
@@ -55,7 +73,32 @@ func (h *PulseHealth) List(ctx context.Context, ns string) ([]runtime.Object, er
 		hh = append(hh, m)
 	}
 
-	return hh, nil
+	return hh, firstErr
+}
+
+func (h *PulseHealth) checkRules(ns string, t config.PulseTile) (*health.Check, error) {
+	oo, err := h.factory.List(t.GVR, ns, true, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	c := health.NewCheck(t.GVR)
+	c.Total(int64(len(oo)))
+	for _, o := range oo {
+		var m map[string]interface{}
+		if u, ok := o.(*unstructured.Unstructured); ok {
+			m = u.Object
+		} else if m, err = runtime.DefaultUnstructuredConverter.ToUnstructured(o); err != nil {
+			return nil, err
+		}
+		l, err := PulseLevel(t, m)
+		if err != nil {
+			return nil, err
+		}
+		c.Inc(l)
+	}
+
+	return c, nil
 }
 
 func (h *PulseHealth) checkMetrics(ctx context.Context) (health.Checks, error) {
@@ -0,0 +1,120 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/health"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// PulseLevel computes a resource health level given a tile rules.
+// Critical rules are checked first, then warning rules. A resource failing
+// the healthy rules is deemed critical. Returns S1 when healthy, S3 when
+// warning and S2 when critical.
+func PulseLevel(t config.PulseTile, o map[string]interface{}) (health.Level, error) {
+	for _, r := range t.Critical {
+		ok, err := matchRule(r, o)
+		if err != nil {
+			return health.Unknown, err
+		}
+		if ok {
+			return health.S2, nil
+		}
+	}
+	for _, r := range t.Warning {
+		ok, err := matchRule(r, o)
+		if err != nil {
+			return health.Unknown, err
+		}
+		if ok {
+			return health.S3, nil
+		}
+	}
+	for _, r := range t.Healthy {
+		ok, err := matchRule(r, o)
+		if err != nil {
+			return health.Unknown, err
+		}
+		if !ok {
+			return health.S2, nil
+		}
+	}
+
+	return health.S1, nil
+}
+
+func matchRule(r config.PulseRule, o map[string]interface{}) (bool, error) {
+	if r.Condition != "" {
+		return matchCondition(r, o), nil
+	}
+	if r.JSONPath == "" {
+		return false, fmt.Errorf("pulse rule requires either a condition or a jsonPath")
+	}
+
+	vv, err := dao.JSONPathValues(r.JSONPath, o)
+	if err != nil {
+		return false, err
+	}
+	if r.Op == "" && r.Value == "" {
+		return len(vv) > 0, nil
+	}
+	for _, v := range vv {
+		ok, err := compare(v, r.Op, r.Value)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func matchCondition(r config.PulseRule, o map[string]interface{}) bool {
+	cc, _, _ := unstructured.NestedSlice(o, "status", "conditions")
+	for _, c := range cc {
+		m, ok := c.(map[string]interface{})
+		if !ok || m["type"] != r.Condition {
+			continue
+		}
+		return strings.EqualFold(fmt.Sprintf("%v", m["status"]), r.Status)
+	}
+
+	return false
+}
+
+func compare(v, op, expected string) (bool, error) {
+	switch op {
+	case "", "==":
+		return v == expected, nil
+	case "!=":
+		return v != expected, nil
+	case "<", "<=", ">", ">=":
+	default:
+		return false, fmt.Errorf("unsupported pulse rule operator %q", op)
+	}
+
+	n1, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return false, nil
+	}
+	n2, err := strconv.ParseFloat(expected, 64)
+	if err != nil {
+		return false, fmt.Errorf("expecting a number for operator %q but got %q", op, expected)
+	}
+	switch op {
+	case "<":
+		return n1 < n2, nil
+	case "<=":
+		return n1 <= n2, nil
+	case ">":
+		return n1 > n2, nil
+	default:
+		return n1 >= n2, nil
+	}
+}
@@ -0,0 +1,127 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/health"
+	"github.com/derailed/k9s/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPulseLevel(t *testing.T) {
+	uu := map[string]struct {
+		t   config.PulseTile
+		o   map[string]interface{}
+		e   health.Level
+		err bool
+	}{
+		"no-rules": {
+			o: makePulseObj("True", 1),
+			e: health.S1,
+		},
+		"ready": {
+			t: config.PulseTile{Healthy: []config.PulseRule{{Condition: "Ready", Status: "True"}}},
+			o: makePulseObj("True", 1),
+			e: health.S1,
+		},
+		"not-ready": {
+			t: config.PulseTile{Healthy: []config.PulseRule{{Condition: "Ready", Status: "True"}}},
+			o: makePulseObj("False", 1),
+			e: health.S2,
+		},
+		"no-condition": {
+			t: config.PulseTile{Healthy: []config.PulseRule{{Condition: "Synced", Status: "True"}}},
+			o: makePulseObj("True", 1),
+			e: health.S2,
+		},
+		"critical": {
+			t: config.PulseTile{Critical: []config.PulseRule{{Condition: "Ready", Status: "false"}}},
+			o: makePulseObj("False", 1),
+			e: health.S2,
+		},
+		"warning": {
+			t: config.PulseTile{
+				Warning:  []config.PulseRule{{JSONPath: "{.status.restarts}", Op: ">", Value: "0"}},
+				Critical: []config.PulseRule{{JSONPath: "{.status.restarts}", Op: ">=", Value: "5"}},
+			},
+			o: makePulseObj("True", 2),
+			e: health.S3,
+		},
+		"critical-first": {
+			t: config.PulseTile{
+				Warning:  []config.PulseRule{{JSONPath: "{.status.restarts}", Op: ">", Value: "0"}},
+				Critical: []config.PulseRule{{JSONPath: "{.status.restarts}", Op: ">=", Value: "5"}},
+			},
+			o: makePulseObj("True", 5),
+			e: health.S2,
+		},
+		"equal": {
+			t: config.PulseTile{Healthy: []config.PulseRule{{JSONPath: "{.status.phase}", Value: "Bound"}}},
+			o: makePulseObj("True", 0),
+			e: health.S1,
+		},
+		"not-equal": {
+			t: config.PulseTile{Critical: []config.PulseRule{{JSONPath: "{.status.phase}", Op: "!=", Value: "Bound"}}},
+			o: makePulseObj("True", 0),
+			e: health.S1,
+		},
+		"exists": {
+			t: config.PulseTile{Warning: []config.PulseRule{{JSONPath: "{.status.phase}"}}},
+			o: makePulseObj("True", 0),
+			e: health.S3,
+		},
+		"missing": {
+			t: config.PulseTile{Warning: []config.PulseRule{{JSONPath: "{.status.fred}"}}},
+			o: makePulseObj("True", 0),
+			e: health.S1,
+		},
+		"filter": {
+			t: config.PulseTile{Healthy: []config.PulseRule{{JSONPath: `{.status.conditions[?(@.type=="Ready")].status}`, Value: "True"}}},
+			o: makePulseObj("True", 0),
+			e: health.S1,
+		},
+		"bad-path": {
+			t:   config.PulseTile{Healthy: []config.PulseRule{{JSONPath: "{.status"}}},
+			o:   makePulseObj("True", 0),
+			err: true,
+		},
+		"bad-op": {
+			t:   config.PulseTile{Healthy: []config.PulseRule{{JSONPath: "{.status.restarts}", Op: "~", Value: "1"}}},
+			o:   makePulseObj("True", 1),
+			err: true,
+		},
+		"empty-rule": {
+			t:   config.PulseTile{Healthy: []config.PulseRule{{}}},
+			o:   makePulseObj("True", 1),
+			err: true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			l, err := model.PulseLevel(u.t, u.o)
+			if u.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, u.e, l)
+		})
+	}
+}
+
+// Helpers...
+
+func makePulseObj(ready string, restarts int64) map[string]interface{} {
+	return map[string]interface{}{
+		"status": map[string]interface{}{
+			"phase":    "Bound",
+			"restarts": restarts,
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": ready},
+			},
+		},
+	}
+}
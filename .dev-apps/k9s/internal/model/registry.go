@@ -25,6 +25,10 @@ var Registry = map[string]ResourceMeta{
 		DAO:      &dao.Helm{},
 		Renderer: &render.Helm{},
 	},
+	"helm-history": {
+		DAO:      &dao.HelmHistory{},
+		Renderer: &render.HelmHistory{},
+	},
 	// BOZO!! revamp with latest...
 	// "openfaas": {
 	// 	DAO:      &dao.OpenFaas{},
@@ -35,6 +39,26 @@ var Registry = map[string]ResourceMeta{
 		Renderer:     &render.Container{},
 		TreeRenderer: &xray.Container{},
 	},
+	"revisions": {
+		DAO:      &dao.Revision{},
+		Renderer: &render.Revision{},
+	},
+	"images": {
+		DAO:      &dao.Image{},
+		Renderer: &render.Image{},
+	},
+	"imageconsumers": {
+		DAO:      &dao.ImageConsumer{},
+		Renderer: &render.ImageConsumer{},
+	},
+	"rightsizing": {
+		DAO:      &dao.Rightsizing{},
+		Renderer: &render.Rightsizing{},
+	},
+	"capacity": {
+		DAO:      &dao.Capacity{},
+		Renderer: &render.Capacity{},
+	},
 	"contexts": {
 		DAO:      &dao.Context{},
 		Renderer: &render.Context{},
@@ -63,6 +87,18 @@ var Registry = map[string]ResourceMeta{
 		DAO:      &dao.PortForward{},
 		Renderer: &render.PortForward{},
 	},
+	"journal": {
+		DAO:      &dao.Journal{},
+		Renderer: &render.Journal{},
+	},
+	"certs": {
+		DAO:      &dao.Cert{},
+		Renderer: &render.Cert{},
+	},
+	"consumers": {
+		DAO:      &dao.Consumer{},
+		Renderer: &render.Consumer{},
+	},
 	"benchmarks": {
 		DAO:      &dao.Benchmark{},
 		Renderer: &render.Benchmark{},
@@ -0,0 +1,146 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/rs/zerolog/log"
+)
+
+const rolloutRefreshRate = 2 * time.Second
+
+// RolloutListener represents a rollout model listener.
+type RolloutListener interface {
+	// RolloutChanged notifies the rollout status changed.
+	RolloutChanged(*dao.RolloutStatus)
+
+	// RolloutFailed notifies the rollout status could not be fetched.
+	RolloutFailed(error)
+}
+
+// Rollout tracks a deployment rollout progress.
+type Rollout struct {
+	path        string
+	inUpdate    int32
+	listeners   []RolloutListener
+	refreshRate time.Duration
+	status      *dao.RolloutStatus
+	mx          sync.RWMutex
+}
+
+// NewRollout returns a new rollout model.
+func NewRollout(path string) *Rollout {
+	return &Rollout{
+		path:        path,
+		refreshRate: rolloutRefreshRate,
+	}
+}
+
+// GetPath returns the deployment path.
+func (r *Rollout) GetPath() string {
+	return r.path
+}
+
+// Watch monitors the rollout.
+func (r *Rollout) Watch(ctx context.Context) {
+	r.Refresh(ctx)
+	go r.updater(ctx)
+}
+
+func (r *Rollout) updater(ctx context.Context) {
+	defer log.Debug().Msgf("Rollout canceled -- %q", r.path)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.refreshRate):
+			r.refresh(ctx)
+		}
+	}
+}
+
+// Refresh updates the model now.
+func (r *Rollout) Refresh(ctx context.Context) {
+	if st := r.getStatus(); st != nil {
+		r.fireRolloutChanged(st)
+	}
+	r.refresh(ctx)
+}
+
+func (r *Rollout) refresh(ctx context.Context) {
+	if !atomic.CompareAndSwapInt32(&r.inUpdate, 0, 1) {
+		log.Debug().Msgf("Dropping update...")
+		return
+	}
+	defer atomic.StoreInt32(&r.inUpdate, 0)
+
+	if err := r.reconcile(ctx); err != nil {
+		log.Error().Err(err).Msg("Rollout reconcile failed")
+		r.fireRolloutFailed(err)
+	}
+}
+
+func (r *Rollout) reconcile(ctx context.Context) error {
+	f, ok := ctx.Value(internal.KeyFactory).(dao.Factory)
+	if !ok {
+		return fmt.Errorf("expected Factory in context but got %T", ctx.Value(internal.KeyFactory))
+	}
+	var dp dao.Deployment
+	dp.Init(f, client.NewGVR("apps/v1/deployments"))
+	st, err := dp.Rollout(ctx, r.path)
+	if err != nil {
+		return err
+	}
+	r.mx.Lock()
+	r.status = st
+	r.mx.Unlock()
+	r.fireRolloutChanged(st)
+
+	return nil
+}
+
+func (r *Rollout) getStatus() *dao.RolloutStatus {
+	r.mx.RLock()
+	defer r.mx.RUnlock()
+
+	return r.status
+}
+
+// AddListener adds a listener.
+func (r *Rollout) AddListener(l RolloutListener) {
+	r.listeners = append(r.listeners, l)
+}
+
+// RemoveListener delete a listener.
+func (r *Rollout) RemoveListener(l RolloutListener) {
+	victim := -1
+	for i, lis := range r.listeners {
+		if lis == l {
+			victim = i
+			break
+		}
+	}
+
+	if victim >= 0 {
+		r.listeners = append(r.listeners[:victim], r.listeners[victim+1:]...)
+	}
+}
+
+func (r *Rollout) fireRolloutChanged(st *dao.RolloutStatus) {
+	for _, l := range r.listeners {
+		l.RolloutChanged(st)
+	}
+}
+
+func (r *Rollout) fireRolloutFailed(err error) {
+	for _, l := range r.listeners {
+		l.RolloutFailed(err)
+	}
+}
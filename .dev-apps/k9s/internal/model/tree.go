@@ -10,6 +10,7 @@ import (
 
 	"github.com/derailed/k9s/internal"
 	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
 	"github.com/derailed/k9s/internal/dao"
 	"github.com/derailed/k9s/internal/render"
 	"github.com/derailed/k9s/internal/xray"
@@ -20,6 +21,13 @@ import (
 
 const initTreeRefreshRate = 500 * time.Millisecond
 
+// netTreeRenderers tracks network path renderers for resources listed generically.
+var netTreeRenderers = map[string]TreeRenderer{
+	"networking.k8s.io/v1/ingresses":             &xray.Ingress{},
+	"gateway.networking.k8s.io/v1/gateways":      &xray.Gateway{},
+	"gateway.networking.k8s.io/v1beta1/gateways": &xray.Gateway{},
+}
+
 // TreeListener represents a tree model listener.
 type TreeListener interface {
 	// TreeChanged notifies the model data changed.
@@ -38,6 +46,7 @@ type Tree struct {
 	inUpdate    int32
 	refreshRate time.Duration
 	query       string
+	rules       *config.XrayRules
 }
 
 // NewTree returns a new model.
@@ -48,6 +57,11 @@ func NewTree(gvr client.GVR) *Tree {
 	}
 }
 
+// SetRules sets user defined relationship rules.
+func (t *Tree) SetRules(rr *config.XrayRules) {
+	t.rules = rr
+}
+
 // ClearFilter clears out active filter.
 func (t *Tree) ClearFilter() {
 	t.query = ""
@@ -198,30 +212,45 @@ func (t *Tree) list(ctx context.Context, a dao.Accessor) ([]runtime.Object, erro
 	return a.List(ctx, client.CleanseNamespace(t.namespace))
 }
 
-func (t *Tree) reconcile(ctx context.Context) error {
+// Load builds the resource tree once.
+func (t *Tree) Load(ctx context.Context) (*xray.TreeNode, error) {
+	return t.build(ctx)
+}
+
+func (t *Tree) build(ctx context.Context) (*xray.TreeNode, error) {
 	meta := t.resourceMeta()
 	oo, err := t.list(ctx, meta.DAO)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	ns := client.CleanseNamespace(t.namespace)
 	res := t.gvr.R()
 	root := xray.NewTreeNode(res, res)
 	ctx = context.WithValue(ctx, xray.KeyParent, root)
+	ctx = context.WithValue(ctx, xray.KeyControllerNS, t.rules.IngressControllerNamespace())
 	if _, ok := meta.TreeRenderer.(*xray.Generic); ok {
 		table, ok := oo[0].(*metav1beta1.Table)
 		if !ok {
-			return fmt.Errorf("expecting a Table but got %T", oo[0])
+			return nil, fmt.Errorf("expecting a Table but got %T", oo[0])
 		}
 		if err := genericTreeHydrate(ctx, ns, table, meta.TreeRenderer); err != nil {
-			return err
+			return nil, err
 		}
 	} else if err := treeHydrate(ctx, ns, oo, meta.TreeRenderer); err != nil {
+		return nil, err
+	}
+	root.Sort()
+
+	return root, nil
+}
+
+func (t *Tree) reconcile(ctx context.Context) error {
+	root, err := t.build(ctx)
+	if err != nil {
 		return err
 	}
 
-	root.Sort()
 	if t.query != "" {
 		t.root = root.Filter(t.query, rxFilter)
 	}
@@ -234,7 +263,19 @@ func (t *Tree) reconcile(ctx context.Context) error {
 }
 
 func (t *Tree) resourceMeta() ResourceMeta {
+	if re, ok := netTreeRenderers[t.gvr.String()]; ok {
+		return ResourceMeta{
+			DAO:          &dao.Resource{},
+			TreeRenderer: re,
+		}
+	}
 	meta, ok := Registry[t.gvr.String()]
+	if meta.TreeRenderer == nil && t.rules.HasRules(t.gvr.String()) {
+		return ResourceMeta{
+			DAO:          &dao.Resource{},
+			TreeRenderer: xray.NewRelation(t.gvr.String(), t.rules, treeRendererFor),
+		}
+	}
 	if !ok {
 		meta = ResourceMeta{
 			DAO:      &dao.Table{},
@@ -274,6 +315,16 @@ func (t *Tree) getMeta(ctx context.Context, gvr string) (ResourceMeta, error) {
 // ----------------------------------------------------------------------------
 // Helpers...
 
+// treeRendererFor returns a resource built-in tree renderer if any.
+func treeRendererFor(gvr string) (xray.Renderer, bool) {
+	meta, ok := Registry[gvr]
+	if !ok || meta.TreeRenderer == nil {
+		return nil, false
+	}
+
+	return meta.TreeRenderer, true
+}
+
 func rxFilter(q, path string) bool {
 	rx := regexp.MustCompile(`(?i)` + q)
 
@@ -0,0 +1,162 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/health"
+	"github.com/rs/zerolog/log"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// TrendRecorder periodically persists cluster health and metrics samples.
+type TrendRecorder struct {
+	factory dao.Factory
+	store   *dao.TrendStore
+	cfg     *config.Trends
+	tiles   []config.PulseTile
+}
+
+// NewTrendRecorder returns a new recorder.
+func NewTrendRecorder(f dao.Factory, s *dao.TrendStore, cfg *config.Trends, tt []config.PulseTile) *TrendRecorder {
+	return &TrendRecorder{
+		factory: f,
+		store:   s,
+		cfg:     cfg,
+		tiles:   tt,
+	}
+}
+
+// Watch records samples until canceled.
+func (r *TrendRecorder) Watch(ctx context.Context) {
+	defer log.Debug().Msgf("Trends recorder canceled")
+
+	rate := initRefreshRate
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(rate):
+			rate = r.cfg.SampleInterval()
+			if err := r.store.Append(r.Sample(ctx)); err != nil {
+				log.Error().Err(err).Msgf("Trends recording failed")
+			}
+			r.compact(time.Now())
+		}
+	}
+}
+
+// compact compacts the store once per compaction resolution. The schedule is
+// tracked by the store so it holds across recorder restarts.
+func (r *TrendRecorder) compact(now time.Time) {
+	if !r.store.CompactDue(now, r.cfg.CompactResolution()) {
+		return
+	}
+	if err := r.store.Compact(now, r.cfg); err != nil {
+		log.Error().Err(err).Msgf("Trends compaction failed")
+	}
+}
+
+// Sample collects the current cluster health and metrics samples.
+func (r *TrendRecorder) Sample(ctx context.Context) []dao.TrendSample {
+	at := time.Now().Unix()
+	ss := make([]dao.TrendSample, 0, len(r.tiles)+10)
+
+	ctx = context.WithValue(ctx, internal.KeyFactory, r.factory)
+	ctx = context.WithValue(ctx, internal.KeyFields, "")
+	ctx = context.WithValue(ctx, internal.KeyWithMetrics, false)
+	oo, err := NewPulseHealth(r.factory, r.tiles).List(ctx, client.AllNamespaces)
+	if err != nil {
+		log.Warn().Err(err).Msgf("Trends health sampling failed")
+	}
+	for _, o := range oo {
+		c, ok := o.(*health.Check)
+		if !ok {
+			continue
+		}
+		ss = append(ss, dao.TrendSample{
+			At:     at,
+			Key:    c.GVR,
+			Values: []int64{c.Tally(health.S1), c.Tally(health.S2), c.Tally(health.S3)},
+		})
+	}
+
+	if n, err := r.restarts(); err != nil {
+		log.Warn().Err(err).Msgf("Trends restarts sampling failed")
+	} else {
+		ss = append(ss, dao.TrendSample{At: at, Key: dao.TrendRestarts, Values: []int64{n}})
+	}
+
+	if !r.factory.Client().HasMetrics() {
+		return ss
+	}
+	mm, err := r.nodesMetrics(ctx)
+	if err != nil {
+		log.Warn().Err(err).Msgf("Trends nodes metrics sampling failed")
+		return ss
+	}
+	for name, m := range mm {
+		ss = append(ss, dao.TrendSample{
+			At:     at,
+			Key:    dao.TrendNodeKey(name),
+			Values: []int64{m.CurrentCPU, m.CurrentMEM, m.AllocatableCPU, m.AllocatableMEM},
+		})
+	}
+
+	return ss
+}
+
+func (r *TrendRecorder) restarts() (int64, error) {
+	oo, err := r.factory.List("v1/pods", client.AllNamespaces, false, labels.Everything())
+	if err != nil {
+		return 0, err
+	}
+
+	return CountRestarts(oo), nil
+}
+
+func (r *TrendRecorder) nodesMetrics(ctx context.Context) (client.NodesMetrics, error) {
+	nn, err := dao.FetchNodes(ctx, r.factory, "")
+	if err != nil {
+		return nil, err
+	}
+	dial := client.DialMetrics(r.factory.Client())
+	nmx, err := dial.FetchNodesMetrics(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mx := make(client.NodesMetrics, len(nn.Items))
+	dial.NodesMetrics(nn, nmx, mx)
+
+	return mx, nil
+}
+
+// CountRestarts sums up pods containers restarts.
+func CountRestarts(oo []runtime.Object) int64 {
+	var total int64
+	for _, o := range oo {
+		u, ok := o.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		for _, f := range []string{"initContainerStatuses", "containerStatuses"} {
+			cc, _, _ := unstructured.NestedSlice(u.Object, "status", f)
+			for _, c := range cc {
+				m, ok := c.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				n, _, _ := unstructured.NestedInt64(m, "restartCount")
+				total += n
+			}
+		}
+	}
+
+	return total
+}
@@ -0,0 +1,53 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/model"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCountRestarts(t *testing.T) {
+	uu := map[string]struct {
+		oo []runtime.Object
+		e  int64
+	}{
+		"empty": {},
+		"pods": {
+			oo: []runtime.Object{
+				makeRestartPod([]int64{1}, []int64{2, 3}),
+				makeRestartPod(nil, []int64{4}),
+				makeRestartPod(nil, nil),
+			},
+			e: 10,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, model.CountRestarts(u.oo))
+		})
+	}
+}
+
+// Helpers...
+
+func makeRestartPod(ii, cc []int64) *unstructured.Unstructured {
+	statuses := func(nn []int64) []interface{} {
+		ss := make([]interface{}, 0, len(nn))
+		for _, n := range nn {
+			ss = append(ss, map[string]interface{}{"restartCount": n})
+		}
+		return ss
+	}
+
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"initContainerStatuses": statuses(ii),
+			"containerStatuses":     statuses(cc),
+		},
+	}}
+}
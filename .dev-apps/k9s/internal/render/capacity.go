@@ -0,0 +1,165 @@
+package render
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/tview"
+	"github.com/gdamore/tcell/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	capacityBarWidth = 10
+	barUsage         = '█'
+	barRequests      = '▓'
+	barLimits        = '░'
+	barFree          = '·'
+	barOvercommit    = '+'
+)
+
+// Capacity renders nodes capacity and bin-packing to screen.
+type Capacity struct {
+	Base
+}
+
+// ColorerFunc colors a resource row.
+func (Capacity) ColorerFunc() ColorerFunc {
+	return func(ns string, h Header, re RowEvent) tcell.Color {
+		if idx := h.IndexOf("SCHEDULABLE", true); idx >= 0 && idx < len(re.Row.Fields) && re.Row.Fields[idx] == "false" {
+			return CompletedColor
+		}
+		for _, col := range []string{"%CPU/R", "%MEM/R"} {
+			idx := h.IndexOf(col, true)
+			if idx < 0 || idx >= len(re.Row.Fields) {
+				continue
+			}
+			if p, err := strconv.Atoi(re.Row.Fields[idx]); err == nil && p >= 90 {
+				return ErrColor
+			}
+		}
+
+		return DefaultColorer(ns, h, re)
+	}
+}
+
+// Header returns a header row.
+func (Capacity) Header(ns string) Header {
+	return Header{
+		HeaderColumn{Name: "NAME"},
+		HeaderColumn{Name: "PODS", Align: tview.AlignRight},
+		HeaderColumn{Name: "CPU/A", Align: tview.AlignRight},
+		HeaderColumn{Name: "CPU/R", Align: tview.AlignRight},
+		HeaderColumn{Name: "CPU/L", Align: tview.AlignRight},
+		HeaderColumn{Name: "CPU/U", Align: tview.AlignRight, MX: true},
+		HeaderColumn{Name: "%CPU/R", Align: tview.AlignRight},
+		HeaderColumn{Name: "CPU/OC", Align: tview.AlignRight},
+		HeaderColumn{Name: "CPU"},
+		HeaderColumn{Name: "MEM/A", Align: tview.AlignRight},
+		HeaderColumn{Name: "MEM/R", Align: tview.AlignRight},
+		HeaderColumn{Name: "MEM/L", Align: tview.AlignRight},
+		HeaderColumn{Name: "MEM/U", Align: tview.AlignRight, MX: true},
+		HeaderColumn{Name: "%MEM/R", Align: tview.AlignRight},
+		HeaderColumn{Name: "MEM/OC", Align: tview.AlignRight},
+		HeaderColumn{Name: "MEM"},
+		HeaderColumn{Name: "SCHEDULABLE", Wide: true},
+	}
+}
+
+// Render renders a K8s resource to screen.
+func (Capacity) Render(o interface{}, ns string, r *Row) error {
+	c, ok := o.(CapacityRes)
+	if !ok {
+		return fmt.Errorf("expecting CapacityRes, but got %T", o)
+	}
+
+	r.ID = client.FQN("", c.Name)
+	r.Fields = Fields{
+		c.Name,
+		fmt.Sprintf("%d/%d", c.Pods, c.MaxPods),
+		toMc(c.CPU.Allocatable),
+		toMc(c.CPU.Requests),
+		toMc(c.CPU.Limits),
+		toMc(c.CPU.Usage),
+		strconv.Itoa(client.ToPercentage(c.CPU.Requests, c.CPU.Allocatable)),
+		c.CPU.Overcommit(),
+		c.CPU.Bar(capacityBarWidth),
+		toMi(c.Mem.Allocatable),
+		toMi(c.Mem.Requests),
+		toMi(c.Mem.Limits),
+		toMi(c.Mem.Usage),
+		strconv.Itoa(client.ToPercentage(c.Mem.Requests, c.Mem.Allocatable)),
+		c.Mem.Overcommit(),
+		c.Mem.Bar(capacityBarWidth),
+		boolToStr(!c.Unschedulable),
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+// CapacityUsage represents a node resource allocation.
+// CPU values are expressed in millicores and memory values in bytes.
+type CapacityUsage struct {
+	Allocatable, Requests, Limits, Usage int64
+}
+
+// Overcommit returns the limits to allocatable ratio.
+func (c CapacityUsage) Overcommit() string {
+	if c.Allocatable == 0 {
+		return NAValue
+	}
+
+	return fmt.Sprintf("%.2fx", float64(c.Limits)/float64(c.Allocatable))
+}
+
+// Bar returns a stacked gauge of usage, requests and limits relative to allocatable.
+func (c CapacityUsage) Bar(width int) string {
+	if c.Allocatable == 0 || width <= 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	for i := 1; i <= width; i++ {
+		slot := c.Allocatable * int64(i) / int64(width)
+		switch {
+		case c.Usage > 0 && c.Usage >= slot:
+			b.WriteRune(barUsage)
+		case c.Requests >= slot:
+			b.WriteRune(barRequests)
+		case c.Limits >= slot:
+			b.WriteRune(barLimits)
+		default:
+			b.WriteRune(barFree)
+		}
+	}
+	if c.Limits > c.Allocatable {
+		b.WriteRune(barOvercommit)
+	}
+
+	return b.String()
+}
+
+// CapacityRes represents a node capacity.
+type CapacityRes struct {
+	Name          string
+	Unschedulable bool
+	Pods          int
+	MaxPods       int64
+	CPU, Mem      CapacityUsage
+}
+
+// GetObjectKind returns a schema object.
+func (CapacityRes) GetObjectKind() schema.ObjectKind {
+	return nil
+}
+
+// DeepCopyObject returns a container copy.
+func (c CapacityRes) DeepCopyObject() runtime.Object {
+	return c
+}
@@ -0,0 +1,77 @@
+package render_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/render"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapacityRender(t *testing.T) {
+	var c render.Capacity
+
+	res := render.CapacityRes{
+		Name:    "n1",
+		Pods:    3,
+		MaxPods: 110,
+		CPU:     render.CapacityUsage{Allocatable: 4000, Requests: 2000, Limits: 6000, Usage: 1000},
+		Mem:     render.CapacityUsage{Allocatable: 8 * 1024 * 1024 * 1024, Requests: 1024 * 1024 * 1024},
+	}
+	var r render.Row
+	assert.Nil(t, c.Render(res, "", &r))
+
+	assert.Equal(t, "n1", r.ID)
+	assert.Equal(t, render.Fields{
+		"n1",
+		"3/110",
+		"4000",
+		"2000",
+		"6000",
+		"1000",
+		"50",
+		"1.50x",
+		"██▓▓▓░░░░░+",
+		"8192",
+		"1024",
+		"0",
+		"0",
+		"12",
+		"0.00x",
+		"▓·········",
+		"true",
+	}, r.Fields)
+}
+
+func TestCapacityUsageBar(t *testing.T) {
+	uu := map[string]struct {
+		u render.CapacityUsage
+		e string
+	}{
+		"empty": {
+			e: "",
+		},
+		"free": {
+			u: render.CapacityUsage{Allocatable: 100},
+			e: "··········",
+		},
+		"full": {
+			u: render.CapacityUsage{Allocatable: 100, Requests: 100, Usage: 100},
+			e: "██████████",
+		},
+		"stacked": {
+			u: render.CapacityUsage{Allocatable: 100, Requests: 50, Limits: 80, Usage: 20},
+			e: "██▓▓▓░░░··",
+		},
+		"overcommit": {
+			u: render.CapacityUsage{Allocatable: 100, Requests: 30, Limits: 150},
+			e: "▓▓▓░░░░░░░+",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, u.u.Bar(10))
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package render
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/derailed/tview"
+	"github.com/gdamore/tcell/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Cert renders certificates to screen.
+type Cert struct {
+	Base
+}
+
+// ColorerFunc colors a resource row.
+func (Cert) ColorerFunc() ColorerFunc {
+	return func(ns string, h Header, re RowEvent) tcell.Color {
+		idx := h.IndexOf("STATUS", true)
+		if idx < 0 || idx >= len(re.Row.Fields) {
+			return DefaultColorer(ns, h, re)
+		}
+		switch re.Row.Fields[idx] {
+		case "EXPIRED", "CRITICAL":
+			return ErrColor
+		case "WARN":
+			return PendingColor
+		}
+
+		return StdColor
+	}
+}
+
+// Header returns a header row.
+func (Cert) Header(_ string) Header {
+	return Header{
+		HeaderColumn{Name: "NAMESPACE"},
+		HeaderColumn{Name: "NAME"},
+		HeaderColumn{Name: "KIND"},
+		HeaderColumn{Name: "KEY"},
+		HeaderColumn{Name: "SUBJECT"},
+		HeaderColumn{Name: "ISSUER"},
+		HeaderColumn{Name: "SANS", Wide: true},
+		HeaderColumn{Name: "KEY-MATCH", Wide: true},
+		HeaderColumn{Name: "EXPIRES"},
+		HeaderColumn{Name: "DAYS", Align: tview.AlignRight},
+		HeaderColumn{Name: "STATUS"},
+	}
+}
+
+// Render renders a certificate to screen.
+func (Cert) Render(o interface{}, _ string, r *Row) error {
+	c, ok := o.(CertRes)
+	if !ok {
+		return fmt.Errorf("expected CertRes, but got %T", o)
+	}
+
+	key := c.Key
+	if c.Index > 0 {
+		key += "#" + strconv.Itoa(c.Index)
+	}
+	r.ID = c.ID
+	r.Fields = Fields{
+		c.Namespace,
+		c.Name,
+		c.Kind,
+		key,
+		c.Subject,
+		c.Issuer,
+		strings.Join(c.SANs, ","),
+		c.KeyMatch,
+		c.NotAfter,
+		strconv.Itoa(c.Days),
+		c.Status,
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+// CertRes represents a certificate resource.
+type CertRes struct {
+	ID, Kind, Namespace, Name, Key string
+	Index                          int
+	Subject, Issuer                string
+	SANs                           []string
+	KeyMatch                       string
+	NotAfter                       string
+	Days                           int
+	Status                         string
+}
+
+// GetObjectKind returns a schema object.
+func (CertRes) GetObjectKind() schema.ObjectKind {
+	return nil
+}
+
+// DeepCopyObject returns a container copy.
+func (c CertRes) DeepCopyObject() runtime.Object {
+	return c
+}
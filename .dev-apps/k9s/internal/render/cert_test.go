@@ -0,0 +1,67 @@
+package render_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/render"
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCertRender(t *testing.T) {
+	var c render.Cert
+
+	res := render.CertRes{
+		ID:        "secret:default/fred:tls.crt#1",
+		Kind:      "secret",
+		Namespace: "default",
+		Name:      "fred",
+		Key:       "tls.crt",
+		Index:     1,
+		Subject:   "ca",
+		Issuer:    "ca",
+		SANs:      []string{"fred.example.com", "10.0.0.1"},
+		KeyMatch:  "n/a",
+		NotAfter:  "2024-01-10 00:00",
+		Days:      9,
+		Status:    "CRITICAL",
+	}
+	var r render.Row
+	assert.Nil(t, c.Render(res, "", &r))
+	assert.Equal(t, "secret:default/fred:tls.crt#1", r.ID)
+	assert.Equal(t, render.Fields{
+		"default",
+		"fred",
+		"secret",
+		"tls.crt#1",
+		"ca",
+		"ca",
+		"fred.example.com,10.0.0.1",
+		"n/a",
+		"2024-01-10 00:00",
+		"9",
+		"CRITICAL",
+	}, r.Fields)
+}
+
+func TestCertColorer(t *testing.T) {
+	h := render.Cert{}.Header("")
+
+	uu := map[string]struct {
+		status string
+		e      tcell.Color
+	}{
+		"ok":       {status: "OK", e: render.StdColor},
+		"warn":     {status: "WARN", e: render.PendingColor},
+		"critical": {status: "CRITICAL", e: render.ErrColor},
+		"expired":  {status: "EXPIRED", e: render.ErrColor},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			re := render.RowEvent{Row: render.Row{Fields: render.Fields{"", "", "", "", "", "", "", "", "", "", u.status}}}
+			assert.Equal(t, u.e, render.Cert{}.ColorerFunc()("", h, re))
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/gdamore/tcell/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Consumer renders configmap or secret consuming pods to screen.
+type Consumer struct {
+	Base
+}
+
+// ColorerFunc colors a resource row.
+func (Consumer) ColorerFunc() ColorerFunc {
+	return func(ns string, h Header, re RowEvent) tcell.Color {
+		idx := h.IndexOf("STALE", true)
+		if idx >= 0 && idx < len(re.Row.Fields) && re.Row.Fields[idx] == "true" {
+			return ErrColor
+		}
+
+		return StdColor
+	}
+}
+
+// Header returns a header row.
+func (Consumer) Header(_ string) Header {
+	return Header{
+		HeaderColumn{Name: "NAMESPACE"},
+		HeaderColumn{Name: "NAME"},
+		HeaderColumn{Name: "USAGE"},
+		HeaderColumn{Name: "OWNER"},
+		HeaderColumn{Name: "STALE"},
+		HeaderColumn{Name: "AGE", Time: true},
+	}
+}
+
+// Render renders a consumer to screen.
+func (Consumer) Render(o interface{}, _ string, r *Row) error {
+	c, ok := o.(ConsumerRes)
+	if !ok {
+		return fmt.Errorf("expected ConsumerRes, but got %T", o)
+	}
+
+	owner := NAValue
+	if c.OwnerKind != "" {
+		owner = strings.ToLower(c.OwnerKind) + "/" + c.OwnerName
+	}
+	age := NAValue
+	if !c.Started.IsZero() {
+		age = toAge(metav1.Time{Time: c.Started})
+	}
+	r.ID = client.FQN(c.Namespace, c.Name)
+	r.Fields = Fields{
+		c.Namespace,
+		c.Name,
+		strings.Join(c.Usages, ","),
+		owner,
+		boolToStr(c.Stale),
+		age,
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+// ConsumerRes represents a pod consuming a configmap or secret.
+type ConsumerRes struct {
+	Namespace, Name                string
+	Usages                         []string
+	Started                        time.Time
+	Stale                          bool
+	OwnerKind, OwnerName, OwnerGVR string
+}
+
+// GetObjectKind returns a schema object.
+func (ConsumerRes) GetObjectKind() schema.ObjectKind {
+	return nil
+}
+
+// DeepCopyObject returns a container copy.
+func (c ConsumerRes) DeepCopyObject() runtime.Object {
+	return c
+}
@@ -0,0 +1,48 @@
+package render_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/render"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsumerRender(t *testing.T) {
+	uu := map[string]struct {
+		res render.ConsumerRes
+		e   render.Fields
+	}{
+		"stale": {
+			res: render.ConsumerRes{
+				Namespace: "default",
+				Name:      "p1",
+				Usages:    []string{"env", "volume"},
+				Started:   time.Now().Add(-time.Hour),
+				Stale:     true,
+				OwnerKind: "Deployment",
+				OwnerName: "fred",
+				OwnerGVR:  "apps/v1/deployments",
+			},
+			e: render.Fields{"default", "p1", "env,volume", "deployment/fred", "true", "60m"},
+		},
+		"pending": {
+			res: render.ConsumerRes{
+				Namespace: "default",
+				Name:      "p2",
+				Usages:    []string{"projected"},
+			},
+			e: render.Fields{"default", "p2", "projected", "n/a", "false", "n/a"},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			var r render.Row
+			assert.Nil(t, render.Consumer{}.Render(u.res, "", &r))
+			assert.Equal(t, "default/"+u.res.Name, r.ID)
+			assert.Equal(t, u.e, r.Fields)
+		})
+	}
+}
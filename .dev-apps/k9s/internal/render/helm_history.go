@@ -0,0 +1,80 @@
+package render
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/derailed/tview"
+	"github.com/gdamore/tcell/v2"
+	"helm.sh/helm/v3/pkg/release"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HelmHistory renders a helm release revisions to screen.
+type HelmHistory struct {
+	Base
+}
+
+// ColorerFunc colors a resource row.
+func (HelmHistory) ColorerFunc() ColorerFunc {
+	return func(ns string, h Header, re RowEvent) tcell.Color {
+		idx := h.IndexOf("STATUS", true)
+		if idx < 0 || idx >= len(re.Row.Fields) {
+			return DefaultColorer(ns, h, re)
+		}
+		switch release.Status(re.Row.Fields[idx]) {
+		case release.StatusDeployed:
+			return HighlightColor
+		case release.StatusFailed:
+			return ErrColor
+		case release.StatusPendingInstall, release.StatusPendingUpgrade, release.StatusPendingRollback:
+			return PendingColor
+		}
+
+		return DefaultColorer(ns, h, re)
+	}
+}
+
+// Header returns a header row.
+func (HelmHistory) Header(_ string) Header {
+	return Header{
+		HeaderColumn{Name: "REVISION", Align: tview.AlignRight},
+		HeaderColumn{Name: "STATUS"},
+		HeaderColumn{Name: "CHART"},
+		HeaderColumn{Name: "APP VERSION"},
+		HeaderColumn{Name: "DESCRIPTION"},
+		HeaderColumn{Name: "AGE", Time: true},
+	}
+}
+
+// Render renders a release revision to screen.
+func (HelmHistory) Render(o interface{}, _ string, r *Row) error {
+	h, ok := o.(HelmRes)
+	if !ok {
+		return fmt.Errorf("expected HelmRes, but got %T", o)
+	}
+
+	var status, desc string
+	var deployed metav1.Time
+	if h.Release.Info != nil {
+		status, desc = h.Release.Info.Status.String(), h.Release.Info.Description
+		deployed = metav1.Time{Time: h.Release.Info.LastDeployed.Time}
+	}
+	var chart, appVersion string
+	if h.Release.Chart != nil && h.Release.Chart.Metadata != nil {
+		chart = h.Release.Chart.Metadata.Name + "-" + h.Release.Chart.Metadata.Version
+		appVersion = h.Release.Chart.Metadata.AppVersion
+	}
+
+	r.ID = strconv.Itoa(h.Release.Version)
+	r.Fields = Fields{
+		r.ID,
+		status,
+		chart,
+		appVersion,
+		desc,
+		toAge(deployed),
+	}
+
+	return nil
+}
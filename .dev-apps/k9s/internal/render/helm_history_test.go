@@ -0,0 +1,37 @@
+package render_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/render"
+	"github.com/stretchr/testify/assert"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
+	helmtime "helm.sh/helm/v3/pkg/time"
+)
+
+func TestHelmHistoryRender(t *testing.T) {
+	var h render.HelmHistory
+
+	res := render.HelmRes{Release: &release.Release{
+		Name:    "fred",
+		Version: 3,
+		Info: &release.Info{
+			Status:       release.StatusDeployed,
+			Description:  "Upgrade complete",
+			LastDeployed: helmtime.Time{Time: time.Now()},
+		},
+		Chart: &chart.Chart{Metadata: &chart.Metadata{Name: "fred", Version: "1.2.0", AppVersion: "2.0"}},
+	}}
+	var r render.Row
+	assert.Nil(t, h.Render(res, "", &r))
+	assert.Equal(t, "3", r.ID)
+	assert.Equal(t, render.Fields{
+		"3",
+		"deployed",
+		"fred-1.2.0",
+		"2.0",
+		"Upgrade complete",
+	}, r.Fields[:len(r.Fields)-1])
+}
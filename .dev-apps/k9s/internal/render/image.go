@@ -0,0 +1,173 @@
+package render
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/tview"
+	"github.com/gdamore/tcell/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const shortDigestLen = 19
+
+// Image renders a cluster container images inventory to screen.
+type Image struct {
+	Base
+}
+
+// ColorerFunc colors a resource row.
+func (Image) ColorerFunc() ColorerFunc {
+	return func(ns string, h Header, re RowEvent) tcell.Color {
+		if idx := h.IndexOf("DRIFT", true); idx >= 0 && idx < len(re.Row.Fields) && re.Row.Fields[idx] != "" {
+			return ErrColor
+		}
+		if idx := h.IndexOf("LATEST", true); idx >= 0 && idx < len(re.Row.Fields) && re.Row.Fields[idx] == "true" {
+			return PendingColor
+		}
+
+		return DefaultColorer(ns, h, re)
+	}
+}
+
+// Header returns a header row.
+func (Image) Header(ns string) Header {
+	return Header{
+		HeaderColumn{Name: "IMAGE"},
+		HeaderColumn{Name: "TAG"},
+		HeaderColumn{Name: "DIGEST"},
+		HeaderColumn{Name: "NAMESPACES"},
+		HeaderColumn{Name: "WORKLOADS", Align: tview.AlignRight},
+		HeaderColumn{Name: "PULL-POLICY"},
+		HeaderColumn{Name: "LATEST"},
+		HeaderColumn{Name: "DRIFT"},
+		HeaderColumn{Name: "CONSUMERS", Wide: true},
+	}
+}
+
+// Render renders a K8s resource to screen.
+func (Image) Render(o interface{}, ns string, r *Row) error {
+	img, ok := o.(ImageRes)
+	if !ok {
+		return fmt.Errorf("expecting ImageRes, but got %T", o)
+	}
+
+	cc := make([]string, 0, len(img.Consumers))
+	for _, c := range img.Consumers {
+		cc = append(cc, client.FQN(c.Namespace, c.Name))
+	}
+	r.ID = img.Image
+	r.Fields = Fields{
+		img.Repo,
+		img.Tag,
+		digestSummary(img.Digests),
+		strings.Join(img.Namespaces, ","),
+		strconv.Itoa(len(img.Consumers)),
+		strings.Join(img.PullPolicies, ","),
+		boolToStr(img.Latest),
+		strings.Join(img.Drift, ","),
+		strings.Join(cc, ","),
+	}
+
+	return nil
+}
+
+// ImageConsumer renders the workloads consuming a given container image to screen.
+type ImageConsumer struct {
+	Base
+}
+
+// Header returns a header row.
+func (ImageConsumer) Header(ns string) Header {
+	return Header{
+		HeaderColumn{Name: "NAMESPACE"},
+		HeaderColumn{Name: "NAME"},
+		HeaderColumn{Name: "GVR"},
+		HeaderColumn{Name: "CONTAINERS"},
+		HeaderColumn{Name: "PULL-POLICY"},
+		HeaderColumn{Name: "DIGEST"},
+	}
+}
+
+// Render renders a K8s resource to screen.
+func (ImageConsumer) Render(o interface{}, ns string, r *Row) error {
+	c, ok := o.(ImageConsumerRes)
+	if !ok {
+		return fmt.Errorf("expecting ImageConsumerRes, but got %T", o)
+	}
+
+	r.ID = c.GVR + ":" + client.FQN(c.Namespace, c.Name)
+	r.Fields = Fields{
+		c.Namespace,
+		c.Name,
+		c.GVR,
+		strings.Join(c.Containers, ","),
+		c.PullPolicy,
+		digestSummary(c.Digests),
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func digestSummary(dd []string) string {
+	switch len(dd) {
+	case 0:
+		return ""
+	case 1:
+		if len(dd[0]) > shortDigestLen {
+			return dd[0][:shortDigestLen]
+		}
+		return dd[0]
+	default:
+		return fmt.Sprintf("%d digests", len(dd))
+	}
+}
+
+// ImageRes represents a container image in use in the cluster.
+type ImageRes struct {
+	Image        string
+	Repo         string
+	Tag          string
+	Digests      []string
+	Namespaces   []string
+	PullPolicies []string
+	Latest       bool
+	Drift        []string
+	Consumers    []ImageConsumerRes
+}
+
+// GetObjectKind returns a schema object.
+func (ImageRes) GetObjectKind() schema.ObjectKind {
+	return nil
+}
+
+// DeepCopyObject returns a container copy.
+func (i ImageRes) DeepCopyObject() runtime.Object {
+	return i
+}
+
+// ImageConsumerRes represents a workload using a container image.
+type ImageConsumerRes struct {
+	GVR        string
+	Namespace  string
+	Name       string
+	Containers []string
+	PullPolicy string
+	Digests    []string
+}
+
+// GetObjectKind returns a schema object.
+func (ImageConsumerRes) GetObjectKind() schema.ObjectKind {
+	return nil
+}
+
+// DeepCopyObject returns a container copy.
+func (i ImageConsumerRes) DeepCopyObject() runtime.Object {
+	return i
+}
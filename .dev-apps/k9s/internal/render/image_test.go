@@ -0,0 +1,65 @@
+package render_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/render"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageRender(t *testing.T) {
+	var img render.Image
+
+	res := render.ImageRes{
+		Image:        "fred:latest",
+		Repo:         "fred",
+		Tag:          "latest",
+		Digests:      []string{"sha256:aaaaaaaaaaaaaaaaaaaaaaaa", "sha256:bbbbbbbbbbbbbbbbbbbbbbbb"},
+		Namespaces:   []string{"blee", "zorg"},
+		PullPolicies: []string{"Always"},
+		Latest:       true,
+		Drift:        []string{"digest"},
+		Consumers: []render.ImageConsumerRes{
+			{GVR: "apps/v1/deployments", Namespace: "blee", Name: "fred"},
+			{GVR: "v1/pods", Namespace: "zorg", Name: "duh"},
+		},
+	}
+	var r render.Row
+	assert.Nil(t, img.Render(res, "", &r))
+	assert.Equal(t, "fred:latest", r.ID)
+	assert.Equal(t, render.Fields{
+		"fred",
+		"latest",
+		"2 digests",
+		"blee,zorg",
+		"2",
+		"Always",
+		"true",
+		"digest",
+		"blee/fred,zorg/duh",
+	}, r.Fields)
+}
+
+func TestImageConsumerRender(t *testing.T) {
+	var c render.ImageConsumer
+
+	res := render.ImageConsumerRes{
+		GVR:        "apps/v1/deployments",
+		Namespace:  "blee",
+		Name:       "fred",
+		Containers: []string{"c1", "c2"},
+		PullPolicy: "IfNotPresent",
+		Digests:    []string{"sha256:0123456789abcdef0123"},
+	}
+	var r render.Row
+	assert.Nil(t, c.Render(res, "", &r))
+	assert.Equal(t, "apps/v1/deployments:blee/fred", r.ID)
+	assert.Equal(t, render.Fields{
+		"blee",
+		"fred",
+		"apps/v1/deployments",
+		"c1,c2",
+		"IfNotPresent",
+		"sha256:0123456789ab",
+	}, r.Fields)
+}
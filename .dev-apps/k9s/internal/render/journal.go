@@ -0,0 +1,85 @@
+package render
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Journal renders undo journal entries to screen.
+type Journal struct {
+	Base
+}
+
+// ColorerFunc colors a resource row.
+func (Journal) ColorerFunc() ColorerFunc {
+	return func(ns string, h Header, re RowEvent) tcell.Color {
+		idx := h.IndexOf("ACTION", true)
+		if idx >= 0 && idx < len(re.Row.Fields) && re.Row.Fields[idx] == "delete" {
+			return KillColor
+		}
+
+		return tcell.ColorNavajoWhite
+	}
+}
+
+// Header returns a header row.
+func (Journal) Header(ns string) Header {
+	return Header{
+		HeaderColumn{Name: "NAMESPACE"},
+		HeaderColumn{Name: "NAME"},
+		HeaderColumn{Name: "ACTION"},
+		HeaderColumn{Name: "KIND"},
+		HeaderColumn{Name: "USER"},
+		HeaderColumn{Name: "CONTEXT", Wide: true},
+		HeaderColumn{Name: "GVR", Wide: true},
+		HeaderColumn{Name: "AGE", Time: true},
+	}
+}
+
+// Render renders a K8s resource to screen.
+func (Journal) Render(o interface{}, ns string, r *Row) error {
+	j, ok := o.(JournalRes)
+	if !ok {
+		return fmt.Errorf("expecting JournalRes, but got %T", o)
+	}
+
+	r.ID = j.Path
+	r.Fields = Fields{
+		j.Namespace,
+		j.Name,
+		j.Action,
+		j.Kind,
+		j.User,
+		j.Context,
+		j.GVR,
+		toAge(metav1.Time{Time: j.Timestamp}),
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+// JournalRes represents an undo journal entry resource.
+type JournalRes struct {
+	Path, Action, GVR, Kind string
+	Namespace, Name         string
+	Context, User           string
+	Timestamp               time.Time
+}
+
+// GetObjectKind returns a schema object.
+func (JournalRes) GetObjectKind() schema.ObjectKind {
+	return nil
+}
+
+// DeepCopyObject returns a container copy.
+func (j JournalRes) DeepCopyObject() runtime.Object {
+	return j
+}
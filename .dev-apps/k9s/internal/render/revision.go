@@ -0,0 +1,96 @@
+package render
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/derailed/tview"
+	"github.com/gdamore/tcell/v2"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Revision renders a workload rollout revisions to screen.
+type Revision struct {
+	Base
+}
+
+// ColorerFunc colors a resource row.
+func (Revision) ColorerFunc() ColorerFunc {
+	return func(ns string, h Header, re RowEvent) tcell.Color {
+		idx := h.IndexOf("CURRENT", true)
+		if idx >= 0 && idx < len(re.Row.Fields) && re.Row.Fields[idx] == "true" {
+			return HighlightColor
+		}
+
+		return DefaultColorer(ns, h, re)
+	}
+}
+
+// Header returns a header row.
+func (Revision) Header(ns string) Header {
+	return Header{
+		HeaderColumn{Name: "REVISION", Align: tview.AlignRight},
+		HeaderColumn{Name: "NAME"},
+		HeaderColumn{Name: "CURRENT"},
+		HeaderColumn{Name: "READY", Align: tview.AlignRight},
+		HeaderColumn{Name: "CHANGE-CAUSE"},
+		HeaderColumn{Name: "IMAGES", Wide: true},
+		HeaderColumn{Name: "AGE", Time: true},
+	}
+}
+
+// Render renders a K8s resource to screen.
+func (Revision) Render(o interface{}, ns string, r *Row) error {
+	rev, ok := o.(RevisionRes)
+	if !ok {
+		return fmt.Errorf("expecting RevisionRes, but got %T", o)
+	}
+
+	r.ID = strconv.FormatInt(rev.Revision, 10)
+	r.Fields = Fields{
+		r.ID,
+		rev.Name,
+		boolToStr(rev.Current),
+		rev.Ready,
+		rev.ChangeCause,
+		revisionImages(rev.Template),
+		toAge(rev.Timestamp),
+	}
+
+	return nil
+}
+
+func revisionImages(tpl *v1.PodTemplateSpec) string {
+	if tpl == nil {
+		return ""
+	}
+
+	return podImageNames(tpl.Spec, false)
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+// RevisionRes represents a workload rollout revision.
+type RevisionRes struct {
+	Revision    int64
+	Name        string
+	ChangeCause string
+	Ready       string
+	Current     bool
+	Template    *v1.PodTemplateSpec
+	Timestamp   metav1.Time
+}
+
+// GetObjectKind returns a schema object.
+func (RevisionRes) GetObjectKind() schema.ObjectKind {
+	return nil
+}
+
+// DeepCopyObject returns a container copy.
+func (r RevisionRes) DeepCopyObject() runtime.Object {
+	return r
+}
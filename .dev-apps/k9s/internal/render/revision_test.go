@@ -0,0 +1,41 @@
+package render_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/render"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestRevisionRender(t *testing.T) {
+	var rev render.Revision
+
+	res := render.RevisionRes{
+		Revision:    3,
+		Name:        "fred-5d8f7b9c4",
+		ChangeCause: "kubectl set image deploy/fred fred=fred:2",
+		Ready:       "2/2",
+		Current:     true,
+		Template: &v1.PodTemplateSpec{
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{
+					{Name: "fred", Image: "fred:2"},
+					{Name: "blee", Image: "blee:1"},
+				},
+			},
+		},
+		Timestamp: makeAge(),
+	}
+	var r render.Row
+	assert.Nil(t, rev.Render(res, "", &r))
+	assert.Equal(t, "3", r.ID)
+	assert.Equal(t, render.Fields{
+		"3",
+		"fred-5d8f7b9c4",
+		"true",
+		"2/2",
+		"kubectl set image deploy/fred fred=fred:2",
+		"fred:2,blee:1",
+	}, r.Fields[:len(r.Fields)-1])
+}
@@ -0,0 +1,148 @@
+package render
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/tview"
+	"github.com/gdamore/tcell/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// RightsizeOK flags a container with adequate requests and limits.
+	RightsizeOK = "ok"
+	// RightsizeOver flags an over-provisioned container.
+	RightsizeOver = "over-provisioned"
+	// RightsizeRisk flags a container at risk of throttling or eviction.
+	RightsizeRisk = "at-risk"
+	// RightsizeUnset flags a container without requests.
+	RightsizeUnset = "unset"
+	// RightsizeSampling flags a container without enough usage samples.
+	RightsizeSampling = "sampling"
+)
+
+// Rightsizing renders containers requests/limits recommendations to screen.
+type Rightsizing struct {
+	Base
+}
+
+// ColorerFunc colors a resource row.
+func (Rightsizing) ColorerFunc() ColorerFunc {
+	return func(ns string, h Header, re RowEvent) tcell.Color {
+		idx := h.IndexOf("STATUS", true)
+		if idx < 0 || idx >= len(re.Row.Fields) {
+			return DefaultColorer(ns, h, re)
+		}
+		switch re.Row.Fields[idx] {
+		case RightsizeRisk:
+			return ErrColor
+		case RightsizeOver:
+			return PendingColor
+		case RightsizeUnset:
+			return ModColor
+		case RightsizeSampling:
+			return CompletedColor
+		default:
+			return DefaultColorer(ns, h, re)
+		}
+	}
+}
+
+// Header returns a header row.
+func (Rightsizing) Header(ns string) Header {
+	return Header{
+		HeaderColumn{Name: "NAMESPACE"},
+		HeaderColumn{Name: "NAME"},
+		HeaderColumn{Name: "GVR", Wide: true},
+		HeaderColumn{Name: "CONTAINER"},
+		HeaderColumn{Name: "SAMPLES", Align: tview.AlignRight},
+		HeaderColumn{Name: "CPU(P95:MAX)", Align: tview.AlignRight, MX: true},
+		HeaderColumn{Name: "CPU/R:L", Align: tview.AlignRight},
+		HeaderColumn{Name: "SUGGEST-CPU/R:L", Align: tview.AlignRight},
+		HeaderColumn{Name: "MEM(P95:MAX)", Align: tview.AlignRight, MX: true},
+		HeaderColumn{Name: "MEM/R:L", Align: tview.AlignRight},
+		HeaderColumn{Name: "SUGGEST-MEM/R:L", Align: tview.AlignRight},
+		HeaderColumn{Name: "STATUS"},
+		HeaderColumn{Name: "REASON", Wide: true},
+	}
+}
+
+// Render renders a K8s resource to screen.
+func (Rightsizing) Render(o interface{}, ns string, r *Row) error {
+	res, ok := o.(RightsizingRes)
+	if !ok {
+		return fmt.Errorf("expecting RightsizingRes, but got %T", o)
+	}
+
+	r.ID = res.ID()
+	r.Fields = Fields{
+		res.Namespace,
+		res.Name,
+		res.GVR,
+		res.Container,
+		strconv.Itoa(res.Samples),
+		toMc(res.CPU.P95) + ":" + toMc(res.CPU.Max),
+		rightsizeRL(res.CPU.Request, res.CPU.Limit, toMc),
+		rightsizeRL(res.CPU.SuggestRequest, res.CPU.SuggestLimit, toMc),
+		toMi(res.Mem.P95) + ":" + toMi(res.Mem.Max),
+		rightsizeRL(res.Mem.Request, res.Mem.Limit, toMi),
+		rightsizeRL(res.Mem.SuggestRequest, res.Mem.SuggestLimit, toMi),
+		res.Status,
+		res.Reason,
+	}
+
+	return nil
+}
+
+func rightsizeRL(req, lim int64, f func(int64) string) string {
+	fmat := func(v int64) string {
+		if v == 0 {
+			return NAValue
+		}
+		return f(v)
+	}
+
+	return fmat(req) + ":" + fmat(lim)
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+// RightsizingUsage represents a resource usage and its recommended settings.
+// CPU values are expressed in millicores and memory values in bytes.
+type RightsizingUsage struct {
+	P95, Max                     int64
+	Request, Limit               int64
+	SuggestRequest, SuggestLimit int64
+}
+
+// RightsizingRes represents a container requests/limits recommendation.
+type RightsizingRes struct {
+	GVR       string
+	Namespace string
+	Name      string
+	Container string
+	Samples   int
+	CPU       RightsizingUsage
+	Mem       RightsizingUsage
+	Status    string
+	Reason    string
+}
+
+// ID returns the recommendation identifier.
+func (r RightsizingRes) ID() string {
+	return r.GVR + ":" + client.FQN(r.Namespace, r.Name) + ":" + r.Container
+}
+
+// GetObjectKind returns a schema object.
+func (RightsizingRes) GetObjectKind() schema.ObjectKind {
+	return nil
+}
+
+// DeepCopyObject returns a container copy.
+func (r RightsizingRes) DeepCopyObject() runtime.Object {
+	return r
+}
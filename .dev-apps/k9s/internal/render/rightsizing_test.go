@@ -0,0 +1,48 @@
+package render_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/render"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRightsizingRender(t *testing.T) {
+	var rs render.Rightsizing
+
+	res := render.RightsizingRes{
+		GVR:       "apps/v1/deployments",
+		Namespace: "blee",
+		Name:      "fred",
+		Container: "c1",
+		Samples:   10,
+		CPU: render.RightsizingUsage{
+			P95: 20, Max: 30, Request: 500, SuggestRequest: 24,
+		},
+		Mem: render.RightsizingUsage{
+			P95: 40 * 1024 * 1024, Max: 50 * 1024 * 1024,
+			Request: 512 * 1024 * 1024, Limit: 1024 * 1024 * 1024,
+			SuggestRequest: 48 * 1024 * 1024, SuggestLimit: 60 * 1024 * 1024,
+		},
+		Status: render.RightsizeOver,
+		Reason: "cpu request over usage",
+	}
+	var r render.Row
+	assert.Nil(t, rs.Render(res, "", &r))
+	assert.Equal(t, "apps/v1/deployments:blee/fred:c1", r.ID)
+	assert.Equal(t, render.Fields{
+		"blee",
+		"fred",
+		"apps/v1/deployments",
+		"c1",
+		"10",
+		"20:30",
+		"500:n/a",
+		"24:n/a",
+		"40:50",
+		"512:1024",
+		"48:60",
+		"over-provisioned",
+		"cpu request over usage",
+	}, r.Fields)
+}
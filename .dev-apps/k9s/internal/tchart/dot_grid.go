@@ -0,0 +1,107 @@
+package tchart
+
+import (
+	"fmt"
+
+	"github.com/derailed/tview"
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	dotGridDot = '●'
+	warnColor  = tcell.ColorOrange
+)
+
+// DotGrid represents a dot matrix chart, one dot per resource.
+type DotGrid struct {
+	*Component
+
+	data Metric
+}
+
+// NewDotGrid returns a new dot grid.
+func NewDotGrid(id string) *DotGrid {
+	return &DotGrid{
+		Component: NewComponent(id),
+	}
+}
+
+// Add adds a new metric.
+func (d *DotGrid) Add(m Metric) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.data = m
+}
+
+// Draw draws the primitive.
+func (d *DotGrid) Draw(sc tcell.Screen) {
+	d.Component.Draw(sc)
+
+	d.mx.RLock()
+	defer d.mx.RUnlock()
+
+	rect := d.asRect()
+	pad := 0
+	if d.legend != "" {
+		pad++
+	}
+	cols, rows := rect.Dx()/2, rect.Dy()-pad
+	if cols <= 0 || rows <= 0 {
+		return
+	}
+
+	okC, faultC := d.colorForSeries()
+	var (
+		style = tcell.StyleDefault.Background(d.bgColor)
+		i     int
+	)
+	ok, warn, fault := toDots(d.data, cols*rows)
+	for _, s := range []struct {
+		n int
+		c tcell.Color
+	}{{ok, okC}, {warn, warnColor}, {fault, faultC}} {
+		for n := 0; n < s.n; n++ {
+			sc.SetContent(rect.Min.X+1+(i%cols)*2, rect.Min.Y+i/cols, dotGridDot, nil, style.Foreground(s.c))
+			i++
+		}
+	}
+
+	if d.legend != "" {
+		legend := d.legend
+		if d.HasFocus() {
+			legend = fmt.Sprintf("[%s:%s:]", d.focusFgColor, d.focusBgColor) + d.legend + "[::]"
+		}
+		tview.Print(sc, legend, rect.Min.X, rect.Max.Y-1, rect.Dx(), tview.AlignCenter, tcell.ColorWhite)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+// toDots computes the number of healthy, warning and faulty dots fitting in a
+// given number of cells. Each non empty series gets at least one dot.
+func toDots(m Metric, cells int) (int, int, int) {
+	total := m.S1 + m.S2 + m.S3
+	if total == 0 || cells <= 0 {
+		return 0, 0, 0
+	}
+	scale := int64(1)
+	if total > int64(cells) {
+		scale = (total + int64(cells) - 1) / int64(cells)
+	}
+	warn, fault := ceilDiv(m.S3, scale), ceilDiv(m.S2, scale)
+	ok := cells - warn - fault
+	if s1 := ceilDiv(m.S1, scale); s1 < ok {
+		ok = s1
+	}
+	if ok < 0 {
+		ok = 0
+	}
+
+	return ok, warn, fault
+}
+
+func ceilDiv(n, d int64) int {
+	return int((n + d - 1) / d)
+}
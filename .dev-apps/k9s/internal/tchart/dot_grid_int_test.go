@@ -0,0 +1,47 @@
+package tchart
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToDots(t *testing.T) {
+	uu := map[string]struct {
+		m               Metric
+		cells           int
+		ok, warn, fault int
+	}{
+		"empty": {
+			cells: 10,
+		},
+		"no-cells": {
+			m: Metric{S1: 5},
+		},
+		"fits": {
+			m:     Metric{S1: 5, S2: 2, S3: 1},
+			cells: 10,
+			ok:    5, warn: 1, fault: 2,
+		},
+		"scaled": {
+			m:     Metric{S1: 18, S2: 1, S3: 1},
+			cells: 10,
+			ok:    8, warn: 1, fault: 1,
+		},
+		"faults-first": {
+			m:     Metric{S1: 1, S2: 100},
+			cells: 10,
+			ok:    0, fault: 10,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			ok, warn, fault := toDots(u.m, u.cells)
+			assert.Equal(t, u.ok, ok)
+			assert.Equal(t, u.warn, warn)
+			assert.Equal(t, u.fault, fault)
+		})
+	}
+}
@@ -20,9 +20,9 @@ type blocks struct {
 	s1, s2 block
 }
 
-// Metric tracks two series.
+// Metric tracks two series. S3 optionally tracks warnings.
 type Metric struct {
-	S1, S2 int64
+	S1, S2, S3 int64
 }
 
 // MaxDigits returns the max series number of digits.
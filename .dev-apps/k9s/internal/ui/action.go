@@ -18,6 +18,7 @@ type (
 		Action      ActionHandler
 		Visible     bool
 		Shared      bool
+		Policy      string
 	}
 
 	// KeyActions tracks mappings between keystrokes and actions.
@@ -34,6 +35,11 @@ func NewSharedKeyAction(d string, a ActionHandler, display bool) KeyAction {
 	return KeyAction{Description: d, Action: a, Visible: display, Shared: true}
 }
 
+// NewDangerousKeyAction returns a new keyboard action subject to an action policy.
+func NewDangerousKeyAction(d string, a ActionHandler, display bool, policy string) KeyAction {
+	return KeyAction{Description: d, Action: a, Visible: display, Policy: policy}
+}
+
 // Add sets up keyboard action listener.
 func (a KeyActions) Add(aa KeyActions) {
 	for k, v := range aa {
@@ -62,6 +68,15 @@ func (a KeyActions) Delete(kk ...tcell.Key) {
 	}
 }
 
+// Guard removes policy bound actions that are not allowed.
+func (a KeyActions) Guard(allowed func(policy string) bool) {
+	for k, v := range a {
+		if v.Policy != "" && !allowed(v.Policy) {
+			delete(a, k)
+		}
+	}
+}
+
 // Hints returns a collection of hints.
 func (a KeyActions) Hints() model.MenuHints {
 	kk := make([]int, 0, len(a))
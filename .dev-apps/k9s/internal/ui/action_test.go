@@ -20,3 +20,19 @@ func TestKeyActionsHints(t *testing.T) {
 	assert.Equal(t, 3, len(hh))
 	assert.Equal(t, model.MenuHint{Mnemonic: "b", Description: "blee", Visible: true}, hh[0])
 }
+
+func TestKeyActionsGuard(t *testing.T) {
+	kk := ui.KeyActions{
+		ui.KeyF: ui.NewKeyAction("fred", nil, true),
+		ui.KeyB: ui.NewDangerousKeyAction("blee", nil, true, "shell"),
+		ui.KeyZ: ui.NewDangerousKeyAction("zorg", nil, true, "delete"),
+	}
+
+	kk.Guard(func(policy string) bool {
+		return policy != "delete"
+	})
+
+	assert.Equal(t, 2, len(kk))
+	_, ok := kk[ui.KeyZ]
+	assert.False(t, ok)
+}
@@ -1,9 +1,11 @@
 package view
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/derailed/k9s/internal/client"
 	"github.com/derailed/k9s/internal/config"
 	"github.com/derailed/k9s/internal/ui"
 	"github.com/derailed/k9s/internal/ui/dialog"
@@ -104,10 +106,11 @@ func pluginActions(r Runner, aa ui.KeyActions) {
 			log.Warn().Err(fmt.Errorf("Doh! you are trying to override an existing command `%s", k)).Msg("Invalid shortcut")
 			continue
 		}
-		aa[key] = ui.NewKeyAction(
+		aa[key] = ui.NewDangerousKeyAction(
 			plugin.Description,
 			pluginAction(r, plugin),
-			true)
+			true,
+			config.ActionPlugins)
 	}
 }
 
@@ -139,10 +142,17 @@ func pluginAction(r Runner, p config.Plugin) ui.ActionHandler {
 				pipes:      p.Pipes,
 				args:       args,
 			}
+			var gvr client.GVR
+			if g, ok := r.(interface{ GVR() client.GVR }); ok {
+				gvr = g.GVR()
+			}
+			details := p.Description + ": " + strings.TrimSpace(p.Command+" "+strings.Join(args, " "))
 			if run(r.App(), opts) {
+				auditAction(r.App(), auditPlugin, gvr, path, details, nil)
 				r.App().Flash().Info("Plugin command launched successfully!")
 				return
 			}
+			auditAction(r.App(), auditPlugin, gvr, path, details, errors.New("plugin command failed"))
 			r.App().Flash().Info("Plugin command failed!")
 		}
 		if p.Confirm {
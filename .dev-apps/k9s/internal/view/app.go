@@ -92,6 +92,7 @@ func (a *App) Init(version string, rate int) error {
 		return errors.New("No client connection detected")
 	}
 	ns := a.Config.ActiveNamespace()
+	configureUsageSampler(a.Config.K9s.Rightsizing)
 
 	a.factory = watch.NewFactory(a.Conn())
 	ok, err := a.isValidNS(ns)
@@ -276,6 +277,7 @@ func (a *App) Resume() {
 	ctx, a.cancelFn = context.WithCancel(context.Background())
 
 	go a.clusterUpdater(ctx)
+	recordTrends(ctx, a)
 	if err := a.StylesWatcher(ctx, a); err != nil {
 		log.Warn().Err(err).Msgf("Styles watcher failed")
 	}
@@ -408,6 +410,7 @@ func (a *App) switchContext(name string, loadPods bool) error {
 			log.Warn().Msg("No namespace specified in context. Using K9s config")
 		}
 		a.initFactory(ns)
+		resetUsageSampler()
 
 		if e := a.command.Reset(true); e != nil {
 			return e
@@ -0,0 +1,77 @@
+package view
+
+import (
+	"strings"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/rs/zerolog/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	auditDelete      = "delete"
+	auditKill        = "kill"
+	auditEdit        = "edit"
+	auditLabels      = "labels"
+	auditScale       = "scale"
+	auditRestart     = "restart"
+	auditRollback    = "rollback"
+	auditRecreate    = "recreate"
+	auditSetImage    = "set-image"
+	auditCordon      = "cordon"
+	auditUncordon    = "uncordon"
+	auditDrain       = "drain"
+	auditShell       = "shell"
+	auditAttach      = "attach"
+	auditPortForward = "port-forward"
+	auditPlugin      = "plugin"
+	auditApply       = "apply"
+	auditInstall     = "install"
+	auditUpgrade     = "upgrade"
+	auditTrigger     = "trigger"
+	auditSuspend     = "suspend"
+	auditPause       = "pause"
+	auditResume      = "resume"
+	auditRightsize   = "rightsize"
+	auditDebug       = "debug"
+	auditCopy        = "copy"
+)
+
+// auditAction records a mutating action outcome in the audit log.
+func auditAction(app *App, action string, gvr client.GVR, path, details string, err error) {
+	ns, n := client.Namespaced(path)
+	r := dao.NewAuditRecord(action, gvr.String(), ns, n, err)
+	r.Details = details
+	if app.Conn() != nil {
+		cfg := app.Conn().Config()
+		r.Context, _ = cfg.CurrentContextName()
+		r.Cluster, _ = cfg.CurrentClusterName()
+		r.User, _ = cfg.CurrentUserName()
+	}
+	if e := dao.WriteAudit(config.K9sAuditLog, app.Config.K9s.Audit, r); e != nil {
+		log.Error().Err(e).Msgf("Audit %s %s failed", action, path)
+	}
+}
+
+func deleteDetails(p *metav1.DeletionPropagation, force bool) string {
+	var s string
+	if p != nil {
+		s = "propagation=" + string(*p)
+	}
+	if force {
+		s = strings.TrimSpace(s + " force")
+	}
+
+	return s
+}
+
+func imageDetails(ii dao.ImageSpecs) string {
+	ss := make([]string, 0, len(ii))
+	for _, i := range ii {
+		ss = append(ss, i.Name+"="+i.DockerImage)
+	}
+
+	return strings.Join(ss, ",")
+}
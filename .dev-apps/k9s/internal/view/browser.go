@@ -20,7 +20,9 @@ import (
 	"github.com/derailed/k9s/internal/ui/dialog"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rs/zerolog/log"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // Browser represents a generic resource browser.
@@ -72,6 +74,7 @@ func (b *Browser) Init(ctx context.Context) error {
 	for _, f := range b.bindKeysFn {
 		f(b.Actions())
 	}
+	b.guardActions()
 	b.accessor, err = dao.AccessorFor(b.app.factory, b.GVR())
 	if err != nil {
 		return err
@@ -377,6 +380,11 @@ func (b *Browser) editCmd(evt *tcell.EventKey) *tcell.EventKey {
 		return nil
 	}
 
+	if err := journalResources(b.app, dao.JournalEdit, b.GVR(), path); err != nil {
+		b.App().Flash().Err(err)
+		return nil
+	}
+
 	b.Stop()
 	defer b.Start()
 	{
@@ -386,14 +394,68 @@ func (b *Browser) editCmd(evt *tcell.EventKey) *tcell.EventKey {
 		if ns != client.AllNamespaces {
 			args = append(args, "-n", ns)
 		}
+		var err error
 		if !runK(b.app, shellOpts{clear: true, args: args}) {
-			b.app.Flash().Err(errors.New("Edit exec failed"))
+			err = errors.New("Edit exec failed")
+			b.app.Flash().Err(err)
 		}
+		auditAction(b.app, auditEdit, b.GVR(), path, "", err)
 	}
 
 	return evt
 }
 
+func (b *Browser) metaEditCmd(evt *tcell.EventKey) *tcell.EventKey {
+	selections := b.GetSelectedItems()
+	if len(selections) == 0 {
+		return evt
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), b.app.Conn().Config().CallTimeout())
+	defer cancel()
+	o, err := b.accessor.Get(ctx, selections[0])
+	if err != nil {
+		b.app.Flash().Err(err)
+		return nil
+	}
+	m, err := meta.Accessor(o)
+	if err != nil {
+		b.app.Flash().Err(err)
+		return nil
+	}
+	ShowMetaEdit(b, selections, m, b.patchMeta)
+
+	return nil
+}
+
+func (b *Browser) patchMeta(v ResourceViewer, paths []string, edits dao.MetaEdits, pt types.PatchType) error {
+	patcher, ok := b.accessor.(dao.MetaPatcher)
+	if !ok {
+		return fmt.Errorf("expecting a meta patcher for %q", b.GVR())
+	}
+	if err := journalResources(b.app, dao.JournalEdit, b.GVR(), paths...); err != nil {
+		return err
+	}
+	for _, path := range paths {
+		ctx, cancel := context.WithTimeout(context.Background(), b.app.Conn().Config().CallTimeout())
+		err := patcher.PatchMeta(ctx, path, edits, pt)
+		cancel()
+		auditAction(b.app, auditLabels, b.GVR(), path, edits.Dump(), err)
+		if err != nil {
+			return fmt.Errorf("patch %s failed: %w", path, err)
+		}
+		b.GetTable().DeleteMark(path)
+	}
+	if len(paths) > 1 {
+		b.app.Flash().Infof("Labels/Annotations updated on %d marked %s", len(paths), b.GVR())
+	} else {
+		b.app.Flash().Infof("Labels/Annotations updated on %s %s", b.GVR(), paths[0])
+	}
+	b.refresh()
+
+	return nil
+}
+
 func (b *Browser) switchNamespaceCmd(evt *tcell.EventKey) *tcell.EventKey {
 	i, err := strconv.Atoi(string(evt.Rune()))
 	if err != nil {
@@ -477,10 +539,13 @@ func (b *Browser) refreshActions() {
 		b.namespaceActions(aa)
 		if !b.app.Config.K9s.IsReadOnly() {
 			if client.Can(b.meta.Verbs, "edit") {
-				aa[ui.KeyE] = ui.NewKeyAction("Edit", b.editCmd, true)
+				aa[ui.KeyE] = ui.NewDangerousKeyAction("Edit", b.editCmd, true, config.ActionEdit)
 			}
 			if client.Can(b.meta.Verbs, "delete") {
-				aa[tcell.KeyCtrlD] = ui.NewKeyAction("Delete", b.deleteCmd, true)
+				aa[tcell.KeyCtrlD] = ui.NewDangerousKeyAction("Delete", b.deleteCmd, true, config.ActionDelete)
+			}
+			if _, ok := b.accessor.(dao.MetaPatcher); ok && dao.IsK8sMeta(b.meta) && client.Can(b.meta.Verbs, "edit") {
+				aa[tcell.KeyCtrlT] = ui.NewDangerousKeyAction("Labels", b.metaEditCmd, true, config.ActionEdit)
 			}
 		}
 	}
@@ -496,9 +561,21 @@ func (b *Browser) refreshActions() {
 		f(aa)
 	}
 	b.Actions().Add(aa)
+	b.guardActions()
 	b.app.Menu().HydrateMenu(b.Hints())
 }
 
+// guardActions removes actions denied by the current context action policy.
+func (b *Browser) guardActions() {
+	ns := b.GetModel().GetNamespace()
+	if b.Path != "" {
+		ns, _ = client.Namespaced(b.Path)
+	}
+	b.Actions().Guard(func(policy string) bool {
+		return b.app.Config.IsActionAllowed(policy, ns)
+	})
+}
+
 func (b *Browser) namespaceActions(aa ui.KeyActions) {
 	if !b.meta.Namespaced || b.GetTable().Path != "" {
 		return
@@ -532,7 +609,9 @@ func (b *Browser) simpleDelete(selections []string, msg string) {
 				b.app.Flash().Errf("Invalid nuker %T", b.accessor)
 				continue
 			}
-			if err := nuker.Delete(context.Background(), sel, nil, false); err != nil {
+			err := nuker.Delete(context.Background(), sel, nil, false)
+			auditAction(b.app, auditDelete, b.GVR(), sel, "", err)
+			if err != nil {
 				b.app.Flash().Errf("Delete failed with `%s", err)
 			} else {
 				b.app.factory.DeleteForwarder(sel)
@@ -552,7 +631,13 @@ func (b *Browser) resourceDelete(selections []string, msg string) {
 			b.app.Flash().Infof("Delete resource %s %s", b.GVR(), selections[0])
 		}
 		for _, sel := range selections {
-			if err := b.GetModel().Delete(b.defaultContext(), sel, propagation, force); err != nil {
+			if err := journalResources(b.app, dao.JournalDelete, b.GVR(), sel); err != nil {
+				b.app.Flash().Err(err)
+				continue
+			}
+			err := b.GetModel().Delete(b.defaultContext(), sel, propagation, force)
+			auditAction(b.app, auditDelete, b.GVR(), sel, deleteDetails(propagation, force), err)
+			if err != nil {
 				b.app.Flash().Errf("Delete failed with `%s", err)
 			} else {
 				b.app.factory.DeleteForwarder(sel)
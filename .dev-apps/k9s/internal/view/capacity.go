@@ -0,0 +1,155 @@
+package view
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/tview"
+	"github.com/gdamore/tcell/v2"
+	v1 "k8s.io/api/core/v1"
+)
+
+const fitDialogKey = "fit"
+
+// Capacity presents nodes capacity and bin-packing.
+type Capacity struct {
+	ResourceViewer
+}
+
+// NewCapacity returns a new viewer.
+func NewCapacity(gvr client.GVR) ResourceViewer {
+	c := Capacity{
+		ResourceViewer: NewBrowser(gvr),
+	}
+	c.AddBindKeysFn(c.bindKeys)
+	c.GetTable().SetEnterFn(c.showNode)
+
+	return &c
+}
+
+func (c *Capacity) bindKeys(aa ui.KeyActions) {
+	aa.Add(ui.KeyActions{
+		ui.KeyF:      ui.NewKeyAction("Fit", c.fitCmd, true),
+		ui.KeyShiftC: ui.NewKeyAction("Sort %CPU/R", c.GetTable().SortColCmd("%CPU/R", false), false),
+		ui.KeyShiftM: ui.NewKeyAction("Sort %MEM/R", c.GetTable().SortColCmd("%MEM/R", false), false),
+	})
+}
+
+func (c *Capacity) showNode(app *App, _ ui.Tabular, _, path string) {
+	app.gotoResource("nodes", path, false)
+}
+
+func (c *Capacity) fitCmd(evt *tcell.EventKey) *tcell.EventKey {
+	c.Stop()
+	defer c.Start()
+	c.showFitDialog()
+
+	return nil
+}
+
+func (c *Capacity) showFitDialog() {
+	f := tview.NewForm()
+	f.SetItemPadding(0)
+	f.SetButtonsAlign(tview.AlignCenter).
+		SetButtonBackgroundColor(tview.Styles.PrimitiveBackgroundColor).
+		SetButtonTextColor(tview.Styles.PrimaryTextColor).
+		SetLabelColor(tcell.ColorAqua).
+		SetFieldTextColor(tcell.ColorOrange)
+
+	var workload string
+	replicas := "1"
+	f.AddInputField("Workload:", "", 40, nil, func(changed string) {
+		workload = changed
+	})
+	f.AddInputField("Replicas:", replicas, 4, func(textToCheck string, lastChar rune) bool {
+		_, err := strconv.Atoi(textToCheck)
+		return err == nil
+	}, func(changed string) {
+		replicas = changed
+	})
+	f.AddButton("OK", func() {
+		c.dismissFitDialog()
+		count, err := strconv.Atoi(replicas)
+		if err != nil {
+			c.App().Flash().Err(err)
+			return
+		}
+		c.fit(strings.TrimSpace(workload), count)
+	})
+	f.AddButton("Cancel", func() {
+		c.dismissFitDialog()
+	})
+
+	modal := tview.NewModalForm("<Fit>", f)
+	modal.SetText("Fit a workload (e.g. dp default/nginx) or a manifest file")
+	modal.SetDoneFunc(func(int, string) {
+		c.dismissFitDialog()
+	})
+	c.App().Content.AddPage(fitDialogKey, modal, false, false)
+	c.App().Content.ShowPage(fitDialogKey)
+}
+
+func (c *Capacity) dismissFitDialog() {
+	c.App().Content.RemovePage(fitDialogKey)
+}
+
+func (c *Capacity) fit(workload string, replicas int) {
+	spec, err := c.podSpecFor(workload)
+	if err != nil {
+		c.App().Flash().Err(err)
+		return
+	}
+
+	var acc dao.Capacity
+	acc.Init(c.App().factory, c.GVR())
+	ctx, cancel := context.WithTimeout(context.Background(), c.App().Conn().Config().CallTimeout())
+	defer cancel()
+	report, err := acc.Fit(ctx, spec, replicas)
+	if err != nil {
+		c.App().Flash().Err(err)
+		return
+	}
+
+	details := NewDetails(c.App(), "Fit", workload, true).Update(report.String())
+	if err := c.App().inject(details); err != nil {
+		c.App().Flash().Err(err)
+	}
+}
+
+func (c *Capacity) podSpecFor(workload string) (*v1.PodSpec, error) {
+	if workload == "" {
+		return nil, fmt.Errorf("a workload or manifest file is required")
+	}
+	tokens := strings.Fields(workload)
+	if len(tokens) == 1 {
+		raw, err := os.ReadFile(tokens[0])
+		if err != nil {
+			return nil, err
+		}
+		return dao.ManifestPodSpec(raw)
+	}
+	if len(tokens) != 2 {
+		return nil, fmt.Errorf("expecting a workload of the form `dp ns/name` but got %q", workload)
+	}
+
+	gvr, ok := c.App().command.alias.AsGVR(tokens[0])
+	if !ok {
+		return nil, fmt.Errorf("unable to resolve resource %q", tokens[0])
+	}
+	acc, err := dao.AccessorFor(c.App().factory, gvr)
+	if err != nil {
+		return nil, err
+	}
+	ps, ok := acc.(dao.ContainsPodSpec)
+	if !ok {
+		return nil, fmt.Errorf("resource %s does not define a pod template", gvr)
+	}
+
+	return ps.GetPodSpec(tokens[1])
+}
@@ -0,0 +1,81 @@
+package view
+
+import (
+	"context"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/gdamore/tcell/v2"
+)
+
+// Cert presents a cluster wide certificates expiry viewer.
+type Cert struct {
+	ResourceViewer
+}
+
+// NewCert returns a new viewer.
+func NewCert(gvr client.GVR) ResourceViewer {
+	c := Cert{
+		ResourceViewer: NewBrowser(gvr),
+	}
+	c.GetTable().SetBorderFocusColor(tcell.ColorDarkSeaGreen)
+	c.GetTable().SetSelectedStyle(tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDarkSeaGreen).Attributes(tcell.AttrNone))
+	c.GetTable().SetSortCol("EXPIRES", true)
+	c.GetTable().SetEnterFn(c.showCert)
+	c.SetContextFn(c.certContext)
+	c.AddBindKeysFn(c.bindKeys)
+
+	return &c
+}
+
+func (c *Cert) bindKeys(aa ui.KeyActions) {
+	aa.Delete(ui.KeyShiftA, tcell.KeyCtrlS, tcell.KeyCtrlSpace, ui.KeySpace)
+	aa.Add(ui.KeyActions{
+		ui.KeyShiftE: ui.NewKeyAction("Sort Expires", c.GetTable().SortColCmd("EXPIRES", true), false),
+		ui.KeyShiftS: ui.NewKeyAction("Sort Status", c.GetTable().SortColCmd(statusCol, true), false),
+		ui.KeyShiftK: ui.NewKeyAction("Sort Kind", c.GetTable().SortColCmd("KIND", true), false),
+	})
+}
+
+func (c *Cert) certContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, internal.KeyCerts, c.App().Config.K9s.Certs)
+}
+
+func (c *Cert) showCert(app *App, _ ui.Tabular, _, id string) {
+	var acc dao.Cert
+	acc.Init(app.factory, c.GVR())
+	report, err := acc.Bundle(id, app.Config.K9s.Certs)
+	if err != nil {
+		app.Flash().Err(err)
+		return
+	}
+
+	details := NewDetails(app, "Certificates", id, true).Update(report)
+	if err := app.inject(details); err != nil {
+		app.Flash().Err(err)
+	}
+}
+
+func inspectCerts(evt *tcell.EventKey, a *App, t *Table, gvr string) *tcell.EventKey {
+	path := t.GetSelectedItem()
+	if path == "" {
+		return evt
+	}
+
+	var acc dao.Cert
+	acc.Init(a.factory, client.NewGVR("certs"))
+	report, err := acc.Inspect(gvr, path, a.Config.K9s.Certs)
+	if err != nil {
+		a.Flash().Err(err)
+		return nil
+	}
+
+	details := NewDetails(a, "Certificates", path, true).Update(report)
+	if err := a.inject(details); err != nil {
+		a.Flash().Err(err)
+	}
+
+	return nil
+}
@@ -28,6 +28,8 @@ func NewConfigMap(gvr client.GVR) ResourceViewer {
 func (s *ConfigMap) bindKeys(aa ui.KeyActions) {
 	aa.Add(ui.KeyActions{
 		ui.KeyU: ui.NewKeyAction("UsedBy", s.refCmd, true),
+		ui.KeyI: ui.NewKeyAction("Certificates", s.certsCmd, true),
+		ui.KeyO: ui.NewKeyAction("Consumers", s.consumersCmd, true),
 	})
 }
 
@@ -35,6 +37,14 @@ func (s *ConfigMap) refCmd(evt *tcell.EventKey) *tcell.EventKey {
 	return scanRefs(evt, s.App(), s.GetTable(), "v1/configmaps")
 }
 
+func (s *ConfigMap) consumersCmd(evt *tcell.EventKey) *tcell.EventKey {
+	return showConsumers(evt, s.App(), s.GetTable(), "v1/configmaps")
+}
+
+func (s *ConfigMap) certsCmd(evt *tcell.EventKey) *tcell.EventKey {
+	return inspectCerts(evt, s.App(), s.GetTable(), "v1/configmaps")
+}
+
 func scanRefs(evt *tcell.EventKey, a *App, t *Table, gvr string) *tcell.EventKey {
 	path := t.GetSelectedItem()
 	if path == "" {
@@ -8,6 +8,7 @@ import (
 	"sync"
 
 	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
 	"github.com/derailed/k9s/internal/dao"
 	"github.com/derailed/k9s/internal/model"
 	"github.com/rs/zerolog/log"
@@ -61,7 +62,10 @@ func (c *Command) Reset(clear bool) error {
 	return nil
 }
 
-func allowedXRay(gvr client.GVR) bool {
+func allowedXRay(gvr client.GVR, rr *config.XrayRules) bool {
+	if rr.HasRules(gvr.String()) {
+		return true
+	}
 	gg := []string{
 		"v1/pods",
 		"v1/services",
@@ -69,6 +73,9 @@ func allowedXRay(gvr client.GVR) bool {
 		"apps/v1/daemonsets",
 		"apps/v1/statefulsets",
 		"apps/v1/replicasets",
+		"networking.k8s.io/v1/ingresses",
+		"gateway.networking.k8s.io/v1/gateways",
+		"gateway.networking.k8s.io/v1beta1/gateways",
 	}
 	for _, g := range gg {
 		if g == gvr.String() {
@@ -88,7 +95,11 @@ func (c *Command) xrayCmd(cmd string) error {
 	if !ok {
 		return fmt.Errorf("`%s` command not found", cmd)
 	}
-	if !allowedXRay(gvr) {
+	rules, err := loadXrayRules()
+	if err != nil {
+		c.app.Flash().Warnf("Unable to load xray rules: %s", err)
+	}
+	if !allowedXRay(gvr, rules) {
 		return fmt.Errorf("`%s` command not found", cmd)
 	}
 
@@ -0,0 +1,149 @@
+package view
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/k9s/internal/ui/dialog"
+	"github.com/gdamore/tcell/v2"
+)
+
+// Consumer presents the pods consuming a configmap or secret.
+type Consumer struct {
+	ResourceViewer
+
+	gvr, path string
+}
+
+// NewConsumer returns a new viewer.
+func NewConsumer(gvr client.GVR) ResourceViewer {
+	c := Consumer{
+		ResourceViewer: NewBrowser(gvr),
+	}
+	c.GetTable().SetBorderFocusColor(tcell.ColorMediumSpringGreen)
+	c.GetTable().SetSelectedStyle(tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorMediumSpringGreen).Attributes(tcell.AttrNone))
+	c.GetTable().SetSortCol("STALE", false)
+	c.AddBindKeysFn(c.bindKeys)
+
+	return &c
+}
+
+func (c *Consumer) bindKeys(aa ui.KeyActions) {
+	aa.Delete(ui.KeyShiftA, tcell.KeyCtrlS, tcell.KeyCtrlSpace, ui.KeySpace)
+	aa.Delete(tcell.KeyCtrlW, tcell.KeyCtrlL, tcell.KeyCtrlZ)
+	aa.Add(ui.KeyActions{
+		tcell.KeyEnter: ui.NewKeyAction("Goto", c.gotoCmd, true),
+		ui.KeyShiftS:   ui.NewKeyAction("Sort Stale", c.GetTable().SortColCmd("STALE", false), false),
+		ui.KeyShiftU:   ui.NewKeyAction("Sort Usage", c.GetTable().SortColCmd("USAGE", true), false),
+	})
+	if c.App().Config.K9s.IsReadOnly() {
+		return
+	}
+	aa.Add(ui.KeyActions{
+		ui.KeyR: ui.NewDangerousKeyAction("Restart Stale", c.restartCmd, true, config.ActionEdit),
+	})
+}
+
+func (c *Consumer) gotoCmd(evt *tcell.EventKey) *tcell.EventKey {
+	path := c.GetTable().GetSelectedItem()
+	if path == "" {
+		return evt
+	}
+	c.App().gotoResource("pods", path, false)
+
+	return nil
+}
+
+func (c *Consumer) restartCmd(evt *tcell.EventKey) *tcell.EventKey {
+	var acc dao.Consumer
+	acc.Init(c.App().factory, c.GVR())
+	cc, err := acc.Consumers(c.gvr, c.path)
+	if err != nil {
+		c.App().Flash().Err(err)
+		return nil
+	}
+	owners, orphans := dao.StaleConsumerOwners(cc)
+	if len(owners) == 0 {
+		if len(orphans) > 0 {
+			c.App().Flash().Warnf("No restartable owners for stale pods %s", strings.Join(orphans, ", "))
+		} else {
+			c.App().Flash().Infof("No stale consumers for %s", c.path)
+		}
+		return nil
+	}
+
+	nn := make([]string, 0, len(owners))
+	for _, o := range owners {
+		nn = append(nn, singularize(client.NewGVR(o.GVR).R())+"/"+o.FQN)
+	}
+	msg := fmt.Sprintf("Restart %d workload(s) with stale consumers: %s?", len(owners), strings.Join(nn, ", "))
+	dialog.ShowConfirm(c.App().Styles.Dialog(), c.App().Content.Pages, "Confirm Restart", msg, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), c.App().Conn().Config().CallTimeout())
+		defer cancel()
+		var failed []string
+		for i, o := range owners {
+			if err := c.restartOwner(ctx, o); err != nil {
+				failed = append(failed, nn[i]+": "+err.Error())
+			}
+		}
+		if len(failed) > 0 {
+			c.App().Flash().Errf("Restart failed for %s", strings.Join(failed, "; "))
+			return
+		}
+		msg := fmt.Sprintf("Restart in progress for %s", strings.Join(nn, ", "))
+		if len(orphans) > 0 {
+			msg += ". Not restartable: " + strings.Join(orphans, ", ")
+		}
+		c.App().Flash().Info(msg)
+		c.Refresh()
+	}, func() {})
+
+	return nil
+}
+
+func (c *Consumer) restartOwner(ctx context.Context, o dao.ConsumerOwner) error {
+	gvr := client.NewGVR(o.GVR)
+	if err := journalResources(c.App(), dao.JournalEdit, gvr, o.FQN); err != nil {
+		return err
+	}
+	res, err := dao.AccessorFor(c.App().factory, gvr)
+	if err != nil {
+		return err
+	}
+	s, ok := res.(dao.Restartable)
+	if !ok {
+		return errors.New("resource is not restartable")
+	}
+	err = s.Restart(ctx, o.FQN)
+	auditAction(c.App(), auditRestart, gvr, o.FQN, "stale consumers of "+c.path, err)
+
+	return err
+}
+
+func showConsumers(evt *tcell.EventKey, a *App, t *Table, gvr string) *tcell.EventKey {
+	path := t.GetSelectedItem()
+	if path == "" {
+		return evt
+	}
+
+	v := NewConsumer(client.NewGVR("consumers"))
+	if c, ok := v.(*Consumer); ok {
+		c.gvr, c.path = gvr, path
+	}
+	v.SetContextFn(func(ctx context.Context) context.Context {
+		ctx = context.WithValue(ctx, internal.KeyGVR, gvr)
+		return context.WithValue(ctx, internal.KeyPath, path)
+	})
+	if err := a.inject(v); err != nil {
+		a.Flash().Err(err)
+	}
+
+	return nil
+}
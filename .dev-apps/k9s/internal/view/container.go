@@ -7,6 +7,7 @@ import (
 
 	"github.com/derailed/k9s/internal"
 	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
 	"github.com/derailed/k9s/internal/dao"
 	"github.com/derailed/k9s/internal/port"
 	"github.com/derailed/k9s/internal/render"
@@ -55,8 +56,12 @@ func (c *Container) Name() string { return containerTitle }
 
 func (c *Container) bindDangerousKeys(aa ui.KeyActions) {
 	aa.Add(ui.KeyActions{
-		ui.KeyS: ui.NewKeyAction("Shell", c.shellCmd, true),
-		ui.KeyA: ui.NewKeyAction("Attach", c.attachCmd, true),
+		ui.KeyS: ui.NewDangerousKeyAction("Shell", c.shellCmd, true, config.ActionShell),
+		ui.KeyA: ui.NewDangerousKeyAction("Attach", c.attachCmd, true, config.ActionShell),
+		ui.KeyB: ui.NewDangerousKeyAction("Debug", c.debugCmd, true, config.ActionShell),
+		ui.KeyU: ui.NewDangerousKeyAction("Upload", c.uploadCmd, true, config.ActionShell),
+		ui.KeyO: ui.NewDangerousKeyAction("Download", c.downloadCmd, true, config.ActionShell),
+		ui.KeyV: ui.NewDangerousKeyAction("Browse Files", c.browseCmd, true, config.ActionShell),
 	})
 }
 
@@ -69,7 +74,7 @@ func (c *Container) bindKeys(aa ui.KeyActions) {
 
 	aa.Add(ui.KeyActions{
 		ui.KeyF:      ui.NewKeyAction("Show PortForward", c.showPFCmd, true),
-		ui.KeyShiftF: ui.NewKeyAction("PortForward", c.portFwdCmd, true),
+		ui.KeyShiftF: ui.NewDangerousKeyAction("PortForward", c.portFwdCmd, true, config.ActionPortForward),
 		ui.KeyShiftT: ui.NewKeyAction("Sort Restart", c.GetTable().SortColCmd("RESTARTS", false), false),
 	})
 	aa.Add(resourceSorters(c.GetTable()))
@@ -163,6 +168,52 @@ func (c *Container) attachCmd(evt *tcell.EventKey) *tcell.EventKey {
 	return nil
 }
 
+func (c *Container) debugCmd(evt *tcell.EventKey) *tcell.EventKey {
+	sel := c.GetTable().GetSelectedItem()
+	if sel == "" {
+		return evt
+	}
+
+	debugIn(c.App(), c, c.GetTable().Path, sel)
+
+	return nil
+}
+
+func (c *Container) uploadCmd(evt *tcell.EventKey) *tcell.EventKey {
+	sel := c.GetTable().GetSelectedItem()
+	if sel == "" {
+		return evt
+	}
+
+	uploadTo(c.App(), c.GetTable().Path, sel)
+
+	return nil
+}
+
+func (c *Container) downloadCmd(evt *tcell.EventKey) *tcell.EventKey {
+	sel := c.GetTable().GetSelectedItem()
+	if sel == "" {
+		return evt
+	}
+
+	downloadFrom(c.App(), c.GetTable().Path, sel)
+
+	return nil
+}
+
+func (c *Container) browseCmd(evt *tcell.EventKey) *tcell.EventKey {
+	sel := c.GetTable().GetSelectedItem()
+	if sel == "" {
+		return evt
+	}
+
+	if err := c.App().inject(NewFileSystem(c.GetTable().Path, sel)); err != nil {
+		c.App().Flash().Err(err)
+	}
+
+	return nil
+}
+
 func (c *Container) portFwdCmd(evt *tcell.EventKey) *tcell.EventKey {
 	path := c.GetTable().GetSelectedItem()
 	if path == "" {
@@ -0,0 +1,151 @@
+package view
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/tview"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	copyDialogKey     = "copy"
+	copyProgressDelay = 500 * time.Millisecond
+)
+
+func uploadTo(a *App, fqn, co string) {
+	picker := NewFilePicker(localDir(), func(src string) {
+		showRemotePath(a, "Upload", fmt.Sprintf("Upload %s to %s:%s?", src, fqn, co), fqn, co, func(dst string) {
+			go copyFiles(a, "Uploading", fqn, co, src, dst, func(po *dao.Pod, fn dao.ProgressFn) error {
+				return po.Upload(fqn, co, src, dst, fn)
+			})
+		})
+	})
+	if err := a.inject(picker); err != nil {
+		a.Flash().Err(err)
+	}
+}
+
+func downloadFrom(a *App, fqn, co string) {
+	showRemotePath(a, "Download", fmt.Sprintf("Download from %s:%s?", fqn, co), fqn, co, func(src string) {
+		downloadPath(a, fqn, co, src)
+	})
+}
+
+func downloadPath(a *App, fqn, co, src string) {
+	picker := NewFilePicker(localDir(), func(dst string) {
+		if info, err := os.Stat(dst); err == nil && !info.IsDir() {
+			dst = filepath.Dir(dst)
+		}
+		go copyFiles(a, "Downloading", fqn, co, src, dst, func(po *dao.Pod, fn dao.ProgressFn) error {
+			return po.Download(fqn, co, src, dst, fn)
+		})
+	})
+	if err := a.inject(picker); err != nil {
+		a.Flash().Err(err)
+	}
+}
+
+func showRemotePath(a *App, title, msg, fqn, co string, ok func(string)) {
+	go func() {
+		var po dao.Pod
+		po.Init(a.factory, client.NewGVR("v1/pods"))
+		dir := po.WorkingDir(fqn, co)
+		a.QueueUpdateDraw(func() {
+			showRemotePathDialog(a, title, msg, dir, ok)
+		})
+	}()
+}
+
+func showRemotePathDialog(a *App, title, msg, dir string, ok func(string)) {
+	f := tview.NewForm()
+	f.SetItemPadding(0)
+	f.SetButtonsAlign(tview.AlignCenter).
+		SetButtonBackgroundColor(tview.Styles.PrimitiveBackgroundColor).
+		SetButtonTextColor(tview.Styles.PrimaryTextColor).
+		SetLabelColor(tcell.ColorAqua).
+		SetFieldTextColor(tcell.ColorOrange)
+
+	remote := dir
+	f.AddInputField("Remote Path:", remote, 40, nil, func(changed string) {
+		remote = changed
+	})
+	f.AddButton("OK", func() {
+		a.Content.RemovePage(copyDialogKey)
+		if remote = strings.TrimSpace(remote); remote == "" {
+			a.Flash().Err(fmt.Errorf("a remote path is required"))
+			return
+		}
+		ok(remote)
+	})
+	f.AddButton("Cancel", func() {
+		a.Content.RemovePage(copyDialogKey)
+	})
+
+	modal := tview.NewModalForm("<"+title+">", f)
+	modal.SetText(msg)
+	modal.SetDoneFunc(func(int, string) {
+		a.Content.RemovePage(copyDialogKey)
+	})
+	a.Content.AddPage(copyDialogKey, modal, false, false)
+	a.Content.ShowPage(copyDialogKey)
+}
+
+func copyFiles(a *App, verb, fqn, co, src, dst string, cp func(*dao.Pod, dao.ProgressFn) error) {
+	var po dao.Pod
+	po.Init(a.factory, client.NewGVR("v1/pods"))
+
+	var (
+		mx   sync.Mutex
+		last time.Time
+	)
+	a.Flash().Infof("%s %s...", verb, src)
+	progress := func(n int64) {
+		mx.Lock()
+		defer mx.Unlock()
+		if time.Since(last) < copyProgressDelay {
+			return
+		}
+		last = time.Now()
+		a.Flash().Infof("%s %s... %s", verb, src, toHumanBytes(n))
+	}
+
+	err := cp(&po, progress)
+	auditAction(a, auditCopy, client.NewGVR("v1/pods"), fqn, fmt.Sprintf("container=%s %s %s -> %s", co, strings.ToLower(verb), src, dst), err)
+	if err != nil {
+		log.Error().Err(err).Msgf("%s %s failed", verb, src)
+		a.Flash().Err(err)
+		return
+	}
+	a.Flash().Infof("%s %s to %s completed", verb, src, dst)
+}
+
+func localDir() string {
+	dir, err := os.Getwd()
+	if err != nil {
+		return string(filepath.Separator)
+	}
+
+	return dir
+}
+
+func toHumanBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,26 @@
+package view
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToHumanBytes(t *testing.T) {
+	uu := map[string]struct {
+		n int64
+		e string
+	}{
+		"bytes": {n: 512, e: "512B"},
+		"kilo":  {n: 1536, e: "1.5KiB"},
+		"mega":  {n: 10 * 1024 * 1024, e: "10.0MiB"},
+		"giga":  {n: 3 * 1024 * 1024 * 1024, e: "3.0GiB"},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, toHumanBytes(u.n))
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/derailed/k9s/internal"
 	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
 	"github.com/derailed/k9s/internal/dao"
 	"github.com/derailed/k9s/internal/ui"
 	"github.com/derailed/k9s/internal/ui/dialog"
@@ -66,8 +67,10 @@ func jobCtx(path, uid string) ContextFunc {
 
 func (c *CronJob) bindKeys(aa ui.KeyActions) {
 	aa.Add(ui.KeyActions{
-		ui.KeyT: ui.NewKeyAction("Trigger", c.triggerCmd, true),
-		ui.KeyS: ui.NewKeyAction("Suspend/Resume", c.toggleSuspendCmd, true),
+		ui.KeyT: ui.NewDangerousKeyAction("Trigger", c.triggerCmd, true, config.ActionEdit),
+		ui.KeyN: ui.NewKeyAction("Schedule", c.scheduleCmd, true),
+		ui.KeyH: ui.NewKeyAction("History", c.historyCmd, true),
+		ui.KeyS: ui.NewDangerousKeyAction("Suspend/Resume", c.toggleSuspendCmd, true, config.ActionEdit),
 	})
 }
 
@@ -90,7 +93,9 @@ func (c *CronJob) triggerCmd(evt *tcell.EventKey) *tcell.EventKey {
 			return
 		}
 
-		if err := runner.Run(fqn); err != nil {
+		err = runner.Run(fqn)
+		auditAction(c.App(), auditTrigger, c.GVR(), fqn, "", err)
+		if err != nil {
 			c.App().Flash().Errf("Cronjob trigger failed %v", err)
 			return
 		}
@@ -169,8 +174,14 @@ func (c *CronJob) toggleSuspend(ctx context.Context, path string) error {
 	if !ok {
 		return fmt.Errorf("expecting a scalable resource for %q", c.GVR())
 	}
+	if err := journalResources(c.App(), dao.JournalEdit, c.GVR(), path); err != nil {
+		return err
+	}
+
+	err = cronJob.ToggleSuspend(ctx, path)
+	auditAction(c.App(), auditSuspend, c.GVR(), path, "", err)
 
-	return cronJob.ToggleSuspend(ctx, path)
+	return err
 }
 
 func (c *CronJob) makeStyledForm() *tview.Form {
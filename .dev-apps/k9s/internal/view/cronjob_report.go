@@ -0,0 +1,197 @@
+package view
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/gdamore/tcell/v2"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+const (
+	// cronNextRuns tracks the number of upcoming runs to preview.
+	cronNextRuns = 10
+
+	cronTimeFmt = "2006-01-02 15:04 MST"
+)
+
+func (c *CronJob) scheduleCmd(evt *tcell.EventKey) *tcell.EventKey {
+	return c.showReport(evt, "Schedule", cronScheduleReport)
+}
+
+func (c *CronJob) historyCmd(evt *tcell.EventKey) *tcell.EventKey {
+	return c.showReport(evt, "Job History", cronHistoryReport)
+}
+
+func (c *CronJob) showReport(evt *tcell.EventKey, title string, report func(*dao.CronJobReport, time.Time) string) *tcell.EventKey {
+	path := c.GetTable().GetSelectedItem()
+	if path == "" {
+		return evt
+	}
+
+	var cj dao.CronJob
+	cj.Init(c.App().factory, c.GVR())
+	now := time.Now()
+	r, err := cj.Report(path, now, cronNextRuns)
+	if err != nil {
+		c.App().Flash().Err(err)
+		return nil
+	}
+	details := NewDetails(c.App(), title, path, true).Update(report(r, now))
+	if err := c.App().inject(details); err != nil {
+		c.App().Flash().Err(err)
+	}
+
+	return nil
+}
+
+func cronScheduleReport(r *dao.CronJobReport, now time.Time) string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "schedule:\t%s\n", r.Schedule)
+	fmt.Fprintf(w, "time zone:\t%s\n", r.TimeZone)
+	fmt.Fprintf(w, "suspended:\t%t\n", r.Suspended)
+	fmt.Fprintf(w, "concurrency:\t%s\n", r.Policy)
+	deadline := "none"
+	if r.Deadline > 0 {
+		deadline = r.Deadline.String()
+	}
+	fmt.Fprintf(w, "deadline:\t%s\n", deadline)
+	fmt.Fprintf(w, "last schedule:\t%s\n", cronTime(r.LastSchedule, now))
+	fmt.Fprintf(w, "last success:\t%s\n", cronTime(r.LastSuccess, now))
+	_ = w.Flush()
+
+	b.WriteString("next runs:\n")
+	if len(r.Next) == 0 {
+		b.WriteString("  none\n")
+	}
+	for _, t := range r.Next {
+		fmt.Fprintf(&b, "  %s\n", cronTime(t, now))
+	}
+	writeCronIssues(&b, r)
+	if len(r.Missed) > 0 {
+		b.WriteString("missed:\n")
+		for _, t := range r.Missed {
+			fmt.Fprintf(&b, "  %s\n", cronTime(t, now))
+		}
+	}
+
+	return b.String()
+}
+
+func cronHistoryReport(r *dao.CronJobReport, now time.Time) string {
+	var (
+		b            strings.Builder
+		done, failed int
+	)
+	for _, run := range r.Runs {
+		switch run.Status {
+		case dao.CronRunComplete:
+			done++
+		case dao.CronRunFailed:
+			failed++
+		}
+	}
+	fmt.Fprintf(&b, "runs:      %d (%d succeeded, %d failed, %d missed)\n", len(r.Runs), done, failed, r.MissedCount)
+	if len(r.Runs) > 0 {
+		spark, max := cronSpark(r.Runs, now)
+		fmt.Fprintf(&b, "durations: %s (max %s)\n", spark, duration.HumanDuration(max))
+		fmt.Fprintf(&b, "results:   %s\n", cronResults(r.Runs))
+	}
+	writeCronIssues(&b, r)
+
+	type entry struct {
+		at   time.Time
+		line string
+	}
+	ee := make([]entry, 0, len(r.Runs)+len(r.Missed))
+	for _, run := range r.Runs {
+		d := "n/a"
+		if !run.Started.IsZero() {
+			d = duration.HumanDuration(run.Duration(now))
+		}
+		ee = append(ee, entry{at: run.Scheduled, line: fmt.Sprintf("%s\t%s\t%s\t%s", run.Job, cronTime(run.Scheduled, now), run.Status, d)})
+	}
+	for _, t := range r.Missed {
+		ee = append(ee, entry{at: t, line: fmt.Sprintf("-\t%s\t%s\tn/a", cronTime(t, now), dao.CronRunMissed)})
+	}
+	if len(ee) == 0 {
+		b.WriteString("\nNo jobs found\n")
+		return b.String()
+	}
+	sort.SliceStable(ee, func(i, j int) bool {
+		return ee[i].at.After(ee[j].at)
+	})
+
+	b.WriteString("\n")
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "JOB\tSCHEDULED\tSTATUS\tDURATION")
+	for _, e := range ee {
+		fmt.Fprintln(w, e.line)
+	}
+	_ = w.Flush()
+
+	return b.String()
+}
+
+func writeCronIssues(b *strings.Builder, r *dao.CronJobReport) {
+	if len(r.Issues) == 0 {
+		return
+	}
+	b.WriteString("issues:\n")
+	for _, i := range r.Issues {
+		fmt.Fprintf(b, "  ! %s\n", i)
+	}
+}
+
+// cronSpark renders the runs durations as a sparkline, one run per glyph.
+func cronSpark(rr []dao.CronJobRun, now time.Time) (string, time.Duration) {
+	var max time.Duration
+	for _, r := range rr {
+		if d := r.Duration(now); d > max {
+			max = d
+		}
+	}
+	ss := make([]rune, 0, len(rr))
+	for _, r := range rr {
+		if max == 0 {
+			ss = append(ss, trendSparks[0])
+			continue
+		}
+		ss = append(ss, trendSparks[int64(r.Duration(now))*int64(len(trendSparks)-1)/int64(max)])
+	}
+
+	return string(ss), max
+}
+
+// cronResults renders the runs outcome, one run per glyph.
+func cronResults(rr []dao.CronJobRun) string {
+	ss := make([]rune, 0, len(rr))
+	for _, r := range rr {
+		switch r.Status {
+		case dao.CronRunComplete:
+			ss = append(ss, '✓')
+		case dao.CronRunFailed:
+			ss = append(ss, '✗')
+		default:
+			ss = append(ss, '•')
+		}
+	}
+
+	return string(ss)
+}
+
+func cronTime(t, now time.Time) string {
+	if t.IsZero() {
+		return "n/a"
+	}
+	if t.After(now) {
+		return fmt.Sprintf("%s (in %s)", t.Format(cronTimeFmt), duration.HumanDuration(t.Sub(now)))
+	}
+
+	return fmt.Sprintf("%s (%s ago)", t.Format(cronTimeFmt), duration.HumanDuration(now.Sub(t)))
+}
@@ -0,0 +1,62 @@
+package view
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCronSpark(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	uu := map[string]struct {
+		rr  []dao.CronJobRun
+		e   string
+		max time.Duration
+	}{
+		"flat": {
+			rr: []dao.CronJobRun{{}, {}},
+			e:  "▁▁",
+		},
+		"ramp": {
+			rr: []dao.CronJobRun{
+				{Started: now.Add(-time.Hour), Finished: now.Add(-time.Hour)},
+				{Started: now.Add(-time.Hour), Finished: now.Add(-50 * time.Minute)},
+				{Started: now.Add(-7 * time.Minute)},
+			},
+			e:   "▁█▅",
+			max: 10 * time.Minute,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			s, max := cronSpark(u.rr, now)
+			assert.Equal(t, u.e, s)
+			assert.Equal(t, u.max, max)
+		})
+	}
+}
+
+func TestCronHistoryReport(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	r := dao.CronJobReport{
+		Runs: []dao.CronJobRun{
+			{Job: "default/fred-1", Scheduled: now.Add(-3 * time.Hour), Started: now.Add(-3 * time.Hour), Finished: now.Add(-170 * time.Minute), Status: dao.CronRunComplete},
+			{Job: "default/fred-2", Scheduled: now.Add(-2 * time.Hour), Started: now.Add(-2 * time.Hour), Finished: now.Add(-115 * time.Minute), Status: dao.CronRunFailed},
+		},
+		Missed:      []time.Time{now.Add(-time.Hour)},
+		MissedCount: 1,
+		Issues:      []string{"last run default/fred-2 failed"},
+	}
+
+	s := cronHistoryReport(&r, now)
+	assert.Contains(t, s, "runs:      2 (1 succeeded, 1 failed, 1 missed)\n")
+	assert.Contains(t, s, "durations: █▄ (max 10m)\n")
+	assert.Contains(t, s, "results:   ✓✗\n")
+	assert.Contains(t, s, "  ! last run default/fred-2 failed\n")
+	assert.Regexp(t, `(?s)Missed.*default/fred-2.*default/fred-1`, s)
+	assert.Equal(t, "runs:      0 (0 succeeded, 0 failed, 0 missed)\n\nNo jobs found\n", cronHistoryReport(&dao.CronJobReport{}, now))
+}
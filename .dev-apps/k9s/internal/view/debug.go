@@ -0,0 +1,95 @@
+package view
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/model"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	debugRetryCount = 30
+	debugRetryDelay = 2 * time.Second
+)
+
+func containerDebugIn(a *App, comp model.Component, path, co string) error {
+	if co != "" || !a.Config.K9s.ActiveCluster().DebugContainer.TargetProcess {
+		debugIn(a, comp, path, co)
+		return nil
+	}
+
+	pod, err := fetchPod(a.factory, path)
+	if err != nil {
+		return err
+	}
+	cc := fetchContainers(pod.Spec, false)
+	if len(cc) == 1 {
+		debugIn(a, comp, path, cc[0])
+		return nil
+	}
+	picker := NewPicker()
+	picker.populate(cc)
+	picker.SetSelectedFunc(func(_ int, co, _ string, _ rune) {
+		debugIn(a, comp, path, co)
+	})
+
+	return a.inject(picker)
+}
+
+func debugIn(a *App, comp model.Component, path, target string) {
+	cfg := a.Config.K9s.ActiveCluster().DebugContainer
+	opts := dao.DebugOpts{
+		Image:   cfg.Image,
+		Command: cfg.Command,
+		Args:    cfg.Args,
+	}
+	if cfg.TargetProcess {
+		opts.Target = target
+	}
+
+	a.Flash().Infof("Launching debug container on %s...", path)
+	go func() {
+		co, err := launchDebugContainer(a, path, opts)
+		auditAction(a, auditDebug, client.NewGVR("v1/pods"), path, fmt.Sprintf("image=%s target=%s", opts.Image, opts.Target), err)
+		if err != nil {
+			a.Flash().Err(err)
+			return
+		}
+		a.QueueUpdateDraw(func() {
+			resumeAttachIn(a, comp, path, co)
+		})
+	}()
+}
+
+func launchDebugContainer(a *App, path string, opts dao.DebugOpts) (string, error) {
+	var po dao.Pod
+	po.Init(a.factory, client.NewGVR("v1/pods"))
+	ctx, cancel := context.WithTimeout(context.Background(), a.Conn().Config().CallTimeout())
+	defer cancel()
+	co, err := po.Debug(ctx, path, opts)
+	if err != nil {
+		return "", err
+	}
+
+	for i := 0; i < debugRetryCount; i++ {
+		pod, err := fetchPod(a.factory, path)
+		if err != nil {
+			return "", err
+		}
+		ok, err := dao.EphemeralContainerRunning(pod, co)
+		if err != nil {
+			return "", err
+		}
+		log.Debug().Msgf("Checking debug container %s [%d] %t", co, i, ok)
+		if ok {
+			return co, nil
+		}
+		time.Sleep(debugRetryDelay)
+	}
+
+	return "", fmt.Errorf("unable to launch debug container %s on pod %s", co, path)
+}
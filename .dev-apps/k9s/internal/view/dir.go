@@ -11,6 +11,7 @@ import (
 	"github.com/derailed/k9s/internal"
 	"github.com/derailed/k9s/internal/client"
 	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
 	"github.com/derailed/k9s/internal/ui"
 	"github.com/derailed/k9s/internal/ui/dialog"
 	"github.com/gdamore/tcell/v2"
@@ -60,9 +61,10 @@ func (d *Dir) dirContext(ctx context.Context) context.Context {
 
 func (d *Dir) bindDangerousKeys(aa ui.KeyActions) {
 	aa.Add(ui.KeyActions{
-		ui.KeyA: ui.NewKeyAction("Apply", d.applyCmd, true),
-		ui.KeyD: ui.NewKeyAction("Delete", d.delCmd, true),
-		ui.KeyE: ui.NewKeyAction("Edit", d.editCmd, true),
+		ui.KeyA: ui.NewDangerousKeyAction("Apply", d.applyCmd, true, config.ActionEdit),
+		ui.KeyD: ui.NewDangerousKeyAction("Delete", d.delCmd, true, config.ActionDelete),
+		ui.KeyE: ui.NewDangerousKeyAction("Edit", d.editCmd, true, config.ActionEdit),
+		ui.KeyH: ui.NewDangerousKeyAction("Helm Install/Upgrade", d.helmCmd, true, config.ActionEdit),
 	})
 }
 
@@ -202,12 +204,17 @@ func (d *Dir) applyCmd(evt *tcell.EventKey) *tcell.EventKey {
 	}
 	d.Stop()
 	defer d.Start()
+	if err := d.journalManifest(dao.JournalEdit, sel); err != nil {
+		d.App().Flash().Err(err)
+		return nil
+	}
 	{
 		args := make([]string, 0, 10)
 		args = append(args, "apply")
 		args = append(args, opts...)
 		args = append(args, sel)
 		res, err := runKu(d.App(), shellOpts{clear: false, args: args})
+		auditAction(d.App(), auditApply, client.GVR{}, "", "manifest="+sel, err)
 		if err != nil {
 			res = "status:\n  " + err.Error() + "\nmessage:\n" + fmtResults(res)
 		} else {
@@ -233,11 +240,16 @@ func (d *Dir) delCmd(evt *tcell.EventKey) *tcell.EventKey {
 	defer d.Start()
 	msg := fmt.Sprintf("Delete resource(s) in manifest %s", sel)
 	dialog.ShowConfirm(d.App().Styles.Dialog(), d.App().Content.Pages, "Confirm Delete", msg, func() {
+		if err := d.journalManifest(dao.JournalDelete, sel); err != nil {
+			d.App().Flash().Err(err)
+			return
+		}
 		args := make([]string, 0, 10)
 		args = append(args, "delete")
 		args = append(args, "-f")
 		args = append(args, sel)
 		res, err := runKu(d.App(), shellOpts{clear: false, args: args})
+		auditAction(d.App(), auditDelete, client.GVR{}, "", "manifest="+sel, err)
 		if err != nil {
 			res = "status:\n  " + err.Error() + "\nmessage:\n" + fmtResults(res)
 		} else {
@@ -252,6 +264,28 @@ func (d *Dir) delCmd(evt *tcell.EventKey) *tcell.EventKey {
 	return nil
 }
 
+// journalManifest snapshots the live resources declared in a manifest.
+func (d *Dir) journalManifest(action, sel string) error {
+	var (
+		refs []dao.ManifestRef
+		err  error
+	)
+	if isKustomized(sel) {
+		var out string
+		if out, err = runKu(d.App(), shellOpts{args: []string{"kustomize", sel}}); err != nil {
+			return fmt.Errorf("kustomize %s failed: %w", sel, err)
+		}
+		refs, err = dao.ParseManifestRefs(strings.NewReader(out))
+	} else {
+		refs, err = dao.ManifestRefs(sel, containsDir(sel))
+	}
+	if err != nil {
+		return err
+	}
+
+	return journalManifest(d.App(), action, refs)
+}
+
 func fmtResults(res string) string {
 	res = strings.TrimSpace(res)
 	lines := strings.Split(res, "\n")
@@ -0,0 +1,224 @@
+package view
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/ui/dialog"
+	"github.com/derailed/tview"
+	"github.com/gdamore/tcell/v2"
+)
+
+const helmChartDialogKey = "helm-chart"
+
+func (d *Dir) helmCmd(evt *tcell.EventKey) *tcell.EventKey {
+	sel := d.GetTable().GetSelectedItem()
+	if sel == "" {
+		return evt
+	}
+	if !dao.IsHelmChart(sel) {
+		d.App().Flash().Errf("you must select a chart directory or archive")
+		return nil
+	}
+
+	ns := d.App().Config.ActiveNamespace()
+	if !client.IsNamespaced(ns) {
+		ns = client.DefaultNamespace
+	}
+	d.showHelmDialog(dao.HelmChartOpts{
+		Chart:     sel,
+		Release:   dao.HelmChartName(sel),
+		Namespace: ns,
+	})
+
+	return nil
+}
+
+func (d *Dir) showHelmDialog(opts dao.HelmChartOpts) {
+	f := tview.NewForm()
+	f.SetItemPadding(0)
+	f.SetButtonsAlign(tview.AlignCenter).
+		SetButtonBackgroundColor(tview.Styles.PrimitiveBackgroundColor).
+		SetButtonTextColor(tview.Styles.PrimaryTextColor).
+		SetLabelColor(tcell.ColorAqua).
+		SetFieldTextColor(tcell.ColorOrange)
+	f.AddInputField("Release:", opts.Release, 40, nil, func(changed string) {
+		opts.Release = strings.TrimSpace(changed)
+	})
+	f.AddInputField("Namespace:", opts.Namespace, 40, nil, func(changed string) {
+		opts.Namespace = strings.TrimSpace(changed)
+	})
+	f.AddButton("Values", func() {
+		d.dismissHelmDialog()
+		d.editHelmValues(opts)
+		d.showHelmDialog(opts)
+	})
+	f.AddButton("Dry Run", func() {
+		d.dismissHelmDialog()
+		d.helmPreview(opts, false)
+	})
+	f.AddButton("Diff", func() {
+		d.dismissHelmDialog()
+		d.helmPreview(opts, true)
+	})
+	f.AddButton("Deploy", func() {
+		d.dismissHelmDialog()
+		d.helmDeploy(opts)
+	})
+	f.AddButton("Cancel", func() {
+		d.dismissHelmDialog()
+	})
+
+	values := "chart defaults"
+	if path := d.helmValuesFile(opts); path != "" {
+		values = path
+	}
+	modal := tview.NewModalForm("<Helm Install/Upgrade>", f)
+	modal.SetText(fmt.Sprintf("Chart %s\nValues %s", opts.Chart, values))
+	modal.SetDoneFunc(func(int, string) {
+		d.dismissHelmDialog()
+	})
+	d.App().Content.AddPage(helmChartDialogKey, modal, false, false)
+	d.App().Content.ShowPage(helmChartDialogKey)
+}
+
+func (d *Dir) dismissHelmDialog() {
+	d.App().Content.RemovePage(helmChartDialogKey)
+}
+
+func (d *Dir) helmChart() *dao.HelmChart {
+	var h dao.HelmChart
+	h.Init(d.App().factory, client.NewGVR("helm"))
+
+	return &h
+}
+
+// helmValuesFile returns the release edited values file if any.
+func (d *Dir) helmValuesFile(opts dao.HelmChartOpts) string {
+	path := d.App().Config.K9s.GetHelmValuesFile(opts.Namespace, opts.Release)
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+
+	return path
+}
+
+func (d *Dir) editHelmValues(opts dao.HelmChartOpts) {
+	path := d.App().Config.K9s.GetHelmValuesFile(opts.Namespace, opts.Release)
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		vals, err := d.helmChart().Values(opts)
+		if err != nil {
+			d.App().Flash().Err(err)
+			return
+		}
+		if err := ensureDir(filepath.Dir(path)); err != nil {
+			d.App().Flash().Err(err)
+			return
+		}
+		if err := os.WriteFile(path, vals, config.DefaultFileMod); err != nil {
+			d.App().Flash().Err(err)
+			return
+		}
+	}
+
+	d.Stop()
+	defer d.Start()
+	if !edit(d.App(), shellOpts{clear: true, args: []string{path}}) {
+		d.App().Flash().Err(errors.New("Failed to launch editor"))
+	}
+}
+
+func (d *Dir) helmPreview(opts dao.HelmChartOpts, diff bool) {
+	opts.DryRun, opts.ValuesFile = true, d.helmValuesFile(opts)
+	fqn := client.FQN(opts.Namespace, opts.Release)
+	d.App().Flash().Infof("Rendering %s...", fqn)
+	go func() {
+		res, err := d.helmChart().Deploy(opts)
+		title, text := "Dry Run", ""
+		if err == nil {
+			if diff {
+				title = "Release Diff"
+				text, err = res.Diff()
+				if err == nil && text == "" {
+					text = "No manifest changes for release " + fqn
+				}
+			} else {
+				text = res.String()
+			}
+		}
+		d.App().QueueUpdateDraw(func() {
+			if err != nil {
+				d.App().Flash().Err(err)
+				return
+			}
+			d.App().Flash().Clear()
+			details := NewDetails(d.App(), title, fqn, true).Update(text)
+			if err := d.App().inject(details); err != nil {
+				d.App().Flash().Err(err)
+			}
+		})
+	}()
+}
+
+func (d *Dir) helmDeploy(opts dao.HelmChartOpts) {
+	opts.ValuesFile = d.helmValuesFile(opts)
+	fqn := client.FQN(opts.Namespace, opts.Release)
+	msg := fmt.Sprintf("Deploy chart %s as release %s?", opts.Chart, fqn)
+	dialog.ShowConfirm(d.App().Styles.Dialog(), d.App().Content.Pages, "Confirm Deploy", msg, func() {
+		var (
+			mx  sync.Mutex
+			out strings.Builder
+		)
+		fmt.Fprintf(&out, "Deploying chart %s as release %s...\n", opts.Chart, fqn)
+		details := NewDetails(d.App(), "Helm Deploy", fqn, true).Update(out.String())
+		if err := d.App().inject(details); err != nil {
+			d.App().Flash().Err(err)
+			return
+		}
+
+		report := func(s string) {
+			mx.Lock()
+			out.WriteString(s)
+			text := out.String()
+			mx.Unlock()
+			d.App().QueueUpdateDraw(func() {
+				details.Update(text)
+			})
+		}
+		opts.Log = func(format string, args ...interface{}) {
+			report(fmt.Sprintf(format, args...) + "\n")
+		}
+		go func() {
+			res, err := d.helmChart().Deploy(opts)
+			action, done := auditInstall, "installed"
+			if res != nil && res.Upgrade() {
+				action, done = auditUpgrade, "upgraded"
+			}
+			info := "chart=" + opts.Chart
+			if opts.ValuesFile != "" {
+				info += " values=" + opts.ValuesFile
+			}
+			auditAction(d.App(), action, client.NewGVR("helm"), fqn, info, err)
+			if err != nil {
+				report("\nstatus:\n  " + err.Error() + "\n")
+			}
+			if res != nil {
+				report("\n" + res.String())
+			}
+			d.App().QueueUpdateDraw(func() {
+				if err != nil {
+					d.App().Flash().Err(err)
+					return
+				}
+				d.App().Flash().Infof("%s %s", fqn, done)
+			})
+		}()
+	}, func() {})
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/derailed/k9s/internal/client"
 	"github.com/derailed/k9s/internal/dao"
 	"github.com/derailed/k9s/internal/ui"
+	"github.com/gdamore/tcell/v2"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -24,7 +25,9 @@ func NewDeploy(gvr client.GVR) ResourceViewer {
 		NewRestartExtender(
 			NewScaleExtender(
 				NewImageExtender(
-					NewLogsExtender(NewBrowser(gvr), d.logOptions),
+					NewHistoryExtender(
+						NewLogsExtender(NewBrowser(gvr), d.logOptions),
+					),
 				),
 			),
 		),
@@ -40,9 +43,22 @@ func (d *Deploy) bindKeys(aa ui.KeyActions) {
 		ui.KeyShiftR: ui.NewKeyAction("Sort Ready", d.GetTable().SortColCmd(readyCol, true), false),
 		ui.KeyShiftU: ui.NewKeyAction("Sort UpToDate", d.GetTable().SortColCmd(uptodateCol, true), false),
 		ui.KeyShiftL: ui.NewKeyAction("Sort Available", d.GetTable().SortColCmd(availCol, true), false),
+		ui.KeyO:      ui.NewKeyAction("Rollout", d.rolloutCmd, true),
 	})
 }
 
+func (d *Deploy) rolloutCmd(evt *tcell.EventKey) *tcell.EventKey {
+	path := d.GetTable().GetSelectedItem()
+	if path == "" {
+		return evt
+	}
+	if err := d.App().inject(NewRollout(path)); err != nil {
+		d.App().Flash().Err(err)
+	}
+
+	return nil
+}
+
 func (d *Deploy) logOptions(prev bool) (*dao.LogOptions, error) {
 	path := d.GetTable().GetSelectedItem()
 	if path == "" {
@@ -17,7 +17,9 @@ func NewDaemonSet(gvr client.GVR) ResourceViewer {
 		ResourceViewer: NewPortForwardExtender(
 			NewRestartExtender(
 				NewImageExtender(
-					NewLogsExtender(NewBrowser(gvr), nil),
+					NewHistoryExtender(
+						NewLogsExtender(NewBrowser(gvr), nil),
+					),
 				),
 			),
 		),
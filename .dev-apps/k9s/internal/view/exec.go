@@ -187,7 +187,10 @@ const (
 	k9sShellRetryDelay = 10 * time.Second
 )
 
-func ssh(a *App, node string) error {
+func ssh(a *App, node string) (err error) {
+	defer func() {
+		auditAction(a, auditShell, client.NewGVR("v1/nodes"), node, "node shell", err)
+	}()
 	if err := nukeK9sShell(a); err != nil {
 		return err
 	}
@@ -202,12 +205,11 @@ func ssh(a *App, node string) error {
 
 	cl := a.Config.K9s.ActiveCluster()
 	ns := cl.ShellPod.Namespace
-	sshIn(a, client.FQN(ns, k9sShellPodName()), k9sShell)
 
-	return nil
+	return sshIn(a, client.FQN(ns, k9sShellPodName()), k9sShell)
 }
 
-func sshIn(a *App, fqn, co string) {
+func sshIn(a *App, fqn, co string) error {
 	cl := a.Config.K9s.ActiveCluster()
 	cfg := cl.ShellPod
 	os, err := getPodOS(a.factory, fqn)
@@ -215,23 +217,32 @@ func sshIn(a *App, fqn, co string) {
 		log.Warn().Err(err).Msgf("os detect failed")
 	}
 
-	args := buildShellArgs("exec", fqn, co, a.Conn().Config().Flags().KubeConfig)
-	args = append(args, "--")
+	cmd := shellCommand(os)
 	if len(cfg.Command) > 0 {
-		args = append(args, cfg.Command...)
-		args = append(args, cfg.Args...)
-	} else {
-		if os == windowsOS {
-			args = append(args, "--", powerShell)
+		cmd = append(append([]string{}, cfg.Command...), cfg.Args...)
+	}
+	c := color.New(color.BgGreen).Add(color.FgBlack).Add(color.Bold)
+	if recordSessions(a) {
+		file, err := runRecorded(a, "node-shell", fqn, co, c.Sprintf(bannerFmt, fqn, co), cmd)
+		if err != nil {
+			a.Flash().Errf("Shell exited: %v", err)
+			return err
 		}
-		args = append(args, "sh", "-c", shellCheck)
+		a.Flash().Infof("Node shell session recorded to %s", file)
+		return nil
 	}
+
+	args := buildShellArgs("exec", fqn, co, a.Conn().Config().Flags().KubeConfig)
+	args = append(append(args, "--"), cmd...)
 	log.Debug().Msgf("ARGS %#v", args)
 
-	c := color.New(color.BgGreen).Add(color.FgBlack).Add(color.Bold)
 	if !runK(a, shellOpts{clear: true, banner: c.Sprintf(bannerFmt, fqn, co), args: args}) {
-		a.Flash().Err(errors.New("Shell exec failed"))
+		err := errors.New("Shell exec failed")
+		a.Flash().Err(err)
+		return err
 	}
+
+	return nil
 }
 
 func nukeK9sShell(a *App) error {
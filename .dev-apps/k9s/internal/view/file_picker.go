@@ -0,0 +1,133 @@
+package view
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/derailed/k9s/internal/model"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/tview"
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	pickCurrentDir = "./ (select this directory)"
+	pickParentDir  = "../"
+)
+
+// FilePicker represents a local file picker.
+type FilePicker struct {
+	*tview.List
+
+	app      *App
+	actions  ui.KeyActions
+	dir      string
+	selectFn func(path string)
+}
+
+// NewFilePicker returns a new file picker.
+func NewFilePicker(dir string, fn func(path string)) *FilePicker {
+	return &FilePicker{
+		List:     tview.NewList(),
+		actions:  ui.KeyActions{},
+		dir:      dir,
+		selectFn: fn,
+	}
+}
+
+// Init initializes the view.
+func (f *FilePicker) Init(ctx context.Context) error {
+	app, err := extractApp(ctx)
+	if err != nil {
+		return err
+	}
+	f.app = app
+	f.actions[tcell.KeyEscape] = ui.NewKeyAction("Back", app.PrevCmd, true)
+
+	f.SetBorder(true)
+	f.SetMainTextColor(tcell.ColorWhite)
+	f.ShowSecondaryText(false)
+	f.SetSelectedBackgroundColor(tcell.ColorAqua)
+	f.SetInputCapture(func(evt *tcell.EventKey) *tcell.EventKey {
+		if a, ok := f.actions[evt.Key()]; ok {
+			a.Action(evt)
+			evt = nil
+		}
+		return evt
+	})
+
+	return f.populate(f.dir)
+}
+
+// InCmdMode checks if prompt is active.
+func (*FilePicker) InCmdMode() bool {
+	return false
+}
+
+// Start starts the view.
+func (f *FilePicker) Start() {}
+
+// Stop stops the view.
+func (f *FilePicker) Stop() {}
+
+// Name returns the component name.
+func (f *FilePicker) Name() string { return "filepicker" }
+
+// Hints returns the view hints.
+func (f *FilePicker) Hints() model.MenuHints {
+	return f.actions.Hints()
+}
+
+// ExtraHints returns additional hints.
+func (f *FilePicker) ExtraHints() map[string]string {
+	return nil
+}
+
+func (f *FilePicker) populate(dir string) error {
+	ee, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	sort.Slice(ee, func(i, j int) bool {
+		if ee[i].IsDir() != ee[j].IsDir() {
+			return ee[i].IsDir()
+		}
+		return ee[i].Name() < ee[j].Name()
+	})
+
+	f.dir = dir
+	f.Clear()
+	f.SetTitle(fmt.Sprintf(" [aqua::b]File Picker [white::-]%s ", dir))
+	f.AddItem(pickCurrentDir, "", 0, f.pick(dir))
+	if parent := filepath.Dir(dir); parent != dir {
+		f.AddItem(pickParentDir, "", 0, f.browse(parent))
+	}
+	for _, e := range ee {
+		p := filepath.Join(dir, e.Name())
+		if e.IsDir() {
+			f.AddItem(e.Name()+string(filepath.Separator), "", 0, f.browse(p))
+			continue
+		}
+		f.AddItem(e.Name(), "", 0, f.pick(p))
+	}
+
+	return nil
+}
+
+func (f *FilePicker) browse(dir string) func() {
+	return func() {
+		if err := f.populate(dir); err != nil {
+			f.app.Flash().Err(err)
+		}
+	}
+}
+
+func (f *FilePicker) pick(path string) func() {
+	return func() {
+		f.app.Content.Pop()
+		f.selectFn(path)
+	}
+}
@@ -0,0 +1,262 @@
+package view
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/model"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/tview"
+	"github.com/gdamore/tcell/v2"
+)
+
+const fsTitle = "FileSystem"
+
+var _ model.Component = (*FileSystem)(nil)
+
+// fsNode tracks a file system tree node.
+type fsNode struct {
+	path   string
+	entry  dao.FileEntry
+	loaded bool
+}
+
+func (n *fsNode) isDir() bool {
+	return n.entry.Dir || n.entry.Link != ""
+}
+
+// FileSystem represents a container file system browser.
+type FileSystem struct {
+	*ui.Tree
+
+	app  *App
+	fqn  string
+	co   string
+	root *tview.TreeNode
+}
+
+// NewFileSystem returns a new file system browser.
+func NewFileSystem(fqn, co string) *FileSystem {
+	return &FileSystem{
+		Tree: ui.NewTree(),
+		fqn:  fqn,
+		co:   co,
+	}
+}
+
+// Init initializes the view.
+func (f *FileSystem) Init(ctx context.Context) error {
+	if err := f.Tree.Init(ctx); err != nil {
+		return err
+	}
+	var err error
+	if f.app, err = extractApp(ctx); err != nil {
+		return err
+	}
+
+	f.bindKeys()
+	f.SetBackgroundColor(f.app.Styles.Xray().BgColor.Color())
+	f.SetBorderColor(f.app.Styles.Xray().FgColor.Color())
+	f.SetBorderFocusColor(f.app.Styles.Frame().Border.FocusColor.Color())
+	f.SetGraphicsColor(f.app.Styles.Xray().GraphicColor.Color())
+	f.SetTitle(fmt.Sprintf(" %s [aqua::b]%s:%s ", fsTitle, f.fqn, f.co))
+
+	f.root = tview.NewTreeNode("/").SetReference(&fsNode{path: "/", entry: dao.FileEntry{Name: "/", Dir: true}})
+	f.root.SetColor(tcell.ColorAqua)
+	f.SetRoot(f.root)
+	f.SetCurrentNode(f.root)
+	f.SetSelectedItem("/")
+	f.SetChangedFunc(func(n *tview.TreeNode) {
+		if ref, ok := n.GetReference().(*fsNode); ok {
+			f.SetSelectedItem(ref.path)
+		}
+	})
+	f.SetSelectedFunc(f.selectNode)
+	f.load(f.root)
+
+	return nil
+}
+
+// InCmdMode checks if prompt is active.
+func (*FileSystem) InCmdMode() bool {
+	return false
+}
+
+// Name returns the component name.
+func (f *FileSystem) Name() string { return fsTitle }
+
+// Start starts the view.
+func (f *FileSystem) Start() {}
+
+// Stop stops the view.
+func (f *FileSystem) Stop() {}
+
+func (f *FileSystem) bindKeys() {
+	f.Actions().Delete(ui.KeyX)
+	f.Actions().Add(ui.KeyActions{
+		tcell.KeyEscape: ui.NewKeyAction("Back", f.app.PrevCmd, true),
+		ui.KeyR:         ui.NewKeyAction("Reload", f.reloadCmd, true),
+		ui.KeyO:         ui.NewDangerousKeyAction("Download", f.downloadCmd, true, config.ActionShell),
+	})
+	ns, _ := client.Namespaced(f.fqn)
+	f.Actions().Guard(func(policy string) bool {
+		return f.app.Config.IsActionAllowed(policy, ns)
+	})
+}
+
+func (f *FileSystem) selectedNode() (*tview.TreeNode, *fsNode) {
+	n := f.GetCurrentNode()
+	if n == nil {
+		return nil, nil
+	}
+	ref, ok := n.GetReference().(*fsNode)
+	if !ok {
+		return nil, nil
+	}
+
+	return n, ref
+}
+
+func (f *FileSystem) selectNode(n *tview.TreeNode) {
+	ref, ok := n.GetReference().(*fsNode)
+	if !ok {
+		return
+	}
+	if !ref.isDir() {
+		f.viewFile(ref.path)
+		return
+	}
+	if ref.loaded {
+		n.SetExpanded(!n.IsExpanded())
+		return
+	}
+	f.load(n)
+}
+
+func (f *FileSystem) reloadCmd(evt *tcell.EventKey) *tcell.EventKey {
+	n, ref := f.selectedNode()
+	if n == nil || !ref.isDir() {
+		return evt
+	}
+	f.load(n)
+
+	return nil
+}
+
+func (f *FileSystem) downloadCmd(evt *tcell.EventKey) *tcell.EventKey {
+	_, ref := f.selectedNode()
+	if ref == nil {
+		return evt
+	}
+	downloadPath(f.app, f.fqn, f.co, ref.path)
+
+	return nil
+}
+
+func (f *FileSystem) pod() *dao.Pod {
+	var po dao.Pod
+	po.Init(f.app.factory, client.NewGVR("v1/pods"))
+
+	return &po
+}
+
+func (f *FileSystem) load(n *tview.TreeNode) {
+	ref := n.GetReference().(*fsNode)
+	dir := ref.path
+	if ref.entry.Link != "" {
+		dir += "/"
+	}
+	f.app.Flash().Infof("Listing %s...", ref.path)
+	go func() {
+		ee, err := f.pod().ListDir(f.fqn, f.co, dir)
+		f.app.QueueUpdateDraw(func() {
+			if err != nil {
+				if ref.entry.Link != "" {
+					ref.entry.Link, ref.loaded = "", true
+					f.viewFile(ref.path)
+					return
+				}
+				n.ClearChildren()
+				n.AddChild(tview.NewTreeNode("⚠️  " + err.Error()).SetColor(tcell.ColorOrangeRed).SetSelectable(false))
+				f.app.Flash().Warnf("Unable to browse %s. Try a debug container!", ref.path)
+				return
+			}
+			ref.loaded = true
+			n.ClearChildren()
+			for _, e := range ee {
+				n.AddChild(makeFsNode(e, e.Path(ref.path)))
+			}
+			n.SetExpanded(true)
+			f.app.Flash().Infof("%s: %d entries", ref.path, len(ee))
+		})
+	}()
+}
+
+func (f *FileSystem) viewFile(path string) {
+	f.app.Flash().Infof("Reading %s...", path)
+	go func() {
+		po := f.pod()
+		stat, err := po.Stat(f.fqn, f.co, path)
+		if err != nil {
+			stat = fmt.Sprintf("stat unavailable: %s\n", err)
+		}
+		raw, err := po.ReadFile(f.fqn, f.co, path)
+		f.app.QueueUpdateDraw(func() {
+			if err != nil {
+				f.app.Flash().Err(err)
+				return
+			}
+			if !dao.IsText(raw) {
+				f.app.Flash().Warnf("%s is not a text file. Use download instead.", path)
+				return
+			}
+			content := stat + "\n" + string(raw)
+			if len(raw) >= dao.MaxFileView {
+				content += fmt.Sprintf("\n... truncated to %s", toHumanBytes(dao.MaxFileView))
+			}
+			details := NewDetails(f.app, "File", path, true).Update(content)
+			if err := f.app.inject(details); err != nil {
+				f.app.Flash().Err(err)
+			}
+		})
+	}()
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func makeFsNode(e dao.FileEntry, path string) *tview.TreeNode {
+	n := tview.NewTreeNode(fsNodeText(e)).SetReference(&fsNode{path: path, entry: e})
+	switch {
+	case e.Dir:
+		n.SetColor(tcell.ColorDodgerBlue)
+	case e.Link != "":
+		n.SetColor(tcell.ColorAqua)
+	default:
+		n.SetColor(tcell.ColorWhite)
+	}
+	n.SetExpanded(false)
+
+	return n
+}
+
+func fsNodeText(e dao.FileEntry) string {
+	var b strings.Builder
+	b.WriteString(tview.Escape(e.Name))
+	if e.Dir {
+		b.WriteString("/")
+	}
+	if e.Link != "" {
+		b.WriteString(" -> " + tview.Escape(e.Link))
+	}
+	fmt.Fprintf(&b, " [gray::]%s", e.Mode)
+	if !e.Dir {
+		fmt.Fprintf(&b, " %s", toHumanBytes(e.Size))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,208 @@
+package view
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/k9s/internal/ui/dialog"
+	"github.com/gdamore/tcell/v2"
+)
+
+// HelmHistory presents a helm release history viewer.
+type HelmHistory struct {
+	ResourceViewer
+
+	path string
+}
+
+// NewHelmHistory returns a new viewer.
+func NewHelmHistory(gvr client.GVR) ResourceViewer {
+	h := HelmHistory{
+		ResourceViewer: NewBrowser(gvr),
+	}
+	h.GetTable().SetBorderFocusColor(tcell.ColorMediumSpringGreen)
+	h.GetTable().SetSelectedStyle(tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorMediumSpringGreen).Attributes(tcell.AttrNone))
+	h.AddBindKeysFn(h.bindKeys)
+	h.GetTable().SetEnterFn(h.showManifest)
+	h.GetTable().SetSortCol("REVISION", false)
+
+	return &h
+}
+
+func (h *HelmHistory) bindKeys(aa ui.KeyActions) {
+	aa.Delete(ui.KeyShiftA, ui.KeyShiftN, tcell.KeyCtrlS, tcell.KeyCtrlSpace)
+	aa.Add(ui.KeyActions{
+		ui.KeyD:      ui.NewKeyAction("Diff Manifests", h.diffCmd(false), true),
+		ui.KeyV:      ui.NewKeyAction("Diff Values", h.diffCmd(true), true),
+		ui.KeyN:      ui.NewKeyAction("Notes", h.notesCmd, true),
+		ui.KeyShiftV: ui.NewKeyAction("Sort Revision", h.GetTable().SortColCmd("REVISION", false), false),
+		ui.KeyShiftS: ui.NewKeyAction("Sort Status", h.GetTable().SortColCmd(statusCol, true), false),
+	})
+	if h.App().Config.K9s.IsReadOnly() {
+		return
+	}
+	aa.Add(ui.KeyActions{
+		tcell.KeyCtrlL: ui.NewDangerousKeyAction("Rollback", h.rollbackCmd, true, config.ActionEdit),
+	})
+}
+
+func (h *HelmHistory) accessor() *dao.HelmHistory {
+	var hh dao.HelmHistory
+	hh.Init(h.App().factory, h.GVR())
+
+	return &hh
+}
+
+func (h *HelmHistory) showManifest(app *App, _ ui.Tabular, _, id string) {
+	rev, err := strconv.Atoi(id)
+	if err != nil {
+		app.Flash().Err(err)
+		return
+	}
+	r, err := h.accessor().Revision(h.path, rev)
+	if err != nil {
+		app.Flash().Err(err)
+		return
+	}
+
+	details := NewDetails(app, "Manifest", fmt.Sprintf("%s #%d", h.path, rev), true).Update(r.Manifest)
+	if err := app.inject(details); err != nil {
+		app.Flash().Err(err)
+	}
+}
+
+func (h *HelmHistory) selectedRevision() (int, bool) {
+	id := h.GetTable().GetSelectedItem()
+	if id == "" {
+		return 0, false
+	}
+	rev, err := strconv.Atoi(id)
+	if err != nil {
+		h.App().Flash().Err(err)
+		return 0, false
+	}
+
+	return rev, true
+}
+
+func (h *HelmHistory) notesCmd(evt *tcell.EventKey) *tcell.EventKey {
+	rev, ok := h.selectedRevision()
+	if !ok {
+		return evt
+	}
+	notes, err := h.accessor().Notes(h.path, rev)
+	if err != nil {
+		h.App().Flash().Err(err)
+		return nil
+	}
+
+	details := NewDetails(h.App(), "Notes", fmt.Sprintf("%s #%d", h.path, rev), true).Update(notes)
+	if err := h.App().inject(details); err != nil {
+		h.App().Flash().Err(err)
+	}
+
+	return nil
+}
+
+func (h *HelmHistory) diffCmd(values bool) func(evt *tcell.EventKey) *tcell.EventKey {
+	return func(evt *tcell.EventKey) *tcell.EventKey {
+		ids := h.GetTable().GetSelectedItems()
+		if len(ids) == 0 || ids[0] == "" {
+			return evt
+		}
+		if len(ids) > 2 {
+			h.App().Flash().Warn("Mark at most 2 revisions to diff")
+			return nil
+		}
+
+		revs := make([]int, 0, 2)
+		for _, id := range ids {
+			rev, err := strconv.Atoi(id)
+			if err != nil {
+				h.App().Flash().Err(err)
+				return nil
+			}
+			revs = append(revs, rev)
+		}
+		acc := h.accessor()
+		if len(revs) == 1 {
+			rr, err := acc.History(h.path)
+			if err != nil {
+				h.App().Flash().Err(err)
+				return nil
+			}
+			if len(rr) == 0 {
+				return nil
+			}
+			revs = append(revs, rr[len(rr)-1].Version)
+		}
+		from, to := revs[0], revs[1]
+		if from > to {
+			from, to = to, from
+		}
+		diff, err := acc.Diff(h.path, from, to, values)
+		if err != nil {
+			h.App().Flash().Err(err)
+			return nil
+		}
+		kind, title := "manifest", "Manifest Diff"
+		if values {
+			kind, title = "values", "Values Diff"
+		}
+		if diff == "" {
+			diff = fmt.Sprintf("No %s changes between revisions %d and %d", kind, from, to)
+		}
+
+		details := NewDetails(h.App(), title, fmt.Sprintf("%s #%d..#%d", h.path, from, to), true).Update(diff)
+		if err := h.App().inject(details); err != nil {
+			h.App().Flash().Err(err)
+		}
+
+		return nil
+	}
+}
+
+func (h *HelmHistory) rollbackCmd(evt *tcell.EventKey) *tcell.EventKey {
+	rev, ok := h.selectedRevision()
+	if !ok {
+		return evt
+	}
+
+	msg := fmt.Sprintf("Rollback release %s to revision %d?", h.path, rev)
+	dialog.ShowConfirm(h.App().Styles.Dialog(), h.App().Content.Pages, "Confirm Rollback", msg, func() {
+		h.App().Flash().Infof("Rolling back %s to revision %d...", h.path, rev)
+		go func() {
+			err := h.accessor().Rollback(h.path, rev)
+			h.App().QueueUpdateDraw(func() {
+				auditAction(h.App(), auditRollback, client.NewGVR("helm"), h.path, fmt.Sprintf("revision=%d", rev), err)
+				if err != nil {
+					h.App().Flash().Err(err)
+					return
+				}
+				h.App().Flash().Infof("%s rolled back to revision %d", h.path, rev)
+				h.Refresh()
+			})
+		}()
+	}, func() {})
+
+	return nil
+}
+
+func showHelmHistory(app *App, path string) {
+	v := NewHelmHistory(client.NewGVR("helm-history"))
+	if h, ok := v.(*HelmHistory); ok {
+		h.path = path
+	}
+	v.SetContextFn(func(ctx context.Context) context.Context {
+		return context.WithValue(ctx, internal.KeyPath, path)
+	})
+	if err := app.inject(v); err != nil {
+		app.Flash().Err(err)
+	}
+}
@@ -0,0 +1,36 @@
+package view
+
+import (
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/gdamore/tcell/v2"
+)
+
+// HistoryExtender represents a resource with a rollout history.
+type HistoryExtender struct {
+	ResourceViewer
+}
+
+// NewHistoryExtender returns a new extender.
+func NewHistoryExtender(v ResourceViewer) ResourceViewer {
+	h := HistoryExtender{ResourceViewer: v}
+	v.AddBindKeysFn(h.bindKeys)
+
+	return &h
+}
+
+// BindKeys creates additional menu actions.
+func (h *HistoryExtender) bindKeys(aa ui.KeyActions) {
+	aa.Add(ui.KeyActions{
+		ui.KeyH: ui.NewKeyAction("History", h.historyCmd, true),
+	})
+}
+
+func (h *HistoryExtender) historyCmd(evt *tcell.EventKey) *tcell.EventKey {
+	path := h.GetTable().GetSelectedItem()
+	if path == "" {
+		return evt
+	}
+	showRevisions(h.App(), h.GVR(), path)
+
+	return nil
+}
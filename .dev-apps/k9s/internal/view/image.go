@@ -0,0 +1,80 @@
+package view
+
+import (
+	"context"
+	"strings"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/gdamore/tcell/v2"
+)
+
+// Image presents a cluster container images inventory viewer.
+type Image struct {
+	ResourceViewer
+}
+
+// NewImage returns a new viewer.
+func NewImage(gvr client.GVR) ResourceViewer {
+	i := Image{
+		ResourceViewer: NewBrowser(gvr),
+	}
+	i.AddBindKeysFn(i.bindKeys)
+	i.GetTable().SetEnterFn(i.showConsumers)
+
+	return &i
+}
+
+func (i *Image) bindKeys(aa ui.KeyActions) {
+	aa.Add(ui.KeyActions{
+		ui.KeyShiftW: ui.NewKeyAction("Sort Workloads", i.GetTable().SortColCmd("WORKLOADS", false), false),
+		ui.KeyShiftD: ui.NewKeyAction("Sort Drift", i.GetTable().SortColCmd("DRIFT", false), false),
+	})
+}
+
+func (i *Image) showConsumers(app *App, _ ui.Tabular, _, image string) {
+	v := NewImageConsumer(client.NewGVR("imageconsumers"))
+	v.SetContextFn(func(ctx context.Context) context.Context {
+		return context.WithValue(ctx, internal.KeyPath, image)
+	})
+	if err := app.inject(v); err != nil {
+		app.Flash().Err(err)
+	}
+}
+
+// ImageConsumer presents the workloads using a given container image.
+type ImageConsumer struct {
+	ResourceViewer
+}
+
+// NewImageConsumer returns a new viewer.
+func NewImageConsumer(gvr client.GVR) ResourceViewer {
+	c := ImageConsumer{
+		ResourceViewer: NewBrowser(gvr),
+	}
+	c.AddBindKeysFn(c.bindKeys)
+
+	return &c
+}
+
+func (c *ImageConsumer) bindKeys(aa ui.KeyActions) {
+	aa.Add(ui.KeyActions{
+		tcell.KeyEnter: ui.NewKeyAction("Goto", c.gotoCmd, true),
+		ui.KeyShiftV:   ui.NewKeyAction("Sort GVR", c.GetTable().SortColCmd("GVR", true), false),
+	})
+}
+
+func (c *ImageConsumer) gotoCmd(evt *tcell.EventKey) *tcell.EventKey {
+	id := c.GetTable().GetSelectedItem()
+	if id == "" {
+		return evt
+	}
+	tokens := strings.SplitN(id, ":", 2)
+	if len(tokens) != 2 {
+		return evt
+	}
+	c.App().gotoResource(client.NewGVR(tokens[0]).R(), tokens[1], false)
+
+	return nil
+}
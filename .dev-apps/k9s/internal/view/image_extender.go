@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/derailed/k9s/internal/config"
 	"github.com/derailed/k9s/internal/dao"
 	"github.com/derailed/k9s/internal/ui"
 	"github.com/derailed/tview"
@@ -58,7 +59,7 @@ func (s *ImageExtender) bindKeys(aa ui.KeyActions) {
 		return
 	}
 	aa.Add(ui.KeyActions{
-		ui.KeyI: ui.NewKeyAction("Set Image", s.setImageCmd, false),
+		ui.KeyI: ui.NewDangerousKeyAction("Set Image", s.setImageCmd, false, config.ActionEdit),
 	})
 }
 
@@ -166,6 +167,9 @@ func (s *ImageExtender) getPodSpec(path string) (*corev1.PodSpec, error) {
 }
 
 func (s *ImageExtender) setImages(ctx context.Context, path string, imageSpecs dao.ImageSpecs) error {
+	if err := journalResources(s.App(), dao.JournalEdit, s.GVR(), path); err != nil {
+		return err
+	}
 	res, err := dao.AccessorFor(s.App().factory, s.GVR())
 	if err != nil {
 		return err
@@ -176,5 +180,8 @@ func (s *ImageExtender) setImages(ctx context.Context, path string, imageSpecs d
 		return fmt.Errorf("expecting a scalable resource for %q", s.GVR())
 	}
 
-	return resourceWPodSpec.SetImages(ctx, path, imageSpecs)
+	err = resourceWPodSpec.SetImages(ctx, path, imageSpecs)
+	auditAction(s.App(), auditSetImage, s.GVR(), path, imageDetails(imageSpecs), err)
+
+	return err
 }
@@ -0,0 +1,175 @@
+package view
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/k9s/internal/ui/dialog"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rs/zerolog/log"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Journal presents the resources undo journal viewer.
+type Journal struct {
+	ResourceViewer
+}
+
+// NewJournal returns a new viewer.
+func NewJournal(gvr client.GVR) ResourceViewer {
+	j := Journal{
+		ResourceViewer: NewBrowser(gvr),
+	}
+	j.GetTable().SetBorderFocusColor(tcell.ColorMediumPurple)
+	j.GetTable().SetSelectedStyle(tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorMediumPurple).Attributes(tcell.AttrNone))
+	j.GetTable().SetSortCol(ageCol, true)
+	j.SetContextFn(j.journalContext)
+	j.GetTable().SetEnterFn(j.viewEntry)
+	j.AddBindKeysFn(j.bindKeys)
+
+	return &j
+}
+
+func (j *Journal) bindKeys(aa ui.KeyActions) {
+	aa.Add(ui.KeyActions{
+		ui.KeyShiftK: ui.NewKeyAction("Sort Kind", j.GetTable().SortColCmd("KIND", true), false),
+		ui.KeyShiftT: ui.NewKeyAction("Sort Action", j.GetTable().SortColCmd("ACTION", true), false),
+	})
+	if j.App().Config.K9s.IsReadOnly() {
+		return
+	}
+	aa.Add(ui.KeyActions{
+		ui.KeyR:        ui.NewDangerousKeyAction("Re-create", j.recreateCmd, true, config.ActionEdit),
+		tcell.KeyCtrlL: ui.NewDangerousKeyAction("Rollback", j.rollbackCmd, true, config.ActionEdit),
+	})
+}
+
+func (j *Journal) journalContext(ctx context.Context) context.Context {
+	dir := j.App().Config.K9s.GetJournalDir()
+	config.EnsureFullPath(dir, config.DefaultDirMod)
+
+	return context.WithValue(ctx, internal.KeyDir, dir)
+}
+
+func (j *Journal) viewEntry(app *App, _ ui.Tabular, _, path string) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		app.Flash().Err(err)
+		return
+	}
+	e, err := dao.LoadJournalEntry(path)
+	if err != nil {
+		app.Flash().Err(err)
+		return
+	}
+
+	details := NewDetails(app, "Journal", e.Action+" "+e.Path, true).Update(string(raw))
+	if err := app.inject(details); err != nil {
+		app.Flash().Err(err)
+	}
+}
+
+func (j *Journal) recreateCmd(evt *tcell.EventKey) *tcell.EventKey {
+	return j.restore(evt, "Re-create", auditRecreate, func(ctx context.Context, jo *dao.Journal, path string) error {
+		return jo.Recreate(ctx, path)
+	})
+}
+
+func (j *Journal) rollbackCmd(evt *tcell.EventKey) *tcell.EventKey {
+	return j.restore(evt, "Rollback", auditRollback, func(ctx context.Context, jo *dao.Journal, path string) error {
+		return jo.Rollback(ctx, path)
+	})
+}
+
+func (j *Journal) restore(evt *tcell.EventKey, action, audit string, fn func(context.Context, *dao.Journal, string) error) *tcell.EventKey {
+	path := j.GetTable().GetSelectedItem()
+	if path == "" {
+		return evt
+	}
+	e, err := dao.LoadJournalEntry(path)
+	if err != nil {
+		j.App().Flash().Err(err)
+		return nil
+	}
+
+	msg := fmt.Sprintf("%s %s %s from its %s snapshot?", action, client.NewGVR(e.GVR).R(), e.Path, e.Action)
+	dialog.ShowConfirm(j.App().Styles.Dialog(), j.App().Content.Pages, "Confirm "+action, msg, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), j.App().Conn().Config().CallTimeout())
+		defer cancel()
+
+		var jo dao.Journal
+		jo.Init(j.App().factory, j.GVR())
+		err := fn(ctx, &jo, path)
+		auditAction(j.App(), audit, client.NewGVR(e.GVR), e.Path, "journal="+filepath.Base(path), err)
+		if err != nil {
+			j.App().Flash().Err(err)
+			return
+		}
+		j.App().Flash().Infof("%s %s succeeded", action, e.Path)
+	}, func() {})
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+// journalResources snapshots the given resources prior to a destructive action.
+func journalResources(app *App, action string, gvr client.GVR, paths ...string) error {
+	meta, err := dao.MetaAccess.MetaFor(gvr)
+	if err != nil || !dao.IsK8sMeta(meta) {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), app.Conn().Config().CallTimeout())
+	defer cancel()
+
+	var j dao.Journal
+	j.Init(app.factory, client.NewGVR("journal"))
+	for _, path := range paths {
+		if err := j.Record(ctx, app.Config.K9s.GetJournalDir(), app.Config.K9s.Journal, action, gvr, path); err != nil {
+			return fmt.Errorf("journal %s %s failed: %w", action, path, err)
+		}
+	}
+
+	return nil
+}
+
+// journalManifest snapshots the live resources declared in a manifest prior to
+// an apply or a delete. Resources not yet in the cluster are skipped.
+func journalManifest(app *App, action string, refs []dao.ManifestRef) error {
+	defaultNS, err := app.Conn().Config().CurrentNamespaceName()
+	if err != nil || defaultNS == "" {
+		defaultNS = client.DefaultNamespace
+	}
+	for _, r := range refs {
+		gvr, ok := dao.MetaAccess.GVRFor(r.APIVersion, r.Kind)
+		if !ok {
+			log.Warn().Msgf("Journal skipping unknown resource %s %s", r.APIVersion, r.Kind)
+			continue
+		}
+		meta, err := dao.MetaAccess.MetaFor(gvr)
+		if err != nil {
+			return err
+		}
+		var ns string
+		if meta.Namespaced {
+			if ns = r.Namespace; ns == "" {
+				ns = defaultNS
+			}
+		}
+		err = journalResources(app, action, gvr, client.FQN(ns, r.Name))
+		if err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package view
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/tview"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const metaEditKey = "metaEdit"
+
+var metaPatchTypes = []types.PatchType{
+	types.StrategicMergePatchType,
+	types.JSONPatchType,
+}
+
+// MetaEditFunc represents a labels and annotations edit callback function.
+type MetaEditFunc func(v ResourceViewer, paths []string, edits dao.MetaEdits, pt types.PatchType) error
+
+// ShowMetaEdit pops a labels and annotations edit dialog.
+func ShowMetaEdit(v ResourceViewer, paths []string, m metav1.Object, okFn MetaEditFunc) {
+	styles := v.App().Styles.Dialog()
+
+	f := tview.NewForm()
+	f.SetItemPadding(0)
+	f.SetButtonsAlign(tview.AlignCenter).
+		SetButtonBackgroundColor(styles.ButtonBgColor.Color()).
+		SetButtonTextColor(styles.ButtonFgColor.Color()).
+		SetLabelColor(styles.LabelFgColor.Color()).
+		SetFieldTextColor(styles.FieldFgColor.Color()).
+		SetFieldBackgroundColor(styles.BgColor.Color())
+
+	modal := tview.NewModalForm("<Labels/Annotations>", f)
+	var (
+		labels, annotations string
+		pt                  = metaPatchTypes[0]
+	)
+	preview := func() {
+		modal.SetText(metaEditPreview(paths, m, labels, annotations, pt))
+	}
+	f.AddInputField("Labels:", "", 0, nil, func(s string) {
+		labels = s
+		preview()
+	})
+	f.AddInputField("Annotations:", "", 0, nil, func(s string) {
+		annotations = s
+		preview()
+	})
+	opts := make([]string, 0, len(metaPatchTypes))
+	for _, t := range metaPatchTypes {
+		opts = append(opts, string(t))
+	}
+	f.AddDropDown("Patch:", opts, 0, func(_ string, idx int) {
+		if idx < 0 {
+			return
+		}
+		pt = metaPatchTypes[idx]
+		preview()
+	})
+	for _, l := range []string{"Labels:", "Annotations:"} {
+		if field, ok := f.GetFormItemByLabel(l).(*tview.InputField); ok {
+			field.SetPlaceholder("key=value,key-")
+		}
+	}
+
+	pages := v.App().Content.Pages
+	f.AddButton("OK", func() {
+		edits, err := parseMetaEdits(labels, annotations)
+		if err != nil {
+			v.App().Flash().Err(err)
+			return
+		}
+		if len(edits) == 0 {
+			v.App().Flash().Warn("No labels or annotations changes specified")
+			return
+		}
+		DismissMetaEdit(v, pages)
+		if err := okFn(v, paths, edits, pt); err != nil {
+			v.App().Flash().Err(err)
+		}
+	})
+	f.AddButton("Cancel", func() {
+		DismissMetaEdit(v, pages)
+	})
+	for i := 0; i < 2; i++ {
+		if b := f.GetButton(i); b != nil {
+			b.SetBackgroundColorActivated(styles.ButtonFocusBgColor.Color())
+			b.SetLabelColorActivated(styles.ButtonFocusFgColor.Color())
+		}
+	}
+
+	preview()
+	modal.SetTextColor(styles.FgColor.Color())
+	modal.SetBackgroundColor(styles.BgColor.Color())
+	modal.SetDoneFunc(func(_ int, b string) {
+		DismissMetaEdit(v, pages)
+	})
+
+	pages.AddPage(metaEditKey, modal, false, true)
+	pages.ShowPage(metaEditKey)
+	v.App().SetFocus(pages.GetPrimitive(metaEditKey))
+}
+
+// DismissMetaEdit dismiss the labels and annotations dialog.
+func DismissMetaEdit(v ResourceViewer, p *ui.Pages) {
+	p.RemovePage(metaEditKey)
+	v.App().SetFocus(p.CurrentPage().Item)
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func parseMetaEdits(labels, annotations string) (dao.MetaEdits, error) {
+	ll, err := dao.ParseMetaEdits(dao.LabelsMeta, labels)
+	if err != nil {
+		return nil, err
+	}
+	aa, err := dao.ParseMetaEdits(dao.AnnotationsMeta, annotations)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(ll, aa...), nil
+}
+
+func metaEditPreview(paths []string, m metav1.Object, labels, annotations string, pt types.PatchType) string {
+	msg := paths[0]
+	if len(paths) > 1 {
+		msg = fmt.Sprintf("%d marked resources", len(paths))
+	} else {
+		msg += "\n\nLabels:\n" + dumpMeta(m.GetLabels())
+	}
+
+	edits, err := parseMetaEdits(labels, annotations)
+	if err != nil {
+		return msg + "\n\nError:\n" + err.Error()
+	}
+	if len(edits) == 0 {
+		return msg
+	}
+	patch, err := edits.Patch(pt, m)
+	if err != nil {
+		return msg + "\n\nError:\n" + err.Error()
+	}
+	msg += "\n\nChanges:\n" + edits.Dump() + "\n\nPatch:\n" + string(patch)
+	if len(paths) > 1 && pt == types.JSONPatchType {
+		msg += "\n(patch computed for " + paths[0] + ")"
+	}
+
+	return msg
+}
+
+func dumpMeta(m map[string]string) string {
+	if len(m) == 0 {
+		return "<none>"
+	}
+	kk := make([]string, 0, len(m))
+	for k, v := range m {
+		kk = append(kk, k+"="+v)
+	}
+	sort.Strings(kk)
+
+	return strings.Join(kk, "\n")
+}
@@ -0,0 +1,152 @@
+package view
+
+import (
+	"strings"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/tview"
+	"github.com/gdamore/tcell/v2"
+)
+
+const netpolDialogKey = "netpol-sim"
+
+// NetworkPolicy presents a network policy viewer.
+type NetworkPolicy struct {
+	ResourceViewer
+}
+
+// NewNetworkPolicy returns a new viewer.
+func NewNetworkPolicy(gvr client.GVR) ResourceViewer {
+	n := NetworkPolicy{
+		ResourceViewer: NewBrowser(gvr),
+	}
+	n.AddBindKeysFn(n.bindKeys)
+
+	return &n
+}
+
+func (n *NetworkPolicy) bindKeys(aa ui.KeyActions) {
+	aa.Add(ui.KeyActions{
+		ui.KeyS: ui.NewKeyAction("Simulate", n.simulateCmd, true),
+		ui.KeyM: ui.NewKeyAction("Matrix", n.matrixCmd, true),
+	})
+}
+
+func (n *NetworkPolicy) simulateCmd(evt *tcell.EventKey) *tcell.EventKey {
+	var src, dst, port string
+	if ns := n.GetTable().GetSelectedItem(); ns != "" {
+		src, _ = client.Namespaced(ns)
+	}
+	f := n.makeForm()
+	f.AddInputField("Source:", src, 50, nil, func(changed string) {
+		src = changed
+	})
+	f.AddInputField("Destination:", "", 50, nil, func(changed string) {
+		dst = changed
+	})
+	f.AddInputField("Port:", "", 20, nil, func(changed string) {
+		port = changed
+	})
+	f.AddButton("OK", func() {
+		n.dismissDialog()
+		n.simulate(strings.TrimSpace(src), strings.TrimSpace(dst), port)
+	})
+	n.showDialog(f, "<Simulate>", "Source pod (ns/name) or namespace, destination pod (ns/name) and port[/protocol]")
+
+	return nil
+}
+
+func (n *NetworkPolicy) matrixCmd(evt *tcell.EventKey) *tcell.EventKey {
+	ns := n.App().Config.ActiveNamespace()
+	if !client.IsNamespaced(ns) {
+		ns = client.DefaultNamespace
+	}
+	var port string
+	f := n.makeForm()
+	f.AddInputField("Namespace:", ns, 40, nil, func(changed string) {
+		ns = changed
+	})
+	f.AddInputField("Port:", "", 20, nil, func(changed string) {
+		port = changed
+	})
+	f.AddButton("OK", func() {
+		n.dismissDialog()
+		n.matrix(strings.TrimSpace(ns), port)
+	})
+	n.showDialog(f, "<Matrix>", "Pods reachability in a namespace on a port[/protocol]. Leave the port blank for any port")
+
+	return nil
+}
+
+func (n *NetworkPolicy) makeForm() *tview.Form {
+	f := tview.NewForm()
+	f.SetItemPadding(0)
+	f.SetButtonsAlign(tview.AlignCenter).
+		SetButtonBackgroundColor(tview.Styles.PrimitiveBackgroundColor).
+		SetButtonTextColor(tview.Styles.PrimaryTextColor).
+		SetLabelColor(tcell.ColorAqua).
+		SetFieldTextColor(tcell.ColorOrange)
+
+	return f
+}
+
+func (n *NetworkPolicy) showDialog(f *tview.Form, title, msg string) {
+	f.AddButton("Cancel", func() {
+		n.dismissDialog()
+	})
+	modal := tview.NewModalForm(title, f)
+	modal.SetText(msg)
+	modal.SetDoneFunc(func(int, string) {
+		n.dismissDialog()
+	})
+	n.App().Content.AddPage(netpolDialogKey, modal, false, false)
+	n.App().Content.ShowPage(netpolDialogKey)
+}
+
+func (n *NetworkPolicy) dismissDialog() {
+	n.App().Content.RemovePage(netpolDialogKey)
+}
+
+func (n *NetworkPolicy) simulate(src, dst, port string) {
+	var sim dao.NetPolSim
+	sim.Init(n.App().factory, n.GVR())
+	n.App().Flash().Infof("Simulating %s → %s...", src, dst)
+	go func() {
+		var report string
+		r, err := sim.Simulate(src, dst, port)
+		if err == nil {
+			report = r.String()
+		}
+		n.showReport(err, "Simulate", src+" → "+dst, report)
+	}()
+}
+
+func (n *NetworkPolicy) matrix(ns, port string) {
+	var sim dao.NetPolSim
+	sim.Init(n.App().factory, n.GVR())
+	n.App().Flash().Infof("Computing %s reachability matrix...", ns)
+	go func() {
+		var report string
+		m, err := sim.Matrix(ns, port)
+		if err == nil {
+			report = m.String()
+		}
+		n.showReport(err, "Matrix", ns, report)
+	}()
+}
+
+// showReport injects a simulation report from a background routine.
+func (n *NetworkPolicy) showReport(err error, title, subject, report string) {
+	n.App().QueueUpdateDraw(func() {
+		if err != nil {
+			n.App().Flash().Err(err)
+			return
+		}
+		details := NewDetails(n.App(), title, subject, true).Update(report)
+		if err := n.App().inject(details); err != nil {
+			n.App().Flash().Err(err)
+		}
+	})
+}
@@ -0,0 +1,17 @@
+package view_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/view"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkPolicyNew(t *testing.T) {
+	n := view.NewNetworkPolicy(client.NewGVR("networking.k8s.io/v1/networkpolicies"))
+
+	assert.Nil(t, n.Init(makeCtx()))
+	assert.Equal(t, "NetworkPolicy", n.Name())
+	assert.Equal(t, 7, len(n.Hints()))
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
 	"github.com/derailed/k9s/internal/dao"
 	"github.com/derailed/k9s/internal/ui"
 	"github.com/derailed/k9s/internal/ui/dialog"
@@ -34,14 +35,14 @@ func NewNode(gvr client.GVR) ResourceViewer {
 
 func (n *Node) bindDangerousKeys(aa ui.KeyActions) {
 	aa.Add(ui.KeyActions{
-		ui.KeyC: ui.NewKeyAction("Cordon", n.toggleCordonCmd(true), true),
-		ui.KeyU: ui.NewKeyAction("Uncordon", n.toggleCordonCmd(false), true),
-		ui.KeyR: ui.NewKeyAction("Drain", n.drainCmd, true),
+		ui.KeyC: ui.NewDangerousKeyAction("Cordon", n.toggleCordonCmd(true), true, config.ActionDrain),
+		ui.KeyU: ui.NewDangerousKeyAction("Uncordon", n.toggleCordonCmd(false), true, config.ActionDrain),
+		ui.KeyR: ui.NewDangerousKeyAction("Drain", n.drainCmd, true, config.ActionDrain),
 	})
 	cl := n.App().Config.K9s.CurrentCluster
 	if n.App().Config.K9s.Clusters[cl].FeatureGates.NodeShell {
 		aa.Add(ui.KeyActions{
-			ui.KeyS: ui.NewKeyAction("Shell", n.sshCmd, true),
+			ui.KeyS: ui.NewDangerousKeyAction("Shell", n.sshCmd, true, config.ActionShell),
 		})
 	}
 }
@@ -58,6 +59,24 @@ func (n *Node) bindKeys(aa ui.KeyActions) {
 		ui.KeyShiftC: ui.NewKeyAction("Sort CPU", n.GetTable().SortColCmd(cpuCol, false), false),
 		ui.KeyShiftM: ui.NewKeyAction("Sort MEM", n.GetTable().SortColCmd(memCol, false), false),
 	})
+	if trendsEnabled(n.App()) {
+		aa.Add(ui.KeyActions{
+			ui.KeyT: ui.NewKeyAction("Trends", n.trendsCmd, true),
+		})
+	}
+}
+
+func (n *Node) trendsCmd(evt *tcell.EventKey) *tcell.EventKey {
+	path := n.GetTable().GetSelectedItem()
+	if path == "" {
+		return evt
+	}
+	_, name := client.Namespaced(path)
+	showTrends(n.App(), path, []string{dao.TrendNodeKey(name)}, func(ss []dao.TrendSample, now time.Time) string {
+		return nodeTrends(ss, name, now)
+	})
+
+	return nil
 }
 
 func (n *Node) showPods(a *App, _ ui.Tabular, _, path string) {
@@ -94,7 +113,9 @@ func drainNode(v ResourceViewer, path string, opts dao.DrainOptions) {
 	}
 
 	buff := bytes.NewBufferString("")
-	if err := m.Drain(path, opts, buff); err != nil {
+	err = m.Drain(path, opts, buff)
+	auditAction(v.App(), auditDrain, v.GVR(), path, fmt.Sprintf("force=%t deleteEmptyDirData=%t ignoreDaemonSets=%t", opts.Force, opts.DeleteEmptyDirData, opts.IgnoreAllDaemonSets), err)
+	if err != nil {
 		v.App().Flash().Err(err)
 		return
 	}
@@ -132,7 +153,13 @@ func (n *Node) toggleCordonCmd(cordon bool) func(evt *tcell.EventKey) *tcell.Eve
 				n.App().Flash().Err(fmt.Errorf("expecting a maintainer for %q", n.GVR()))
 				return
 			}
-			if err := m.ToggleCordon(path, cordon); err != nil {
+			err = m.ToggleCordon(path, cordon)
+			action := auditCordon
+			if !cordon {
+				action = auditUncordon
+			}
+			auditAction(n.App(), action, n.GVR(), path, "", err)
+			if err != nil {
 				n.App().Flash().Err(err)
 			}
 			n.Refresh()
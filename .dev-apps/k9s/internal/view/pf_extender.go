@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
 	"github.com/derailed/k9s/internal/dao"
 	"github.com/derailed/k9s/internal/port"
 	"github.com/derailed/k9s/internal/ui"
@@ -32,7 +34,7 @@ func NewPortForwardExtender(r ResourceViewer) ResourceViewer {
 
 func (p *PortForwardExtender) bindKeys(aa ui.KeyActions) {
 	aa.Add(ui.KeyActions{
-		ui.KeyShiftF: ui.NewKeyAction("Port-Forward", p.portFwdCmd, true),
+		ui.KeyShiftF: ui.NewDangerousKeyAction("Port-Forward", p.portFwdCmd, true, config.ActionPortForward),
 	})
 }
 
@@ -114,6 +116,7 @@ func startFwdCB(v ResourceViewer, path string, pts port.PortTunnels) error {
 		}
 		pf := dao.NewPortForwarder(v.App().factory)
 		fwd, err := pf.Start(path, pt)
+		auditAction(v.App(), auditPortForward, client.NewGVR("v1/pods"), path, fmt.Sprintf("container=%s %s:%s->%s", pt.Container, pt.Address, pt.LocalPort, pt.ContainerPort), err)
 		if err != nil {
 			return err
 		}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/derailed/k9s/internal"
 	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
 	"github.com/derailed/k9s/internal/dao"
 	"github.com/derailed/k9s/internal/model"
 	"github.com/derailed/k9s/internal/render"
@@ -61,9 +62,10 @@ func (p *Pod) portForwardIndicator(data *render.TableData) {
 
 func (p *Pod) bindDangerousKeys(aa ui.KeyActions) {
 	aa.Add(ui.KeyActions{
-		tcell.KeyCtrlK: ui.NewKeyAction("Kill", p.killCmd, true),
-		ui.KeyS:        ui.NewKeyAction("Shell", p.shellCmd, true),
-		ui.KeyA:        ui.NewKeyAction("Attach", p.attachCmd, true),
+		tcell.KeyCtrlK: ui.NewDangerousKeyAction("Kill", p.killCmd, true, config.ActionDelete),
+		ui.KeyS:        ui.NewDangerousKeyAction("Shell", p.shellCmd, true, config.ActionShell),
+		ui.KeyA:        ui.NewDangerousKeyAction("Attach", p.attachCmd, true, config.ActionShell),
+		ui.KeyB:        ui.NewDangerousKeyAction("Debug", p.debugCmd, true, config.ActionShell),
 	})
 }
 
@@ -200,7 +202,13 @@ func (p *Pod) killCmd(evt *tcell.EventKey) *tcell.EventKey {
 	}
 	p.GetTable().ShowDeleted()
 	for _, path := range selections {
-		if err := nuker.Delete(context.Background(), path, nil, true); err != nil {
+		if err := journalResources(p.App(), dao.JournalDelete, p.GVR(), path); err != nil {
+			p.App().Flash().Err(err)
+			continue
+		}
+		err := nuker.Delete(context.Background(), path, nil, true)
+		auditAction(p.App(), auditKill, p.GVR(), path, "", err)
+		if err != nil {
 			p.App().Flash().Errf("Delete failed with %s", err)
 		} else {
 			p.App().factory.DeleteForwarder(path)
@@ -248,6 +256,24 @@ func (p *Pod) attachCmd(evt *tcell.EventKey) *tcell.EventKey {
 	return nil
 }
 
+func (p *Pod) debugCmd(evt *tcell.EventKey) *tcell.EventKey {
+	path := p.GetTable().GetSelectedItem()
+	if path == "" {
+		return evt
+	}
+
+	if !podIsRunning(p.App().factory, path) {
+		p.App().Flash().Errf("%s is not in a running state", path)
+		return nil
+	}
+
+	if err := containerDebugIn(p.App(), p, path, ""); err != nil {
+		p.App().Flash().Err(err)
+	}
+
+	return nil
+}
+
 // ----------------------------------------------------------------------------
 // Helpers...
 
@@ -287,12 +313,23 @@ func shellIn(a *App, fqn, co string) {
 	if err != nil {
 		log.Warn().Err(err).Msgf("os detect failed")
 	}
+	c := color.New(color.BgGreen).Add(color.FgBlack).Add(color.Bold)
+	if recordSessions(a) {
+		file, err := runRecorded(a, "shell", fqn, co, c.Sprintf(bannerFmt, fqn, co), shellCommand(os))
+		if err != nil {
+			a.Flash().Errf("Shell exited: %v", err)
+		}
+		auditAction(a, auditShell, client.NewGVR("v1/pods"), fqn, "container="+co+" recording="+file, err)
+		return
+	}
 	args := computeShellArgs(fqn, co, a.Conn().Config().Flags().KubeConfig, os)
 
-	c := color.New(color.BgGreen).Add(color.FgBlack).Add(color.Bold)
+	var execErr error
 	if !runK(a, shellOpts{clear: true, banner: c.Sprintf(bannerFmt, fqn, co), args: args}) {
-		a.Flash().Err(errors.New("Shell exec failed"))
+		execErr = errors.New("Shell exec failed")
+		a.Flash().Err(execErr)
 	}
+	auditAction(a, auditShell, client.NewGVR("v1/pods"), fqn, "container="+co, execErr)
 }
 
 func containerAttachIn(a *App, comp model.Component, path, co string) error {
@@ -330,19 +367,36 @@ func resumeAttachIn(a *App, c model.Component, path, co string) {
 }
 
 func attachIn(a *App, path, co string) {
-	args := buildShellArgs("attach", path, co, a.Conn().Config().Flags().KubeConfig)
 	c := color.New(color.BgGreen).Add(color.FgBlack).Add(color.Bold)
+	if recordSessions(a) {
+		file, err := runRecorded(a, "attach", path, co, c.Sprintf(bannerFmt, path, co), nil)
+		if err != nil {
+			a.Flash().Errf("Attach exited: %v", err)
+		}
+		auditAction(a, auditAttach, client.NewGVR("v1/pods"), path, "container="+co+" recording="+file, err)
+		return
+	}
+	args := buildShellArgs("attach", path, co, a.Conn().Config().Flags().KubeConfig)
+	var err error
 	if !runK(a, shellOpts{clear: true, banner: c.Sprintf(bannerFmt, path, co), args: args}) {
-		a.Flash().Err(errors.New("Attach exec failed"))
+		err = errors.New("Attach exec failed")
+		a.Flash().Err(err)
 	}
+	auditAction(a, auditAttach, client.NewGVR("v1/pods"), path, "container="+co, err)
 }
 
 func computeShellArgs(path, co string, kcfg *string, os string) []string {
 	args := buildShellArgs("exec", path, co, kcfg)
+
+	return append(append(args, "--"), shellCommand(os)...)
+}
+
+func shellCommand(os string) []string {
 	if os == windowsOS {
-		return append(args, "--", powerShell)
+		return []string{powerShell}
 	}
-	return append(args, "--", "sh", "-c", shellCheck)
+
+	return []string{"sh", "-c", shellCheck}
 }
 
 func buildShellArgs(cmd, path, co string, kcfg *string) []string {
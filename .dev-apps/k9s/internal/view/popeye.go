@@ -8,11 +8,14 @@ import (
 
 	"github.com/derailed/k9s/internal"
 	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
 	"github.com/derailed/k9s/internal/render"
 	"github.com/derailed/k9s/internal/ui"
 	"github.com/gdamore/tcell/v2"
 )
 
+const popeyeHistoryRuns = 20
+
 // Popeye represents a sanitizer view.
 type Popeye struct {
 	ResourceViewer
@@ -28,6 +31,7 @@ func NewPopeye(gvr client.GVR) ResourceViewer {
 	p.GetTable().SetSortCol("SCORE%", true)
 	p.GetTable().SetDecorateFn(p.decorateRows)
 	p.AddBindKeysFn(p.bindKeys)
+	p.SetContextFn(p.popeyeContext)
 
 	return &p
 }
@@ -60,9 +64,11 @@ func (p *Popeye) decorateRows(data *render.TableData) {
 }
 
 func (p *Popeye) bindKeys(aa ui.KeyActions) {
-	aa.Delete(ui.KeyShiftA, ui.KeyShiftN, tcell.KeyCtrlS, tcell.KeyCtrlSpace, ui.KeySpace)
+	aa.Delete(ui.KeyShiftA, ui.KeyShiftN, tcell.KeyCtrlSpace, ui.KeySpace)
 	aa.Add(ui.KeyActions{
 		tcell.KeyEnter: ui.NewKeyAction("Goto", p.gotoCmd, true),
+		tcell.KeyCtrlS: ui.NewKeyAction("Export", p.exportCmd, true),
+		ui.KeyH:        ui.NewKeyAction("History", p.historyCmd, true),
 		ui.KeyShiftR:   ui.NewKeyAction("Sort Resource", p.GetTable().SortColCmd("RESOURCE", true), false),
 		ui.KeyShiftS:   ui.NewKeyAction("Sort Score", p.GetTable().SortColCmd("SCORE%", true), false),
 		ui.KeyShiftO:   ui.NewKeyAction("Sort OK", p.GetTable().SortColCmd("OK", true), false),
@@ -86,6 +92,28 @@ func (p *Popeye) gotoCmd(evt *tcell.EventKey) *tcell.EventKey {
 	return nil
 }
 
+func (p *Popeye) popeyeContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, internal.KeyDir, p.runsDir())
+}
+
+func (p *Popeye) runsDir() string {
+	return p.App().Config.K9s.GetPopeyeDir(p.GetTable().GetModel().GetNamespace())
+}
+
+func (p *Popeye) historyCmd(evt *tcell.EventKey) *tcell.EventKey {
+	rr, err := dao.NewPopeyeStore(p.runsDir()).Runs(popeyeHistoryRuns)
+	if err != nil {
+		p.App().Flash().Err(err)
+		return nil
+	}
+	details := NewDetails(p.App(), "History", "popeye", true).Update(dao.PopeyeHistory(rr))
+	if err := p.App().inject(details); err != nil {
+		p.App().Flash().Err(err)
+	}
+
+	return nil
+}
+
 func sanitizerCtx(path string) ContextFunc {
 	return func(ctx context.Context) context.Context {
 		ctx = context.WithValue(ctx, internal.KeyPath, path)
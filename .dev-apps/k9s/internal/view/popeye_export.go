@@ -0,0 +1,140 @@
+package view
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/watch"
+	"github.com/derailed/tview"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rs/zerolog/log"
+)
+
+const popeyeExportDialogKey = "popeye-export"
+
+// RunPopeye sanitizes the cluster without launching the UI and writes out the
+// run in the given format. When save is set the run is added to the runs
+// history. The previous run, if any, is returned for comparison.
+func RunPopeye(cfg *config.Config, ns, format string, save bool, w io.Writer) (*dao.PopeyeRun, *dao.PopeyeRun, error) {
+	conn := cfg.GetConnection()
+	if conn == nil || !conn.ConnectionOK() {
+		return nil, nil, errors.New("no cluster connection")
+	}
+	ns = client.CleanseNamespace(ns)
+	f := watch.NewFactory(conn)
+	f.Start(ns)
+	defer f.Terminate()
+
+	run, err := dao.NewPopeye(f).Sanitize(context.Background(), ns)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := dao.ExportPopeye(w, run, format); err != nil {
+		return nil, nil, err
+	}
+
+	store := dao.NewPopeyeStore(cfg.K9s.GetPopeyeDir(ns))
+	rr, err := store.Runs(1)
+	if err != nil {
+		return nil, nil, err
+	}
+	if save {
+		if _, err := store.Save(run); err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(rr) == 0 {
+		return run, nil, nil
+	}
+
+	return run, rr[0], nil
+}
+
+func (p *Popeye) exportCmd(evt *tcell.EventKey) *tcell.EventKey {
+	f := tview.NewForm()
+	f.SetItemPadding(0)
+	f.SetButtonsAlign(tview.AlignCenter).
+		SetButtonBackgroundColor(tview.Styles.PrimitiveBackgroundColor).
+		SetButtonTextColor(tview.Styles.PrimaryTextColor).
+		SetLabelColor(tcell.ColorAqua).
+		SetFieldTextColor(tcell.ColorOrange)
+
+	ff := dao.PopeyeExportFormats()
+	format := ff[0]
+	f.AddDropDown("Format:", ff, 0, func(opt string, _ int) {
+		format = opt
+	})
+	f.AddButton("OK", func() {
+		p.dismissExportDialog()
+		p.export(format)
+	})
+	f.AddButton("Cancel", func() {
+		p.dismissExportDialog()
+	})
+
+	modal := tview.NewModalForm("<Export>", f)
+	modal.SetText("Export the latest sanitizer run")
+	modal.SetDoneFunc(func(int, string) {
+		p.dismissExportDialog()
+	})
+	p.App().Content.AddPage(popeyeExportDialogKey, modal, false, false)
+	p.App().Content.ShowPage(popeyeExportDialogKey)
+
+	return nil
+}
+
+func (p *Popeye) dismissExportDialog() {
+	p.App().Content.RemovePage(popeyeExportDialogKey)
+}
+
+func (p *Popeye) export(format string) {
+	rr, err := dao.NewPopeyeStore(p.runsDir()).Runs(1)
+	if err != nil {
+		p.App().Flash().Err(err)
+		return
+	}
+	if len(rr) == 0 {
+		p.App().Flash().Warn("No sanitizer run recorded yet!")
+		return
+	}
+	path, err := savePopeye(p.App().Config.K9s.GetScreenDumpDir(), p.App().Config.K9s.CurrentContextDir(), format, rr[0])
+	if err != nil {
+		p.App().Flash().Err(err)
+		return
+	}
+	p.App().Flash().Infof("Popeye run exported to %s", path)
+}
+
+func savePopeye(screenDumpDir, context, format string, run *dao.PopeyeRun) (string, error) {
+	dir := filepath.Join(screenDumpDir, context)
+	if err := ensureDir(dir); err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("popeye-%d%s", run.Time.Unix(), dao.PopeyeExportExt(format))
+	path := filepath.Join(dir, strings.ToLower(name))
+	log.Debug().Msgf("Saving popeye run to %s", path)
+
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err := out.Close(); err != nil {
+			log.Error().Err(err).Msg("Closing file")
+		}
+	}()
+
+	if err := dao.ExportPopeye(out, run, format); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
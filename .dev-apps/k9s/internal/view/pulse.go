@@ -2,8 +2,10 @@ package view
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"image"
+	"time"
 
 	"github.com/derailed/tview"
 	"github.com/gdamore/tcell/v2"
@@ -13,6 +15,7 @@ import (
 	"github.com/derailed/k9s/internal"
 	"github.com/derailed/k9s/internal/client"
 	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
 	"github.com/derailed/k9s/internal/health"
 	"github.com/derailed/k9s/internal/model"
 	"github.com/derailed/k9s/internal/render"
@@ -63,6 +66,7 @@ type Pulse struct {
 	cancelFn context.CancelFunc
 	actions  ui.KeyActions
 	charts   []Graphable
+	tiles    []config.PulseTile
 }
 
 // NewPulse returns a new alias view.
@@ -85,21 +89,23 @@ func (p *Pulse) Init(ctx context.Context) error {
 		return err
 	}
 
-	p.charts = []Graphable{
-		p.makeGA(image.Point{X: 0, Y: 0}, image.Point{X: 2, Y: 2}, "apps/v1/deployments"),
-		p.makeGA(image.Point{X: 0, Y: 2}, image.Point{X: 2, Y: 2}, "apps/v1/replicasets"),
-		p.makeGA(image.Point{X: 0, Y: 4}, image.Point{X: 2, Y: 2}, "apps/v1/statefulsets"),
-		p.makeGA(image.Point{X: 0, Y: 6}, image.Point{X: 2, Y: 2}, "apps/v1/daemonsets"),
-		p.makeSP(image.Point{X: 2, Y: 0}, image.Point{X: 3, Y: 2}, "v1/pods"),
-		p.makeSP(image.Point{X: 2, Y: 2}, image.Point{X: 3, Y: 2}, "v1/events"),
-		p.makeSP(image.Point{X: 2, Y: 4}, image.Point{X: 3, Y: 2}, "batch/v1/jobs"),
-		p.makeSP(image.Point{X: 2, Y: 6}, image.Point{X: 3, Y: 2}, "v1/persistentvolumes"),
+	cfg := loadPulseConfig(p.app)
+	if p.tiles = cfg.Tiles; len(p.tiles) == 0 {
+		return errors.New("no pulse tiles to display")
 	}
-	if p.app.Conn().HasMetrics() {
-		p.charts = append(p.charts,
-			p.makeSP(image.Point{X: 5, Y: 0}, image.Point{X: 2, Y: 4}, "cpu"),
-			p.makeSP(image.Point{X: 5, Y: 4}, image.Point{X: 2, Y: 4}, "mem"),
-		)
+	p.model.SetTiles(p.tiles)
+
+	p.charts = make([]Graphable, 0, len(p.tiles))
+	for i, r := range pulseLayout(cfg.Columns, p.tiles) {
+		loc, span := image.Point{X: r.Min.Y, Y: r.Min.X}, image.Point{X: r.Dy(), Y: r.Dx()}
+		switch p.tiles[i].Chart {
+		case config.PulseGauge:
+			p.charts = append(p.charts, p.makeGA(loc, span, p.tiles[i]))
+		case config.PulseDotMatrix:
+			p.charts = append(p.charts, p.makeDG(loc, span, p.tiles[i]))
+		default:
+			p.charts = append(p.charts, p.makeSP(loc, span, p.tiles[i]))
+		}
 	}
 	p.bindKeys()
 	p.model.AddListener(p)
@@ -134,9 +140,10 @@ func (p *Pulse) StylesChanged(s *config.Styles) {
 }
 
 const (
-	genFmat = " %s([%s::]%d[white::]:[%s::b]%d[-::])"
-	cpuFmt  = " %s [%s::b]%s[white::-]([%s::]%sm[white::]/[%s::]%sm[-::])"
-	memFmt  = " %s [%s::b]%s[white::-]([%s::]%sMi[white::]/[%s::]%sMi[-::])"
+	genFmat  = " %s([%s::]%d[white::]:[%s::b]%d[-::])"
+	warnFmat = " %s([%s::]%d[white::]:[orange::]%d[white::]:[%s::b]%d[-::])"
+	cpuFmt   = " %s [%s::b]%s[white::-]([%s::]%sm[white::]/[%s::]%sm[-::])"
+	memFmt   = " %s [%s::b]%s[white::-]([%s::]%sMi[white::]/[%s::]%sMi[-::])"
 )
 
 // PulseChanged notifies the model data changed.
@@ -151,6 +158,7 @@ func (p *Pulse) PulseChanged(c *health.Check) {
 		return
 	}
 
+	tile := p.tiles[index]
 	nn := v.GetSeriesColorNames()
 	if c.Tally(health.S1) == 0 {
 		nn[0] = "gray"
@@ -160,8 +168,9 @@ func (p *Pulse) PulseChanged(c *health.Check) {
 	}
 
 	gvr := client.NewGVR(c.GVR)
+	metric := tchart.Metric{S1: c.Tally(health.S1), S2: c.Tally(health.S2), S3: c.Tally(health.S3)}
 	switch c.GVR {
-	case "cpu":
+	case config.PulseCPU:
 		perc := client.ToPercentage(c.Tally(health.S1), c.Tally(health.S2))
 		v.SetLegend(fmt.Sprintf(cpuFmt,
 			cases.Title(language.Und, cases.NoLower).String(gvr.R()),
@@ -172,7 +181,7 @@ func (p *Pulse) PulseChanged(c *health.Check) {
 			nn[1],
 			render.AsThousands(c.Tally(health.S2)),
 		))
-	case "mem":
+	case config.PulseMEM:
 		perc := client.ToPercentage(c.Tally(health.S1), c.Tally(health.S2))
 		v.SetLegend(fmt.Sprintf(memFmt,
 			cases.Title(language.Und, cases.NoLower).String(gvr.R()),
@@ -184,15 +193,30 @@ func (p *Pulse) PulseChanged(c *health.Check) {
 			render.AsThousands(c.Tally(health.S2)),
 		))
 	default:
-		v.SetLegend(fmt.Sprintf(genFmat,
-			cases.Title(language.Und, cases.NoLower).String(gvr.R()),
-			nn[0],
-			c.Tally(health.S1),
-			nn[1],
-			c.Tally(health.S2),
-		))
+		if len(tile.Warning) > 0 {
+			v.SetLegend(fmt.Sprintf(warnFmat,
+				pulseTitleFor(tile),
+				nn[0],
+				c.Tally(health.S1),
+				c.Tally(health.S3),
+				nn[1],
+				c.Tally(health.S2),
+			))
+		} else {
+			v.SetLegend(fmt.Sprintf(genFmat,
+				pulseTitleFor(tile),
+				nn[0],
+				c.Tally(health.S1),
+				nn[1],
+				c.Tally(health.S2),
+			))
+		}
+		// Only dot matrices chart warnings. Others lump them in with the faults.
+		if tile.Chart != config.PulseDotMatrix {
+			metric.S2, metric.S3 = metric.S2+metric.S3, 0
+		}
 	}
-	v.Add(tchart.Metric{S1: c.Tally(health.S1), S2: c.Tally(health.S2)})
+	v.Add(metric)
 }
 
 // PulseFailed notifies the load failed.
@@ -206,10 +230,16 @@ func (p *Pulse) bindKeys() {
 		tcell.KeyTab:     ui.NewKeyAction("Next", p.nextFocusCmd(1), true),
 		tcell.KeyBacktab: ui.NewKeyAction("Prev", p.nextFocusCmd(-1), true),
 	})
+	if trendsEnabled(p.app) {
+		p.actions[ui.KeyT] = ui.NewKeyAction("Trends", p.trendsCmd, true)
+	}
 
-	for i, v := range p.charts {
-		t := cases.Title(language.Und, cases.NoLower).String(client.NewGVR(v.ID()).R())
-		p.actions[ui.NumKeys[i]] = ui.NewKeyAction(t, p.sparkFocusCmd(i), true)
+	for i, t := range p.tiles {
+		k, ok := ui.NumKeys[i]
+		if !ok {
+			break
+		}
+		p.actions[k] = ui.NewKeyAction(pulseTitleFor(t), p.sparkFocusCmd(i), true)
 	}
 }
 
@@ -310,8 +340,8 @@ func (p *Pulse) enterCmd(evt *tcell.EventKey) *tcell.EventKey {
 	if !ok {
 		return nil
 	}
-	res := client.NewGVR(s.ID()).R()
-	if res == "cpu" || res == "mem" {
+	res := s.ID()
+	if res == config.PulseCPU || res == config.PulseMEM {
 		res = "pod"
 	}
 	p.App().gotoResource(res+" all", "", false)
@@ -319,6 +349,14 @@ func (p *Pulse) enterCmd(evt *tcell.EventKey) *tcell.EventKey {
 	return nil
 }
 
+func (p *Pulse) trendsCmd(evt *tcell.EventKey) *tcell.EventKey {
+	showTrends(p.app, p.app.Config.K9s.CurrentContext, nil, func(ss []dao.TrendSample, now time.Time) string {
+		return pulseTrends(ss, p.tiles, now)
+	})
+
+	return nil
+}
+
 func (p *Pulse) nextFocusCmd(direction int) func(evt *tcell.EventKey) *tcell.EventKey {
 	return func(evt *tcell.EventKey) *tcell.EventKey {
 		v := p.app.GetFocus()
@@ -333,7 +371,8 @@ func (p *Pulse) nextFocusCmd(direction int) func(evt *tcell.EventKey) *tcell.Eve
 	}
 }
 
-func (p *Pulse) makeSP(loc image.Point, span image.Point, gvr string) *tchart.SparkLine {
+func (p *Pulse) makeSP(loc image.Point, span image.Point, t config.PulseTile) *tchart.SparkLine {
+	gvr := t.GVR
 	s := tchart.NewSparkLine(gvr)
 	s.SetBackgroundColor(p.app.Styles.Charts().BgColor.Color())
 	s.SetBorderPadding(0, 1, 0, 1)
@@ -342,7 +381,7 @@ func (p *Pulse) makeSP(loc image.Point, span image.Point, gvr string) *tchart.Sp
 	} else {
 		s.SetSeriesColors(p.app.Styles.Charts().DefaultChartColors.Colors()...)
 	}
-	s.SetLegend(fmt.Sprintf(" %s ", cases.Title(language.Und, cases.NoLower).String(client.NewGVR(gvr).R())))
+	s.SetLegend(fmt.Sprintf(" %s ", pulseTitleFor(t)))
 	s.SetInputCapture(p.keyboard)
 	s.SetMultiSeries(true)
 	p.AddItem(s, loc.X, loc.Y, span.X, span.Y, 0, 0, true)
@@ -350,7 +389,8 @@ func (p *Pulse) makeSP(loc image.Point, span image.Point, gvr string) *tchart.Sp
 	return s
 }
 
-func (p *Pulse) makeGA(loc image.Point, span image.Point, gvr string) *tchart.Gauge {
+func (p *Pulse) makeGA(loc image.Point, span image.Point, t config.PulseTile) *tchart.Gauge {
+	gvr := t.GVR
 	g := tchart.NewGauge(gvr)
 	// g.SetResolution(3)
 	g.SetBackgroundColor(p.app.Styles.Charts().BgColor.Color())
@@ -360,16 +400,59 @@ func (p *Pulse) makeGA(loc image.Point, span image.Point, gvr string) *tchart.Ga
 	} else {
 		g.SetSeriesColors(p.app.Styles.Charts().DefaultDialColors.Colors()...)
 	}
-	g.SetLegend(fmt.Sprintf(" %s ", cases.Title(language.Und, cases.NoLower).String(client.NewGVR(gvr).R())))
+	g.SetLegend(fmt.Sprintf(" %s ", pulseTitleFor(t)))
 	g.SetInputCapture(p.keyboard)
 	p.AddItem(g, loc.X, loc.Y, span.X, span.Y, 0, 0, true)
 
 	return g
 }
 
+func (p *Pulse) makeDG(loc image.Point, span image.Point, t config.PulseTile) *tchart.DotGrid {
+	d := tchart.NewDotGrid(t.GVR)
+	d.SetBackgroundColor(p.app.Styles.Charts().BgColor.Color())
+	d.SetBorderPadding(0, 1, 0, 1)
+	if cc, ok := p.app.Styles.Charts().ResourceColors[t.GVR]; ok {
+		d.SetSeriesColors(cc.Colors()...)
+	} else {
+		d.SetSeriesColors(p.app.Styles.Charts().DefaultChartColors.Colors()...)
+	}
+	d.SetLegend(fmt.Sprintf(" %s ", pulseTitleFor(t)))
+	d.SetInputCapture(p.keyboard)
+	p.AddItem(d, loc.X, loc.Y, span.X, span.Y, 0, 0, true)
+
+	return d
+}
+
 // ----------------------------------------------------------------------------
 // Helpers
 
+func pulseTitleFor(t config.PulseTile) string {
+	if t.Title != "" {
+		return t.Title
+	}
+
+	return cases.Title(language.Und, cases.NoLower).String(client.NewGVR(t.GVR).R())
+}
+
+// pulseLayout flows tiles left to right on a grid of the given number of
+// columns. A new row starts below the tallest tile of the previous row.
+func pulseLayout(cols int, tt []config.PulseTile) []image.Rectangle {
+	rr := make([]image.Rectangle, 0, len(tt))
+	var x, y, rowHeight int
+	for _, t := range tt {
+		if x > 0 && x+t.ColSpan > cols {
+			x, y, rowHeight = 0, y+rowHeight, 0
+		}
+		rr = append(rr, image.Rect(x, y, x+t.ColSpan, y+t.RowSpan))
+		x += t.ColSpan
+		if t.RowSpan > rowHeight {
+			rowHeight = t.RowSpan
+		}
+	}
+
+	return rr
+}
+
 func nextFocus(pp []Graphable, index int) (int, tview.Primitive) {
 	if index >= len(pp) {
 		return 0, pp[0]
@@ -0,0 +1,58 @@
+package view
+
+import (
+	"image"
+	"testing"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPulseLayout(t *testing.T) {
+	uu := map[string]struct {
+		cols int
+		tt   []config.PulseTile
+		e    []image.Rectangle
+	}{
+		"empty": {
+			cols: 8,
+			e:    []image.Rectangle{},
+		},
+		"defaults": {
+			cols: 8,
+			tt:   config.NewPulse().Tiles,
+			e: []image.Rectangle{
+				image.Rect(0, 0, 2, 2),
+				image.Rect(2, 0, 4, 2),
+				image.Rect(4, 0, 6, 2),
+				image.Rect(6, 0, 8, 2),
+				image.Rect(0, 2, 2, 5),
+				image.Rect(2, 2, 4, 5),
+				image.Rect(4, 2, 6, 5),
+				image.Rect(6, 2, 8, 5),
+				image.Rect(0, 5, 4, 7),
+				image.Rect(4, 5, 8, 7),
+			},
+		},
+		"wrap-tallest": {
+			cols: 4,
+			tt: []config.PulseTile{
+				{RowSpan: 1, ColSpan: 2},
+				{RowSpan: 3, ColSpan: 2},
+				{RowSpan: 2, ColSpan: 3},
+			},
+			e: []image.Rectangle{
+				image.Rect(0, 0, 2, 1),
+				image.Rect(2, 0, 4, 3),
+				image.Rect(0, 3, 3, 5),
+			},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, pulseLayout(u.cols, u.tt))
+		})
+	}
+}
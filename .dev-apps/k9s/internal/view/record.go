@@ -0,0 +1,243 @@
+package view
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/term"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+const (
+	recordSizePoll    = 250 * time.Millisecond
+	playbackMaxIdle   = 2 * time.Second
+	defaultTermWidth  = 80
+	defaultTermHeight = 24
+)
+
+func recordSessions(a *App) bool {
+	return a.Config.K9s.ActiveCluster().FeatureGates.RecordSessions
+}
+
+// runRecorded runs an interactive container session and records it.
+// An empty command attaches to the container.
+func runRecorded(a *App, kind, fqn, co, banner string, cmd []string) (string, error) {
+	a.Halt()
+	defer a.Resume()
+
+	var (
+		file string
+		err  error
+	)
+	if !a.Suspend(func() {
+		clearScreen()
+		file, err = recordSession(a, kind, fqn, co, banner, cmd)
+		clearScreen()
+	}) {
+		return file, errors.New("unable to suspend k9s")
+	}
+
+	return file, err
+}
+
+func recordSession(a *App, kind, fqn, co, banner string, cmd []string) (string, error) {
+	dir := filepath.Join(a.Config.K9s.GetScreenDumpDir(), a.Config.K9s.CurrentContextDir())
+	config.EnsureFullPath(dir, config.DefaultDirMod)
+	ns, n := client.Namespaced(fqn)
+	file := filepath.Join(dir, fmt.Sprintf("%s-%s-%s-%s-%d%s", kind, ns, n, co, time.Now().Unix(), dao.CastExt))
+	f, err := os.Create(file)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Error().Err(err).Msgf("closing recording %s", file)
+		}
+	}()
+
+	inFd, outFd := int(os.Stdin.Fd()), int(os.Stdout.Fd())
+	w, h, err := term.GetSize(outFd)
+	if err != nil {
+		w, h = defaultTermWidth, defaultTermHeight
+	}
+	rec, err := dao.NewCastRecorder(f, dao.CastHeader{
+		Width:  w,
+		Height: h,
+		Title:  fmt.Sprintf("%s %s:%s", kind, fqn, co),
+		Env:    map[string]string{"TERM": os.Getenv("TERM"), "SHELL": os.Getenv("SHELL")},
+	})
+	if err != nil {
+		return file, err
+	}
+
+	_, _ = os.Stdout.Write([]byte(banner))
+	state, err := term.MakeRaw(inFd)
+	if err != nil {
+		return file, err
+	}
+	defer func() {
+		if err := term.Restore(inFd, state); err != nil {
+			log.Error().Err(err).Msg("restoring terminal")
+		}
+	}()
+
+	in := newStdinProxy()
+	sizes := newTermSizeQueue(outFd, w, h, rec)
+	defer sizes.stop()
+
+	var po dao.Pod
+	po.Init(a.factory, client.NewGVR("v1/pods"))
+	err = po.Session(fqn, dao.SessionOpts{
+		Container: co,
+		Command:   cmd,
+		In:        in,
+		Out:       io.MultiWriter(os.Stdout, rec),
+		SizeQueue: sizes,
+	})
+	if err == nil {
+		err = rec.Err()
+	}
+	fmt.Printf("\r\nSession recorded to %s. Press any key to return to K9s...", file)
+	in.drain()
+
+	return file, err
+}
+
+func playback(a *App, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	a.Halt()
+	defer a.Resume()
+
+	var playErr error
+	a.Suspend(func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(sigChan)
+		go func() {
+			select {
+			case <-sigChan:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		clearScreen()
+		if _, playErr = dao.PlayCast(ctx, f, os.Stdout, playbackMaxIdle); errors.Is(playErr, context.Canceled) {
+			playErr = nil
+		}
+		fmt.Print("\r\n<<K9s-Playback>> Done! Press enter to return to K9s...")
+		_, _ = fmt.Scanln()
+		clearScreen()
+	})
+
+	return playErr
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+// stdinProxy forwards stdin to a session. Once the session is over, the pending
+// stdin read is consumed so no keystroke goes astray once K9s resumes.
+type stdinProxy struct {
+	*io.PipeReader
+
+	w      *io.PipeWriter
+	closed atomic.Bool
+	done   chan struct{}
+}
+
+func newStdinProxy() *stdinProxy {
+	r, w := io.Pipe()
+	p := stdinProxy{PipeReader: r, w: w, done: make(chan struct{})}
+	go func() {
+		defer close(p.done)
+		buff := make([]byte, 1024)
+		for {
+			n, err := os.Stdin.Read(buff)
+			if p.closed.Load() {
+				return
+			}
+			if err != nil {
+				_ = w.CloseWithError(err)
+				return
+			}
+			if _, err := w.Write(buff[:n]); err != nil {
+				return
+			}
+		}
+	}()
+
+	return &p
+}
+
+func (p *stdinProxy) drain() {
+	p.closed.Store(true)
+	_ = p.w.Close()
+	<-p.done
+}
+
+// termSizeQueue tracks terminal resizes.
+type termSizeQueue struct {
+	fd            int
+	width, height int
+	rec           *dao.CastRecorder
+	first         bool
+	done          chan struct{}
+}
+
+func newTermSizeQueue(fd, w, h int, rec *dao.CastRecorder) *termSizeQueue {
+	return &termSizeQueue{
+		fd:     fd,
+		width:  w,
+		height: h,
+		rec:    rec,
+		first:  true,
+		done:   make(chan struct{}),
+	}
+}
+
+// Next returns the next terminal size or nil when the session is over.
+func (t *termSizeQueue) Next() *remotecommand.TerminalSize {
+	if t.first {
+		t.first = false
+		return &remotecommand.TerminalSize{Width: uint16(t.width), Height: uint16(t.height)}
+	}
+	for {
+		select {
+		case <-t.done:
+			return nil
+		case <-time.After(recordSizePoll):
+		}
+		w, h, err := term.GetSize(t.fd)
+		if err != nil || (w == t.width && h == t.height) {
+			continue
+		}
+		t.width, t.height = w, h
+		t.rec.Resize(w, h)
+
+		return &remotecommand.TerminalSize{Width: uint16(w), Height: uint16(h)}
+	}
+}
+
+func (t *termSizeQueue) stop() {
+	close(t.done)
+}
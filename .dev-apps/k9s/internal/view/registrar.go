@@ -22,6 +22,9 @@ func helmViewers(vv MetaViewers) {
 	vv[client.NewGVR("helm")] = MetaViewer{
 		viewerFn: NewHelm,
 	}
+	vv[client.NewGVR("helm-history")] = MetaViewer{
+		viewerFn: NewHelmHistory,
+	}
 }
 
 func coreViewers(vv MetaViewers) {
@@ -55,6 +58,9 @@ func coreViewers(vv MetaViewers) {
 	vv[client.NewGVR("v1/persistentvolumeclaims")] = MetaViewer{
 		viewerFn: NewPersistentVolumeClaim,
 	}
+	vv[client.NewGVR("networking.k8s.io/v1/networkpolicies")] = MetaViewer{
+		viewerFn: NewNetworkPolicy,
+	}
 }
 
 func miscViewers(vv MetaViewers) {
@@ -68,6 +74,21 @@ func miscViewers(vv MetaViewers) {
 	vv[client.NewGVR("containers")] = MetaViewer{
 		viewerFn: NewContainer,
 	}
+	vv[client.NewGVR("revisions")] = MetaViewer{
+		viewerFn: NewRevision,
+	}
+	vv[client.NewGVR("images")] = MetaViewer{
+		viewerFn: NewImage,
+	}
+	vv[client.NewGVR("imageconsumers")] = MetaViewer{
+		viewerFn: NewImageConsumer,
+	}
+	vv[client.NewGVR("rightsizing")] = MetaViewer{
+		viewerFn: NewRightsizing,
+	}
+	vv[client.NewGVR("capacity")] = MetaViewer{
+		viewerFn: NewCapacity,
+	}
 	vv[client.NewGVR("portforwards")] = MetaViewer{
 		viewerFn: NewPortForward,
 	}
@@ -77,6 +98,15 @@ func miscViewers(vv MetaViewers) {
 	vv[client.NewGVR("benchmarks")] = MetaViewer{
 		viewerFn: NewBenchmark,
 	}
+	vv[client.NewGVR("journal")] = MetaViewer{
+		viewerFn: NewJournal,
+	}
+	vv[client.NewGVR("certs")] = MetaViewer{
+		viewerFn: NewCert,
+	}
+	vv[client.NewGVR("consumers")] = MetaViewer{
+		viewerFn: NewConsumer,
+	}
 	vv[client.NewGVR("aliases")] = MetaViewer{
 		viewerFn: NewAlias,
 	}
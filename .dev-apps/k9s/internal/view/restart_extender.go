@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/derailed/k9s/internal/config"
 	"github.com/derailed/k9s/internal/dao"
 	"github.com/derailed/k9s/internal/ui"
 	"github.com/derailed/k9s/internal/ui/dialog"
@@ -31,7 +32,7 @@ func (r *RestartExtender) bindKeys(aa ui.KeyActions) {
 		return
 	}
 	aa.Add(ui.KeyActions{
-		ui.KeyR: ui.NewKeyAction("Restart", r.restartCmd, true),
+		ui.KeyR: ui.NewDangerousKeyAction("Restart", r.restartCmd, true, config.ActionEdit),
 	})
 }
 
@@ -63,6 +64,9 @@ func (r *RestartExtender) restartCmd(evt *tcell.EventKey) *tcell.EventKey {
 }
 
 func (r *RestartExtender) restartRollout(ctx context.Context, path string) error {
+	if err := journalResources(r.App(), dao.JournalEdit, r.GVR(), path); err != nil {
+		return err
+	}
 	res, err := dao.AccessorFor(r.App().factory, r.GVR())
 	if err != nil {
 		return err
@@ -72,7 +76,10 @@ func (r *RestartExtender) restartRollout(ctx context.Context, path string) error
 		return errors.New("resource is not restartable")
 	}
 
-	return s.Restart(ctx, path)
+	err = s.Restart(ctx, path)
+	auditAction(r.App(), auditRestart, r.GVR(), path, "", err)
+
+	return err
 }
 
 // Helpers...
@@ -0,0 +1,182 @@
+package view
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/k9s/internal/ui/dialog"
+	"github.com/gdamore/tcell/v2"
+	"sigs.k8s.io/yaml"
+)
+
+// Revision presents a workload rollout history viewer.
+type Revision struct {
+	ResourceViewer
+
+	owner client.GVR
+	path  string
+}
+
+// NewRevision returns a new viewer.
+func NewRevision(gvr client.GVR) ResourceViewer {
+	r := Revision{
+		ResourceViewer: NewBrowser(gvr),
+	}
+	r.AddBindKeysFn(r.bindKeys)
+	r.GetTable().SetEnterFn(r.showTemplate)
+	r.GetTable().SetSortCol("REVISION", false)
+
+	return &r
+}
+
+func (r *Revision) bindKeys(aa ui.KeyActions) {
+	aa.Add(ui.KeyActions{
+		ui.KeyD:      ui.NewKeyAction("Diff", r.diffCmd, true),
+		ui.KeyShiftV: ui.NewKeyAction("Sort Revision", r.GetTable().SortColCmd("REVISION", false), false),
+	})
+	if r.App().Config.K9s.IsReadOnly() {
+		return
+	}
+	aa.Add(ui.KeyActions{
+		tcell.KeyCtrlL: ui.NewDangerousKeyAction("Rollback", r.rollbackCmd, true, config.ActionEdit),
+	})
+}
+
+func (r *Revision) accessor() *dao.Revision {
+	var rev dao.Revision
+	rev.Init(r.App().factory, r.GVR())
+
+	return &rev
+}
+
+func (r *Revision) showTemplate(app *App, _ ui.Tabular, _, id string) {
+	rev, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		app.Flash().Err(err)
+		return
+	}
+	res, err := r.accessor().Revision(r.owner.String(), r.path, rev)
+	if err != nil {
+		app.Flash().Err(err)
+		return
+	}
+	raw, err := yaml.Marshal(res.Template)
+	if err != nil {
+		app.Flash().Err(err)
+		return
+	}
+
+	details := NewDetails(app, "Revision", fmt.Sprintf("%s #%d", r.path, rev), true).Update(string(raw))
+	if err := app.inject(details); err != nil {
+		app.Flash().Err(err)
+	}
+}
+
+func (r *Revision) diffCmd(evt *tcell.EventKey) *tcell.EventKey {
+	ids := r.GetTable().GetSelectedItems()
+	if len(ids) == 0 || ids[0] == "" {
+		return evt
+	}
+	if len(ids) > 2 {
+		r.App().Flash().Warn("Mark at most 2 revisions to diff")
+		return nil
+	}
+
+	revs := make([]int64, 0, 2)
+	for _, id := range ids {
+		rev, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			r.App().Flash().Err(err)
+			return nil
+		}
+		revs = append(revs, rev)
+	}
+	acc := r.accessor()
+	if len(revs) == 1 {
+		rr, err := acc.History(r.owner.String(), r.path)
+		if err != nil {
+			r.App().Flash().Err(err)
+			return nil
+		}
+		if len(rr) == 0 {
+			return nil
+		}
+		latest := rr[len(rr)-1].Revision
+		if revs[0] == latest && len(rr) > 1 {
+			// Diff the latest revision against its predecessor.
+			latest = rr[len(rr)-2].Revision
+		}
+		revs = append(revs, latest)
+	}
+	from, to := revs[0], revs[1]
+	if from > to {
+		from, to = to, from
+	}
+	diff, err := acc.Diff(r.owner.String(), r.path, from, to)
+	if err != nil {
+		r.App().Flash().Err(err)
+		return nil
+	}
+	if diff == "" {
+		diff = fmt.Sprintf("No pod template changes between revisions %d and %d", from, to)
+	}
+
+	details := NewDetails(r.App(), "Diff", fmt.Sprintf("%s #%d..#%d", r.path, from, to), true).Update(diff)
+	if err := r.App().inject(details); err != nil {
+		r.App().Flash().Err(err)
+	}
+
+	return nil
+}
+
+func (r *Revision) rollbackCmd(evt *tcell.EventKey) *tcell.EventKey {
+	id := r.GetTable().GetSelectedItem()
+	if id == "" {
+		return evt
+	}
+	rev, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		r.App().Flash().Err(err)
+		return nil
+	}
+
+	msg := fmt.Sprintf("Rollback %s %s to revision %d?", singularize(r.owner.R()), r.path, rev)
+	dialog.ShowConfirm(r.App().Styles.Dialog(), r.App().Content.Pages, "Confirm Rollback", msg, func() {
+		if err := journalResources(r.App(), dao.JournalEdit, r.owner, r.path); err != nil {
+			r.App().Flash().Err(err)
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), r.App().Conn().Config().CallTimeout())
+		defer cancel()
+		err := r.accessor().Rollback(ctx, r.owner.String(), r.path, rev)
+		auditAction(r.App(), auditRollback, r.owner, r.path, fmt.Sprintf("revision=%d", rev), err)
+		if err != nil {
+			r.App().Flash().Err(err)
+			return
+		}
+		r.App().Flash().Infof("%s rolled back to revision %d", r.path, rev)
+		r.Refresh()
+	}, func() {})
+
+	return nil
+}
+
+func showRevisions(app *App, gvr client.GVR, path string) {
+	v := NewRevision(client.NewGVR("revisions"))
+	if r, ok := v.(*Revision); ok {
+		r.owner, r.path = gvr, path
+	}
+	v.SetContextFn(func(ctx context.Context) context.Context {
+		ctx = context.WithValue(ctx, internal.KeyPath, path)
+		return context.WithValue(ctx, internal.KeyGVR, gvr.String())
+	})
+	if err := app.inject(v); err != nil {
+		app.Flash().Err(err)
+	}
+}
@@ -0,0 +1,175 @@
+package view
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/k9s/internal/ui/dialog"
+	"github.com/gdamore/tcell/v2"
+)
+
+// Rightsizing presents containers requests/limits recommendations.
+type Rightsizing struct {
+	ResourceViewer
+}
+
+// NewRightsizing returns a new viewer.
+func NewRightsizing(gvr client.GVR) ResourceViewer {
+	r := Rightsizing{
+		ResourceViewer: NewBrowser(gvr),
+	}
+	r.AddBindKeysFn(r.bindKeys)
+	r.GetTable().SetEnterFn(r.showReason)
+	r.GetTable().SetSortCol("STATUS", true)
+
+	return &r
+}
+
+func (r *Rightsizing) bindKeys(aa ui.KeyActions) {
+	aa.Add(ui.KeyActions{
+		ui.KeyShiftS: ui.NewKeyAction("Sort Status", r.GetTable().SortColCmd("STATUS", true), false),
+		ui.KeyShiftC: ui.NewKeyAction("Sort Container", r.GetTable().SortColCmd("CONTAINER", true), false),
+	})
+	if r.App().Config.K9s.IsReadOnly() {
+		return
+	}
+	aa.Add(ui.KeyActions{
+		ui.KeyA: ui.NewDangerousKeyAction("Apply", r.applyCmd, true, config.ActionEdit),
+	})
+}
+
+func (r *Rightsizing) accessor() *dao.Rightsizing {
+	var rs dao.Rightsizing
+	rs.Init(r.App().factory, r.GVR())
+
+	return &rs
+}
+
+func (r *Rightsizing) showReason(app *App, _ ui.Tabular, _, id string) {
+	res, err := r.accessor().Recommendation(id)
+	if err != nil {
+		app.Flash().Err(err)
+		return
+	}
+	patch, err := dao.RightsizePatch(res)
+	if err != nil {
+		app.Flash().Err(err)
+		return
+	}
+
+	details := NewDetails(app, "Rightsizing", id, true).Update(rightsizeSummary(res, string(patch)))
+	if err := app.inject(details); err != nil {
+		app.Flash().Err(err)
+	}
+}
+
+func (r *Rightsizing) applyCmd(evt *tcell.EventKey) *tcell.EventKey {
+	id := r.GetTable().GetSelectedItem()
+	if id == "" {
+		return evt
+	}
+	acc := r.accessor()
+	res, err := acc.Recommendation(id)
+	if err != nil {
+		r.App().Flash().Err(err)
+		return nil
+	}
+	if res.Status == render.RightsizeSampling {
+		r.App().Flash().Warnf("Not enough usage samples for %s yet", id)
+		return nil
+	}
+
+	path := client.FQN(res.Namespace, res.Name)
+	gvr := client.NewGVR(res.GVR)
+	msg := fmt.Sprintf("Set %s container %s resources to cpu %s, memory %s?",
+		path,
+		res.Container,
+		rightsizeRL(res.CPU.SuggestRequest, res.CPU.SuggestLimit, "m", 1),
+		rightsizeRL(res.Mem.SuggestRequest, res.Mem.SuggestLimit, "Mi", 1024*1024),
+	)
+	dialog.ShowConfirm(r.App().Styles.Dialog(), r.App().Content.Pages, "Confirm Rightsizing", msg, func() {
+		if err := journalResources(r.App(), dao.JournalEdit, gvr, path); err != nil {
+			r.App().Flash().Err(err)
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), r.App().Conn().Config().CallTimeout())
+		defer cancel()
+		err := acc.Apply(ctx, res)
+		auditAction(r.App(), auditRightsize, gvr, path, "container="+res.Container, err)
+		if err != nil {
+			r.App().Flash().Err(err)
+			return
+		}
+		r.App().Flash().Infof("%s container %s resources updated", path, res.Container)
+		r.Refresh()
+	}, func() {})
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func rightsizeRL(req, lim int64, unit string, div int64) string {
+	s := fmt.Sprintf("%d%s", req/div, unit)
+	if lim > 0 {
+		s += fmt.Sprintf("/%d%s", lim/div, unit)
+	}
+
+	return s
+}
+
+func rightsizeSummary(res *render.RightsizingRes, patch string) string {
+	reason := res.Reason
+	if reason == "" {
+		reason = "n/a"
+	}
+
+	return fmt.Sprintf(`Workload:  %s %s
+Container: %s
+Samples:   %d
+Status:    %s
+Reason:    %s
+
+CPU  p95/max: %dm/%dm
+     current: %s
+     suggest: %s
+MEM  p95/max: %dMi/%dMi
+     current: %s
+     suggest: %s
+
+Patch:
+%s
+`,
+		res.GVR, client.FQN(res.Namespace, res.Name),
+		res.Container,
+		res.Samples,
+		res.Status,
+		reason,
+		res.CPU.P95, res.CPU.Max,
+		rightsizeRL(res.CPU.Request, res.CPU.Limit, "m", 1),
+		rightsizeRL(res.CPU.SuggestRequest, res.CPU.SuggestLimit, "m", 1),
+		client.ToMB(res.Mem.P95), client.ToMB(res.Mem.Max),
+		rightsizeRL(res.Mem.Request, res.Mem.Limit, "Mi", 1024*1024),
+		rightsizeRL(res.Mem.SuggestRequest, res.Mem.SuggestLimit, "Mi", 1024*1024),
+		patch,
+	)
+}
+
+// configureUsageSampler sets the session usage sampler options from the k9s config.
+func configureUsageSampler(cfg *config.Rightsizing) {
+	dao.UsageSamples().Configure(cfg.Window(), dao.RightsizeOpts{
+		MinSamples: cfg.MinSamples,
+		Headroom:   cfg.Headroom,
+	})
+}
+
+// resetUsageSampler drops the usage recorded so far as samples are cluster specific.
+func resetUsageSampler() {
+	dao.UsageSamples().Reset()
+}
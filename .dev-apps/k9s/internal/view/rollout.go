@@ -0,0 +1,395 @@
+package view
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/model"
+	"github.com/derailed/k9s/internal/tchart"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/k9s/internal/ui/dialog"
+	"github.com/derailed/tview"
+	"github.com/gdamore/tcell/v2"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+const rolloutTitle = "Rollout"
+
+var (
+	_ ResourceViewer        = (*Rollout)(nil)
+	_ model.RolloutListener = (*Rollout)(nil)
+)
+
+// Rollout represents a live deployment rollout progress view.
+type Rollout struct {
+	*tview.Grid
+
+	app      *App
+	gvr      client.GVR
+	model    *model.Rollout
+	cancelFn context.CancelFunc
+	actions  ui.KeyActions
+	gauges   []*tchart.Gauge
+	pods     *tview.TextView
+	events   *tview.TextView
+}
+
+// NewRollout returns a new rollout view.
+func NewRollout(path string) ResourceViewer {
+	return &Rollout{
+		Grid:    tview.NewGrid(),
+		gvr:     client.NewGVR("apps/v1/deployments"),
+		model:   model.NewRollout(path),
+		actions: make(ui.KeyActions),
+	}
+}
+
+// Init initializes the view.
+func (r *Rollout) Init(ctx context.Context) error {
+	var err error
+	if r.app, err = extractApp(ctx); err != nil {
+		return err
+	}
+
+	r.SetBorder(true)
+	r.SetTitle(fmt.Sprintf(" %s(%s) ", rolloutTitle, r.model.GetPath()))
+	r.SetGap(1, 1)
+	r.SetBorderPadding(0, 0, 1, 1)
+	r.SetRows(7, 0)
+
+	for i, id := range []string{"desired", "updated", "ready", "available"} {
+		r.gauges = append(r.gauges, r.makeGA(image.Point{X: i, Y: 0}, id))
+	}
+	r.pods = r.makeText(image.Point{X: 0, Y: 1}, image.Point{X: 2, Y: 1}, "Pods")
+	r.events = r.makeText(image.Point{X: 2, Y: 1}, image.Point{X: 2, Y: 1}, "Events")
+
+	r.bindKeys()
+	r.SetInputCapture(r.keyboard)
+	r.model.AddListener(r)
+	r.app.Styles.AddListener(r)
+	r.StylesChanged(r.app.Styles)
+
+	return nil
+}
+
+// InCmdMode checks if prompt is active.
+func (*Rollout) InCmdMode() bool {
+	return false
+}
+
+// StylesChanged notifies the skin changed.
+func (r *Rollout) StylesChanged(s *config.Styles) {
+	r.SetBackgroundColor(s.Charts().BgColor.Color())
+	for _, g := range r.gauges {
+		g.SetBackgroundColor(s.Charts().DialBgColor.Color())
+		g.SetSeriesColors(s.Charts().DefaultDialColors.Colors()...)
+	}
+	for _, t := range []*tview.TextView{r.pods, r.events} {
+		t.SetBackgroundColor(s.Charts().BgColor.Color())
+		t.SetTextColor(s.Body().FgColor.Color())
+	}
+}
+
+// RolloutChanged notifies the rollout status changed.
+func (r *Rollout) RolloutChanged(st *dao.RolloutStatus) {
+	r.app.QueueUpdateDraw(func() {
+		r.update(st)
+	})
+}
+
+// RolloutFailed notifies the rollout status could not be fetched.
+func (r *Rollout) RolloutFailed(err error) {
+	r.app.Flash().Err(err)
+}
+
+func (r *Rollout) update(st *dao.RolloutStatus) {
+	r.SetTitle(fmt.Sprintf(" %s(%s)[%s] ", rolloutTitle, r.model.GetPath(), rolloutState(st)))
+
+	cc := r.gauges[0].GetSeriesColorNames()
+	for i, n := range []int32{st.Desired, st.Updated, st.Ready, st.Available} {
+		g := r.gauges[i]
+		g.SetLegend(fmt.Sprintf(" %s([%s::]%d[white::]/[%s::b]%d[-::]) ",
+			cases.Title(language.Und, cases.NoLower).String(g.ID()), cc[0], n, cc[1], st.Desired))
+		g.Add(tchart.Metric{S1: int64(n), S2: int64(st.Desired)})
+	}
+
+	r.pods.SetText(rolloutPods(st))
+	r.events.SetText(rolloutEvents(st))
+	r.events.ScrollToEnd()
+}
+
+func (r *Rollout) bindKeys() {
+	r.actions.Add(ui.KeyActions{
+		tcell.KeyEscape: ui.NewKeyAction("Back", r.app.PrevCmd, true),
+	})
+	if !r.app.Config.K9s.IsReadOnly() {
+		r.actions.Add(ui.KeyActions{
+			ui.KeyP: ui.NewDangerousKeyAction("Pause", r.pauseCmd, true, config.ActionEdit),
+			ui.KeyR: ui.NewDangerousKeyAction("Resume", r.resumeCmd, true, config.ActionEdit),
+			ui.KeyU: ui.NewDangerousKeyAction("Undo", r.undoCmd, true, config.ActionEdit),
+		})
+	}
+	ns, _ := client.Namespaced(r.model.GetPath())
+	r.actions.Guard(func(policy string) bool {
+		return r.app.Config.IsActionAllowed(policy, ns)
+	})
+}
+
+func (r *Rollout) keyboard(evt *tcell.EventKey) *tcell.EventKey {
+	key := evt.Key()
+	if key == tcell.KeyRune {
+		key = tcell.Key(evt.Rune())
+	}
+	if a, ok := r.actions[key]; ok {
+		return a.Action(evt)
+	}
+
+	return evt
+}
+
+func (r *Rollout) pauseCmd(evt *tcell.EventKey) *tcell.EventKey {
+	r.toggle("Pause", auditPause, func(ctx context.Context, dp *dao.Deployment, path string) error {
+		return dp.Pause(ctx, path)
+	})
+
+	return nil
+}
+
+func (r *Rollout) resumeCmd(evt *tcell.EventKey) *tcell.EventKey {
+	r.toggle("Resume", auditResume, func(ctx context.Context, dp *dao.Deployment, path string) error {
+		return dp.Resume(ctx, path)
+	})
+
+	return nil
+}
+
+func (r *Rollout) toggle(verb, action string, fn func(context.Context, *dao.Deployment, string) error) {
+	path := r.model.GetPath()
+	msg := fmt.Sprintf("%s rollout of deployment %s?", verb, path)
+	dialog.ShowConfirm(r.app.Styles.Dialog(), r.app.Content.Pages, "Confirm "+verb, msg, func() {
+		if err := journalResources(r.app, dao.JournalEdit, r.gvr, path); err != nil {
+			r.app.Flash().Err(err)
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), r.app.Conn().Config().CallTimeout())
+		defer cancel()
+		var dp dao.Deployment
+		dp.Init(r.app.factory, r.gvr)
+		err := fn(ctx, &dp, path)
+		auditAction(r.app, action, r.gvr, path, "", err)
+		if err != nil {
+			r.app.Flash().Err(err)
+			return
+		}
+		r.app.Flash().Infof("%s rollout %sd", path, strings.ToLower(verb))
+		r.Refresh()
+	}, func() {})
+}
+
+func (r *Rollout) undoCmd(evt *tcell.EventKey) *tcell.EventKey {
+	path := r.model.GetPath()
+	msg := fmt.Sprintf("Undo rollout of deployment %s to its previous revision?", path)
+	dialog.ShowConfirm(r.app.Styles.Dialog(), r.app.Content.Pages, "Confirm Undo", msg, func() {
+		if err := journalResources(r.app, dao.JournalEdit, r.gvr, path); err != nil {
+			r.app.Flash().Err(err)
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), r.app.Conn().Config().CallTimeout())
+		defer cancel()
+		var rev dao.Revision
+		rev.Init(r.app.factory, client.NewGVR("revisions"))
+		err := rev.Rollback(ctx, r.gvr.String(), path, 0)
+		auditAction(r.app, auditRollback, r.gvr, path, "revision=previous", err)
+		if err != nil {
+			r.app.Flash().Err(err)
+			return
+		}
+		r.app.Flash().Infof("%s rolled back to previous revision", path)
+		r.Refresh()
+	}, func() {})
+
+	return nil
+}
+
+func (r *Rollout) defaultContext() context.Context {
+	return context.WithValue(context.Background(), internal.KeyFactory, r.app.factory)
+}
+
+// Start initializes the rollout watch loop.
+func (r *Rollout) Start() {
+	r.Stop()
+
+	ctx := r.defaultContext()
+	ctx, r.cancelFn = context.WithCancel(ctx)
+	r.model.Watch(ctx)
+}
+
+// Stop terminates the watch loop.
+func (r *Rollout) Stop() {
+	if r.cancelFn == nil {
+		return
+	}
+	r.cancelFn()
+	r.cancelFn = nil
+}
+
+// Refresh updates the view.
+func (r *Rollout) Refresh() {
+	go r.model.Refresh(r.defaultContext())
+}
+
+// GVR returns a resource descriptor.
+func (r *Rollout) GVR() client.GVR {
+	return r.gvr
+}
+
+// Name returns the component name.
+func (r *Rollout) Name() string {
+	return rolloutTitle
+}
+
+// App returns the current app handle.
+func (r *Rollout) App() *App {
+	return r.app
+}
+
+// SetInstance sets specific resource instance.
+func (r *Rollout) SetInstance(string) {}
+
+// SetEnvFn sets the custom environment function.
+func (r *Rollout) SetEnvFn(EnvFunc) {}
+
+// AddBindKeysFn sets up extra key bindings.
+func (r *Rollout) AddBindKeysFn(BindKeysFunc) {}
+
+// SetContextFn sets custom context.
+func (r *Rollout) SetContextFn(ContextFunc) {}
+
+// GetTable return the view table if any.
+func (r *Rollout) GetTable() *Table {
+	return nil
+}
+
+// Actions returns active menu bindings.
+func (r *Rollout) Actions() ui.KeyActions {
+	return r.actions
+}
+
+// Hints returns the view hints.
+func (r *Rollout) Hints() model.MenuHints {
+	return r.actions.Hints()
+}
+
+// ExtraHints returns additional hints.
+func (r *Rollout) ExtraHints() map[string]string {
+	return nil
+}
+
+func (r *Rollout) makeGA(loc image.Point, id string) *tchart.Gauge {
+	g := tchart.NewGauge(id)
+	g.SetBackgroundColor(r.app.Styles.Charts().BgColor.Color())
+	g.SetSeriesColors(r.app.Styles.Charts().DefaultDialColors.Colors()...)
+	g.SetLegend(fmt.Sprintf(" %s ", cases.Title(language.Und, cases.NoLower).String(id)))
+	r.AddItem(g, loc.Y, loc.X, 1, 1, 0, 0, false)
+
+	return g
+}
+
+func (r *Rollout) makeText(loc, span image.Point, title string) *tview.TextView {
+	t := tview.NewTextView()
+	t.SetDynamicColors(true)
+	t.SetWrap(true)
+	t.SetBorder(true)
+	t.SetTitle(fmt.Sprintf(" %s ", title))
+	t.SetBorderPadding(0, 0, 1, 1)
+	r.AddItem(t, loc.Y, loc.X, span.Y, span.X, 0, 0, false)
+
+	return t
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func rolloutState(st *dao.RolloutStatus) string {
+	switch {
+	case st.DeadlineExceeded:
+		return "[red::b]Deadline Exceeded[-::-]"
+	case st.Paused:
+		return "[orange::b]Paused[-::-]"
+	case st.Complete:
+		return "[green::b]Complete[-::-]"
+	default:
+		return "[dodgerblue::b]Progressing[-::-]"
+	}
+}
+
+func rolloutPods(st *dao.RolloutStatus) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[::b]Revision:[::-] %s\n", st.Revision)
+	if st.Reason != "" {
+		fmt.Fprintf(&b, "[::b]Progress:[::-] %s (%s)\n", st.Reason, tview.Escape(st.Message))
+	}
+	if st.ProgressDeadline > 0 {
+		fmt.Fprintf(&b, "[::b]Deadline:[::-] %s", duration.HumanDuration(st.ProgressDeadline))
+		if !st.LastProgress.IsZero() && !st.Complete && !st.DeadlineExceeded {
+			left := st.ProgressDeadline - time.Since(st.LastProgress)
+			if left < 0 {
+				left = 0
+			}
+			fmt.Fprintf(&b, " (%s left)", duration.HumanDuration(left))
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "\n[::b]New[::-] %s\n", st.NewRS)
+	rolloutPodList(&b, st.Pods, true)
+	if len(st.OldRS) > 0 {
+		fmt.Fprintf(&b, "\n[::b]Old[::-] %s\n", strings.Join(st.OldRS, ","))
+		rolloutPodList(&b, st.Pods, false)
+	}
+
+	return b.String()
+}
+
+func rolloutPodList(b *strings.Builder, pp []dao.RolloutPod, isNew bool) {
+	for _, p := range pp {
+		if p.New != isNew {
+			continue
+		}
+		color := "orange"
+		if p.Ready {
+			color = "green"
+		}
+		fmt.Fprintf(b, "  [%s::]●[-::] %s %s\n", color, p.Name, p.Phase)
+	}
+}
+
+func rolloutEvents(st *dao.RolloutStatus) string {
+	if len(st.Events) == 0 {
+		return "No events"
+	}
+	var b strings.Builder
+	for _, e := range st.Events {
+		color := "green"
+		if e.Type != "Normal" {
+			color = "orange"
+		}
+		fmt.Fprintf(&b, "[gray::]%s[-::] [%s::]%s[-::] %s %s\n",
+			e.Timestamp.Format("15:04:05"),
+			color,
+			e.Reason,
+			e.Object,
+			tview.Escape(e.Message),
+		)
+	}
+
+	return b.String()
+}
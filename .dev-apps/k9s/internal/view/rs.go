@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
 	"github.com/derailed/k9s/internal/dao"
 	"github.com/derailed/k9s/internal/ui"
 	"github.com/derailed/tview"
@@ -31,7 +32,7 @@ func (r *ReplicaSet) bindKeys(aa ui.KeyActions) {
 		ui.KeyShiftD:   ui.NewKeyAction("Sort Desired", r.GetTable().SortColCmd("DESIRED", true), false),
 		ui.KeyShiftC:   ui.NewKeyAction("Sort Current", r.GetTable().SortColCmd("CURRENT", true), false),
 		ui.KeyShiftR:   ui.NewKeyAction("Sort Ready", r.GetTable().SortColCmd(readyCol, true), false),
-		tcell.KeyCtrlL: ui.NewKeyAction("Rollback", r.rollbackCmd, true),
+		tcell.KeyCtrlL: ui.NewDangerousKeyAction("Rollback", r.rollbackCmd, true, config.ActionEdit),
 	})
 }
 
@@ -58,10 +59,21 @@ func (r *ReplicaSet) rollbackCmd(evt *tcell.EventKey) *tcell.EventKey {
 		if button != "OK" {
 			return
 		}
-		r.App().Flash().Infof("Rolling back %s %s", r.GVR(), path)
 		var drs dao.ReplicaSet
 		drs.Init(r.App().factory, r.GVR())
-		if err := drs.Rollback(path); err != nil {
+		dp, err := drs.OwnerPath(path)
+		if err != nil {
+			r.App().Flash().Err(err)
+			return
+		}
+		if err := journalResources(r.App(), dao.JournalEdit, client.NewGVR("apps/v1/deployments"), dp); err != nil {
+			r.App().Flash().Err(err)
+			return
+		}
+		r.App().Flash().Infof("Rolling back %s %s", r.GVR(), path)
+		err = drs.Rollback(path)
+		auditAction(r.App(), auditRollback, r.GVR(), path, "", err)
+		if err != nil {
 			r.App().Flash().Err(err)
 		} else {
 			r.App().Flash().Infof("%s successfully rolled back", path)
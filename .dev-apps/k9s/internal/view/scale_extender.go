@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/derailed/k9s/internal/config"
 	"github.com/derailed/k9s/internal/dao"
 	"github.com/derailed/k9s/internal/ui"
 	"github.com/derailed/tview"
@@ -31,7 +32,7 @@ func (s *ScaleExtender) bindKeys(aa ui.KeyActions) {
 		return
 	}
 	aa.Add(ui.KeyActions{
-		ui.KeyS: ui.NewKeyAction("Scale", s.scaleCmd, true),
+		ui.KeyS: ui.NewDangerousKeyAction("Scale", s.scaleCmd, true, config.ActionScale),
 	})
 }
 
@@ -144,6 +145,9 @@ func (s *ScaleExtender) makeStyledForm() *tview.Form {
 }
 
 func (s *ScaleExtender) scale(ctx context.Context, path string, replicas int) error {
+	if err := journalResources(s.App(), dao.JournalEdit, s.GVR(), path); err != nil {
+		return err
+	}
 	res, err := dao.AccessorFor(s.App().factory, s.GVR())
 	if err != nil {
 		return err
@@ -153,5 +157,8 @@ func (s *ScaleExtender) scale(ctx context.Context, path string, replicas int) er
 		return fmt.Errorf("expecting a scalable resource for %q", s.GVR())
 	}
 
-	return scaler.Scale(ctx, path, int32(replicas))
+	err = scaler.Scale(ctx, path, int32(replicas))
+	auditAction(s.App(), auditScale, s.GVR(), path, fmt.Sprintf("replicas=%d", replicas), err)
+
+	return err
 }
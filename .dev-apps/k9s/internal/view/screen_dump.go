@@ -8,6 +8,7 @@ import (
 	"github.com/derailed/k9s/internal"
 	"github.com/derailed/k9s/internal/client"
 	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
 	"github.com/derailed/k9s/internal/ui"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rs/zerolog/log"
@@ -46,6 +47,12 @@ func (s *ScreenDump) edit(app *App, model ui.Tabular, gvr, path string) {
 
 	s.Stop()
 	defer s.Start()
+	if filepath.Ext(path) == dao.CastExt {
+		if err := playback(app, path); err != nil {
+			app.Flash().Err(err)
+		}
+		return
+	}
 	if !edit(app, shellOpts{clear: true, args: []string{path}}) {
 		app.Flash().Err(errors.New("Failed to launch editor"))
 	}
@@ -30,9 +30,19 @@ func (s *Secret) bindKeys(aa ui.KeyActions) {
 	aa.Add(ui.KeyActions{
 		ui.KeyX: ui.NewKeyAction("Decode", s.decodeCmd, true),
 		ui.KeyU: ui.NewKeyAction("UsedBy", s.refCmd, true),
+		ui.KeyI: ui.NewKeyAction("Certificates", s.certsCmd, true),
+		ui.KeyO: ui.NewKeyAction("Consumers", s.consumersCmd, true),
 	})
 }
 
+func (s *Secret) consumersCmd(evt *tcell.EventKey) *tcell.EventKey {
+	return showConsumers(evt, s.App(), s.GetTable(), "v1/secrets")
+}
+
+func (s *Secret) certsCmd(evt *tcell.EventKey) *tcell.EventKey {
+	return inspectCerts(evt, s.App(), s.GetTable(), "v1/secrets")
+}
+
 func (s *Secret) refCmd(evt *tcell.EventKey) *tcell.EventKey {
 	return scanRefs(evt, s.App(), s.GetTable(), "v1/secrets")
 }
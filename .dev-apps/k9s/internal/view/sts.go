@@ -21,7 +21,9 @@ func NewStatefulSet(gvr client.GVR) ResourceViewer {
 		NewRestartExtender(
 			NewScaleExtender(
 				NewImageExtender(
-					NewLogsExtender(NewBrowser(gvr), s.logOptions),
+					NewHistoryExtender(
+						NewLogsExtender(NewBrowser(gvr), s.logOptions),
+					),
 				),
 			),
 		),
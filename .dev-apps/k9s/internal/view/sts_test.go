@@ -13,5 +13,5 @@ func TestStatefulSetNew(t *testing.T) {
 
 	assert.Nil(t, s.Init(makeCtx()))
 	assert.Equal(t, "StatefulSets", s.Name())
-	assert.Equal(t, 12, len(s.Hints()))
+	assert.Equal(t, 13, len(s.Hints()))
 }
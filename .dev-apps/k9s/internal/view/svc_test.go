@@ -57,6 +57,14 @@ func init() {
 		Verbs:        []string{"get", "list", "watch", "delete"},
 		Categories:   []string{"k9s"},
 	})
+	dao.MetaAccess.RegisterMeta("networking.k8s.io/v1/networkpolicies", metav1.APIResource{
+		Name:         "networkpolicies",
+		SingularName: "networkpolicy",
+		Namespaced:   true,
+		Kind:         "NetworkPolicy",
+		Verbs:        []string{"get", "list", "watch", "delete"},
+		Categories:   []string{"k9s"},
+	})
 	dao.MetaAccess.RegisterMeta("v1/configmaps", metav1.APIResource{
 		Name:         "configmaps",
 		SingularName: "configmap",
@@ -0,0 +1,216 @@
+package view
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/model"
+	"github.com/derailed/k9s/internal/render"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	trendsTitle = "Trends"
+	trendsWidth = 48
+	trendsDay   = 24 * time.Hour
+)
+
+var trendSparks = []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+
+func trendsEnabled(a *App) bool {
+	return a.Config.K9s.Trends != nil && a.Config.K9s.Trends.Enable
+}
+
+func trendStore(a *App) *dao.TrendStore {
+	return dao.NewTrendStore(a.Config.K9s.GetTrendsDir())
+}
+
+// loadPulseConfig loads the pulses dashboard configuration. Metrics tiles are
+// dropped when no metrics server is available.
+func loadPulseConfig(a *App) *config.Pulse {
+	cfg := config.NewPulse()
+	if err := cfg.Load(config.K9sPulseConfigFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Warn().Err(err).Msgf("Pulse config load failed %s", config.K9sPulseConfigFile)
+		a.Flash().Warnf("Unable to load pulse config: %s", err)
+	}
+	tt := make([]config.PulseTile, 0, len(cfg.Tiles))
+	for _, t := range cfg.Tiles {
+		if t.IsMetrics() && !a.Conn().HasMetrics() {
+			continue
+		}
+		tt = append(tt, t)
+	}
+	cfg.Tiles = tt
+
+	return cfg
+}
+
+func recordTrends(ctx context.Context, a *App) {
+	if !trendsEnabled(a) || !a.Conn().ConnectionOK() {
+		return
+	}
+	r := model.NewTrendRecorder(a.factory, trendStore(a), a.Config.K9s.Trends, loadPulseConfig(a).Tiles)
+	go r.Watch(ctx)
+}
+
+func showTrends(a *App, subject string, keys []string, report func([]dao.TrendSample, time.Time) string) {
+	if !trendsEnabled(a) {
+		a.Flash().Warn("Trends recording is disabled. Enable it via k9s.trends.enable")
+		return
+	}
+	go func() {
+		now := time.Now()
+		ss, err := trendStore(a).Load(now.Add(-2*trendsDay), keys...)
+		a.QueueUpdateDraw(func() {
+			if err != nil {
+				a.Flash().Err(err)
+				return
+			}
+			if len(ss) == 0 {
+				a.Flash().Warn("No trends recorded yet. Check back later!")
+				return
+			}
+			details := NewDetails(a, trendsTitle, subject, true).Update(report(ss, now))
+			if err := a.inject(details); err != nil {
+				a.Flash().Err(err)
+			}
+		})
+	}()
+}
+
+// pulseTrends reports the cluster health trends.
+func pulseTrends(ss []dao.TrendSample, tt []config.PulseTile, now time.Time) string {
+	var b strings.Builder
+
+	from := now.Add(-trendsDay)
+	rr := dao.TrendSeries(ss, dao.TrendRestarts)
+	fmt.Fprintln(&b, "Restarts")
+	fmt.Fprintf(&b, "  Last hour:     %d\n", dao.TrendIncrease(rr, 0, now.Add(-time.Hour), now.Add(time.Second)))
+	fmt.Fprintf(&b, "  Last 24h:      %d\n", dao.TrendIncrease(rr, 0, from, now.Add(time.Second)))
+	fmt.Fprintf(&b, "  Previous 24h:  %d\n", dao.TrendIncrease(rr, 0, from.Add(-trendsDay), from))
+
+	fmt.Fprintln(&b, "\nHealth (last 24h averages)")
+	fmt.Fprintf(&b, "  %-20s %6s %6s %6s  %s\n", "RESOURCE", "OK", "WARN", "TOAST", "TOAST TREND")
+	for _, t := range tt {
+		if t.IsMetrics() {
+			continue
+		}
+		s := dao.TrendSeries(ss, t.GVR)
+		ok, _ := dao.TrendAverage(s, 0, from, now.Add(time.Second))
+		toast, _ := dao.TrendAverage(s, 1, from, now.Add(time.Second))
+		warn, _ := dao.TrendAverage(s, 2, from, now.Add(time.Second))
+		fmt.Fprintf(&b, "  %-20s %6d %6d %6d  %s\n", pulseTitleFor(t), ok, warn, toast, trendSpark(s, 1, from, now, trendsWidth))
+	}
+
+	for _, k := range []string{config.PulseCPU, config.PulseMEM} {
+		s := dao.TrendSeries(ss, k)
+		if len(s) == 0 {
+			continue
+		}
+		unit := "m"
+		if k == config.PulseMEM {
+			unit = "Mi"
+		}
+		fmt.Fprintf(&b, "\n%s\n", strings.ToUpper(k))
+		writeDailyTrend(&b, s, 0, 1, unit, now)
+	}
+
+	return b.String()
+}
+
+// nodeTrends reports a node metrics trends.
+func nodeTrends(ss []dao.TrendSample, node string, now time.Time) string {
+	var b strings.Builder
+
+	s := dao.TrendSeries(ss, dao.TrendNodeKey(node))
+	if len(s) == 0 {
+		return fmt.Sprintf("No metrics recorded for node %s\n", node)
+	}
+	fmt.Fprintln(&b, "CPU")
+	writeDailyTrend(&b, s, 0, 2, "m", now)
+	fmt.Fprintln(&b, "\nMEM")
+	writeDailyTrend(&b, s, 1, 3, "Mi", now)
+
+	return b.String()
+}
+
+// writeDailyTrend compares today vs yesterday average usage relative to a
+// reference ie allocatable.
+func writeDailyTrend(b *strings.Builder, ss []dao.TrendSample, idx, ref int, unit string, now time.Time) {
+	today := startOfDay(now)
+	for _, d := range []struct {
+		label    string
+		from, to time.Time
+	}{
+		{"Today", today, now.Add(time.Second)},
+		{"Yesterday", today.Add(-trendsDay), today},
+	} {
+		v, ok := dao.TrendAverage(ss, idx, d.from, d.to)
+		if !ok {
+			fmt.Fprintf(b, "  %-10s %s\n", d.label+":", render.NAValue)
+			continue
+		}
+		r, _ := dao.TrendAverage(ss, ref, d.from, d.to)
+		fmt.Fprintf(b, "  %-10s %s%s (%d%%)\n", d.label+":", render.AsThousands(v), unit, client.ToPercentage(v, r))
+	}
+	fmt.Fprintf(b, "  %-10s %s\n", "Last 24h:", trendSpark(ss, idx, now.Add(-trendsDay), now, trendsWidth))
+}
+
+// trendSpark renders a series as a sparkline of the given width over [from, to].
+func trendSpark(ss []dao.TrendSample, idx int, from, to time.Time, width int) string {
+	span := to.Sub(from)
+	if width <= 0 || span <= 0 {
+		return ""
+	}
+	var (
+		sums   = make([]int64, width)
+		counts = make([]int64, width)
+		max    int64
+	)
+	for _, s := range ss {
+		t := s.Time()
+		if t.Before(from) || t.After(to) {
+			continue
+		}
+		i := int(int64(t.Sub(from)) * int64(width) / int64(span))
+		if i >= width {
+			i = width - 1
+		}
+		sums[i] += s.Value(idx)
+		counts[i]++
+	}
+	for i := range sums {
+		if counts[i] > 0 {
+			sums[i] /= counts[i]
+		}
+		if sums[i] > max {
+			max = sums[i]
+		}
+	}
+
+	rr := make([]rune, width)
+	for i := range sums {
+		switch {
+		case counts[i] == 0:
+			rr[i] = ' '
+		case max == 0:
+			rr[i] = trendSparks[0]
+		default:
+			rr[i] = trendSparks[sums[i]*int64(len(trendSparks)-1)/max]
+		}
+	}
+
+	return string(rr)
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
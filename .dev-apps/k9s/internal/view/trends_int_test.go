@@ -0,0 +1,77 @@
+package view
+
+import (
+	"testing"
+	"time"
+
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrendSpark(t *testing.T) {
+	from := time.Unix(0, 0)
+	uu := map[string]struct {
+		ss []dao.TrendSample
+		e  string
+	}{
+		"empty": {
+			e: "    ",
+		},
+		"flat": {
+			ss: []dao.TrendSample{{At: 0, Values: []int64{0}}, {At: 30, Values: []int64{0}}},
+			e:  "▁  ▁",
+		},
+		"ramp": {
+			ss: []dao.TrendSample{
+				{At: 0, Values: []int64{0}},
+				{At: 10, Values: []int64{7}},
+				{At: 20, Values: []int64{14}},
+				{At: 40, Values: []int64{28}},
+			},
+			e: "▁▂▄█",
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, trendSpark(u.ss, 0, from, from.Add(40*time.Second), 4))
+		})
+	}
+}
+
+func TestNodeTrends(t *testing.T) {
+	now := time.Date(2024, 3, 2, 12, 0, 0, 0, time.UTC)
+	key := dao.TrendNodeKey("n1")
+	ss := []dao.TrendSample{
+		{At: now.Add(-30 * time.Hour).Unix(), Key: key, Values: []int64{100, 1000, 1000, 4000}},
+		{At: now.Add(-1 * time.Hour).Unix(), Key: key, Values: []int64{400, 2000, 1000, 4000}},
+		{At: now.Unix(), Key: key, Values: []int64{600, 2000, 1000, 4000}},
+	}
+
+	s := nodeTrends(ss, "n1", now)
+	assert.Contains(t, s, "Today:     500m (50%)")
+	assert.Contains(t, s, "Yesterday: 100m (10%)")
+	assert.Contains(t, s, "Today:     2,000Mi (50%)")
+	assert.Equal(t, "No metrics recorded for node n2\n", nodeTrends(ss, "n2", now))
+}
+
+func TestPulseTrends(t *testing.T) {
+	now := time.Unix(1_000_000, 0)
+	ss := []dao.TrendSample{
+		{At: now.Add(-30 * time.Hour).Unix(), Key: dao.TrendRestarts, Values: []int64{1}},
+		{At: now.Add(-25 * time.Hour).Unix(), Key: dao.TrendRestarts, Values: []int64{3}},
+		{At: now.Add(-2 * time.Hour).Unix(), Key: dao.TrendRestarts, Values: []int64{4}},
+		{At: now.Add(-30 * time.Minute).Unix(), Key: dao.TrendRestarts, Values: []int64{9}},
+		{At: now.Add(-time.Hour).Unix(), Key: "v1/pods", Values: []int64{10, 2, 1}},
+		{At: now.Unix(), Key: "v1/pods", Values: []int64{20, 4, 3}},
+	}
+
+	s := pulseTrends(ss, []config.PulseTile{{GVR: "v1/pods"}, {GVR: config.PulseCPU}}, now)
+	assert.Contains(t, s, "Last hour:     5")
+	assert.Contains(t, s, "Last 24h:      6")
+	assert.Contains(t, s, "Previous 24h:  2")
+	assert.Contains(t, s, "Pods                     15      2      3")
+	assert.NotContains(t, s, "CPU")
+}
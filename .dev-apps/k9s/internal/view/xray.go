@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"regexp"
 	"strings"
 	"time"
@@ -79,6 +80,11 @@ func (x *Xray) Init(ctx context.Context) error {
 	x.model.SetRefreshRate(time.Duration(x.app.Config.K9s.GetRefreshRate()) * time.Second)
 	x.model.SetNamespace(client.CleanseNamespace(x.app.Config.ActiveNamespace()))
 	x.model.AddListener(x)
+	rules, err := loadXrayRules()
+	if err != nil {
+		x.app.Flash().Warnf("Unable to load xray rules: %s", err)
+	}
+	x.model.SetRules(rules)
 
 	x.SetChangedFunc(func(n *tview.TreeNode) {
 		spec, ok := n.GetReference().(xray.NodeSpec)
@@ -115,6 +121,7 @@ func (x *Xray) bindKeys() {
 		ui.KeySlash:     ui.NewSharedKeyAction("Filter Mode", x.activateCmd, false),
 		tcell.KeyEscape: ui.NewSharedKeyAction("Filter Reset", x.resetCmd, false),
 		tcell.KeyEnter:  ui.NewKeyAction("Goto", x.gotoCmd, true),
+		tcell.KeyCtrlS:  ui.NewKeyAction("Export", x.exportCmd, false),
 	})
 }
 
@@ -131,6 +138,10 @@ func (x *Xray) refreshActions() {
 		hotKeyActions(x, aa)
 
 		x.Actions().Add(aa)
+		ns, _ := client.Namespaced(x.GetSelectedPath())
+		x.Actions().Guard(func(policy string) bool {
+			return x.app.Config.IsActionAllowed(policy, ns)
+		})
 		x.app.Menu().HydrateMenu(x.Hints())
 	}()
 
@@ -148,14 +159,15 @@ func (x *Xray) refreshActions() {
 	x.meta, err = dao.MetaAccess.MetaFor(client.NewGVR(gvr))
 	if err != nil {
 		log.Warn().Msgf("NO meta for %q -- %s", gvr, err)
+		x.Actions().Delete(tcell.KeyEnter)
 		return
 	}
 
 	if client.Can(x.meta.Verbs, "edit") {
-		aa[ui.KeyE] = ui.NewKeyAction("Edit", x.editCmd, true)
+		aa[ui.KeyE] = ui.NewDangerousKeyAction("Edit", x.editCmd, true, config.ActionEdit)
 	}
 	if client.Can(x.meta.Verbs, "delete") {
-		aa[tcell.KeyCtrlD] = ui.NewKeyAction("Delete", x.deleteCmd, true)
+		aa[tcell.KeyCtrlD] = ui.NewDangerousKeyAction("Delete", x.deleteCmd, true, config.ActionDelete)
 	}
 	if !dao.IsK9sMeta(x.meta) {
 		aa[ui.KeyY] = ui.NewKeyAction("YAML", x.viewCmd, true)
@@ -167,12 +179,12 @@ func (x *Xray) refreshActions() {
 		x.Actions().Delete(tcell.KeyEnter)
 	case "containers":
 		x.Actions().Delete(tcell.KeyEnter)
-		aa[ui.KeyS] = ui.NewKeyAction("Shell", x.shellCmd, true)
+		aa[ui.KeyS] = ui.NewDangerousKeyAction("Shell", x.shellCmd, true, config.ActionShell)
 		aa[ui.KeyL] = ui.NewKeyAction("Logs", x.logsCmd(false), true)
 		aa[ui.KeyP] = ui.NewKeyAction("Logs Previous", x.logsCmd(true), true)
 	case "v1/pods":
-		aa[ui.KeyS] = ui.NewKeyAction("Shell", x.shellCmd, true)
-		aa[ui.KeyA] = ui.NewKeyAction("Attach", x.attachCmd, true)
+		aa[ui.KeyS] = ui.NewDangerousKeyAction("Shell", x.shellCmd, true, config.ActionShell)
+		aa[ui.KeyA] = ui.NewDangerousKeyAction("Attach", x.attachCmd, true, config.ActionShell)
 		aa[ui.KeyL] = ui.NewKeyAction("Logs", x.logsCmd(false), true)
 		aa[ui.KeyP] = ui.NewKeyAction("Logs Previous", x.logsCmd(true), true)
 	}
@@ -401,6 +413,11 @@ func (x *Xray) editCmd(evt *tcell.EventKey) *tcell.EventKey {
 		return evt
 	}
 
+	if err := journalResources(x.app, dao.JournalEdit, client.NewGVR(spec.GVR()), spec.Path()); err != nil {
+		x.app.Flash().Err(err)
+		return nil
+	}
+
 	x.Stop()
 	defer x.Start()
 	{
@@ -413,9 +430,12 @@ func (x *Xray) editCmd(evt *tcell.EventKey) *tcell.EventKey {
 		if cfg := x.app.Conn().Config().Flags().KubeConfig; cfg != nil && *cfg != "" {
 			args = append(args, "--kubeconfig", *cfg)
 		}
+		var err error
 		if !runK(x.app, shellOpts{args: append(args, n)}) {
-			x.app.Flash().Err(errors.New("Edit exec failed"))
+			err = errors.New("Edit exec failed")
+			x.app.Flash().Err(err)
 		}
+		auditAction(x.app, auditEdit, client.NewGVR(spec.GVR()), spec.Path(), "", err)
 	}
 
 	return evt
@@ -470,17 +490,9 @@ func (x *Xray) filter(root *xray.TreeNode) *xray.TreeNode {
 	if x.CmdBuff().Empty() || ui.IsLabelSelector(q) {
 		return root
 	}
-
 	x.UpdateTitle()
-	if ui.IsFuzzySelector(q) {
-		return root.Filter(q, fuzzyFilter)
-	}
 
-	if ui.IsInverseSelector(q) {
-		return root.Filter(q, rxInverseFilter)
-	}
-
-	return root.Filter(q, rxFilter)
+	return filterXray(root, q)
 }
 
 // TreeNodeSelected callback for node selection.
@@ -672,7 +684,13 @@ func (x *Xray) resourceDelete(gvr client.GVR, spec *xray.NodeSpec, msg string) {
 			x.app.Flash().Errf("Invalid nuker %T", accessor)
 			return
 		}
-		if err := nuker.Delete(context.Background(), spec.Path(), nil, true); err != nil {
+		if err := journalResources(x.app, dao.JournalDelete, gvr, spec.Path()); err != nil {
+			x.app.Flash().Err(err)
+			return
+		}
+		err = nuker.Delete(context.Background(), spec.Path(), nil, true)
+		auditAction(x.app, auditDelete, gvr, spec.Path(), "", err)
+		if err != nil {
 			x.app.Flash().Errf("Delete failed with `%s", err)
 		} else {
 			x.app.Flash().Infof("%s `%s deleted successfully", x.GVR(), spec.Path())
@@ -685,6 +703,33 @@ func (x *Xray) resourceDelete(gvr client.GVR, spec *xray.NodeSpec, msg string) {
 // ----------------------------------------------------------------------------
 // Helpers...
 
+// loadXrayRules loads user defined relationship rules if any.
+func loadXrayRules() (*config.XrayRules, error) {
+	rr := config.NewXrayRules()
+	if err := rr.Load(config.K9sXrayConfigFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Warn().Err(err).Msgf("Xray rules load failed %s", config.K9sXrayConfigFile)
+		return rr, err
+	}
+
+	return rr, nil
+}
+
+// filterXray filters a tree given a fuzzy, inverse or regex query. Label
+// selectors are applied while listing resources hence are ignored here.
+func filterXray(root *xray.TreeNode, q string) *xray.TreeNode {
+	if root == nil || q == "" || ui.IsLabelSelector(q) {
+		return root
+	}
+	if ui.IsFuzzySelector(q) {
+		return root.Filter(q, fuzzyFilter)
+	}
+	if ui.IsInverseSelector(q) {
+		return root.Filter(q, rxInverseFilter)
+	}
+
+	return root.Filter(q, rxFilter)
+}
+
 func fuzzyFilter(q, path string) bool {
 	q = strings.TrimSpace(q[2:])
 	mm := fuzzy.Find(q, []string{path})
@@ -0,0 +1,163 @@
+package view
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/model"
+	"github.com/derailed/k9s/internal/ui"
+	"github.com/derailed/k9s/internal/watch"
+	"github.com/derailed/k9s/internal/xray"
+	"github.com/derailed/tview"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rs/zerolog/log"
+)
+
+const xrayExportDialogKey = "xray-export"
+
+// ExportXray writes out a resource xray tree without launching the UI.
+// The query follows the xray view filter syntax ie -l labels, -f fuzzy, !inverse
+// or a regex.
+func ExportXray(cfg *config.Config, res, ns, q, format string, w io.Writer) error {
+	conn := cfg.GetConnection()
+	if conn == nil || !conn.ConnectionOK() {
+		return errors.New("no cluster connection")
+	}
+	ns = client.CleanseNamespace(ns)
+	f := watch.NewFactory(conn)
+	f.Start(ns)
+	defer f.Terminate()
+
+	alias := dao.NewAlias(f)
+	if _, err := alias.Ensure(); err != nil {
+		return err
+	}
+	rules, err := loadXrayRules()
+	if err != nil {
+		return err
+	}
+	gvr, ok := alias.AsGVR(res)
+	if !ok || !allowedXRay(gvr, rules) {
+		return fmt.Errorf("xray is not supported for resource %q", res)
+	}
+
+	ctx := context.WithValue(context.Background(), internal.KeyFactory, f)
+	ctx = context.WithValue(ctx, internal.KeyFields, "")
+	var sel string
+	if ui.IsLabelSelector(q) {
+		sel = ui.TrimLabelSelector(q)
+	}
+	ctx = context.WithValue(ctx, internal.KeyLabels, sel)
+
+	t := model.NewTree(gvr)
+	t.SetNamespace(ns)
+	t.SetRules(rules)
+	root, err := loadXray(ctx, f, t)
+	if err != nil {
+		return err
+	}
+
+	return xray.Export(w, filterXray(root, q), format)
+}
+
+// loadXray builds a tree off a cold factory. Informers are registered lazily on
+// first load hence a second pass once synced.
+func loadXray(ctx context.Context, f dao.Factory, t *model.Tree) (*xray.TreeNode, error) {
+	if _, err := t.Load(ctx); err != nil {
+		return nil, err
+	}
+	f.WaitForCacheSync()
+
+	return t.Load(ctx)
+}
+
+func (x *Xray) exportCmd(evt *tcell.EventKey) *tcell.EventKey {
+	if x.model.Peek() == nil {
+		x.app.Flash().Warn("Nothing to export yet!")
+		return nil
+	}
+	x.showExportDialog()
+
+	return nil
+}
+
+func (x *Xray) showExportDialog() {
+	f := tview.NewForm()
+	f.SetItemPadding(0)
+	f.SetButtonsAlign(tview.AlignCenter).
+		SetButtonBackgroundColor(tview.Styles.PrimitiveBackgroundColor).
+		SetButtonTextColor(tview.Styles.PrimaryTextColor).
+		SetLabelColor(tcell.ColorAqua).
+		SetFieldTextColor(tcell.ColorOrange)
+
+	ff := xray.ExportFormats()
+	format := ff[0]
+	f.AddDropDown("Format:", ff, 0, func(opt string, _ int) {
+		format = opt
+	})
+	f.AddButton("OK", func() {
+		x.dismissExportDialog()
+		x.export(format)
+	})
+	f.AddButton("Cancel", func() {
+		x.dismissExportDialog()
+	})
+
+	modal := tview.NewModalForm("<Export>", f)
+	modal.SetText(fmt.Sprintf("Export %s xray tree", x.gvr.R()))
+	modal.SetDoneFunc(func(int, string) {
+		x.dismissExportDialog()
+	})
+	x.app.Content.AddPage(xrayExportDialogKey, modal, false, false)
+	x.app.Content.ShowPage(xrayExportDialogKey)
+}
+
+func (x *Xray) dismissExportDialog() {
+	x.app.Content.RemovePage(xrayExportDialogKey)
+}
+
+func (x *Xray) export(format string) {
+	root := x.filter(x.model.Peek())
+	path, err := saveXray(x.app.Config.K9s.GetScreenDumpDir(), x.app.Config.K9s.CurrentContextDir(), x.gvr.R(), format, root)
+	if err != nil {
+		x.app.Flash().Err(err)
+		return
+	}
+	x.app.Flash().Infof("Xray exported to %s", path)
+}
+
+func saveXray(screenDumpDir, context, res, format string, root *xray.TreeNode) (string, error) {
+	dir := filepath.Join(screenDumpDir, context)
+	if err := ensureDir(dir); err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("xray-%s-%d%s", config.SanitizeFilename(res), time.Now().Unix(), xray.ExportExt(format))
+	path := filepath.Join(dir, strings.ToLower(name))
+	log.Debug().Msgf("Saving xray to %s", path)
+
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err := out.Close(); err != nil {
+			log.Error().Err(err).Msg("Closing file")
+		}
+	}()
+
+	if err := xray.Export(out, root, format); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
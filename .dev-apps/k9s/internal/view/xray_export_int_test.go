@@ -0,0 +1,169 @@
+package view
+
+import (
+	"context"
+	"testing"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/model"
+	"github.com/derailed/k9s/internal/watch"
+	"github.com/derailed/k9s/internal/xray"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+)
+
+func TestFilterXray(t *testing.T) {
+	uu := map[string]struct {
+		q string
+		e []string
+	}{
+		"none": {
+			e: []string{"default/nginx", "default/nginx-1", "default/fred"},
+		},
+		"labels": {
+			q: "-l app=nginx",
+			e: []string{"default/nginx", "default/nginx-1", "default/fred"},
+		},
+		"regex": {
+			q: "nginx-1",
+			e: []string{"default/nginx", "default/nginx-1"},
+		},
+		"inverse": {
+			q: "!nginx-1",
+			e: []string{"default/nginx", "default/fred"},
+		},
+		"fuzzy": {
+			q: "-f frd",
+			e: []string{"default/nginx", "default/fred"},
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			root := filterXray(xrayExportTree(), u.q)
+			assert.NotNil(t, root)
+			ids := make([]string, 0, len(u.e))
+			var walk func(n *xray.TreeNode)
+			walk = func(n *xray.TreeNode) {
+				ids = append(ids, n.ID)
+				for _, c := range n.Children {
+					walk(c)
+				}
+			}
+			walk(root)
+			assert.Equal(t, u.e, ids)
+		})
+	}
+}
+
+func TestLoadXray(t *testing.T) {
+	f := newXrayFactory(map[string][]runtime.Object{
+		"apps/v1/deployments": {
+			xrayObject(map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata":   map[string]interface{}{"namespace": "default", "name": "nginx"},
+				"spec": map[string]interface{}{
+					"selector": map[string]interface{}{"matchLabels": map[string]interface{}{"app": "nginx"}},
+				},
+			}),
+		},
+		"v1/pods": {
+			xrayObject(map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"metadata": map[string]interface{}{
+					"namespace": "default",
+					"name":      "nginx-1",
+					"labels":    map[string]interface{}{"app": "nginx"},
+				},
+				"spec": map[string]interface{}{
+					"containers": []interface{}{map[string]interface{}{"name": "nginx", "image": "nginx"}},
+				},
+			}),
+		},
+	})
+	ctx := context.WithValue(context.Background(), internal.KeyFactory, f)
+	ctx = context.WithValue(ctx, internal.KeyFields, "")
+	ctx = context.WithValue(ctx, internal.KeyLabels, "")
+	tree := model.NewTree(client.NewGVR("apps/v1/deployments"))
+	tree.SetNamespace("default")
+
+	root, err := loadXray(ctx, f, tree)
+	assert.Nil(t, err)
+	assert.True(t, f.synced)
+	assert.NotNil(t, root.Find("apps/v1/deployments", "default/nginx"))
+	assert.NotNil(t, root.Find("v1/pods", "default/nginx-1"))
+}
+
+// Helpers...
+
+// xrayFactory mimics a cold informer cache only serving non blocking lists once synced.
+type xrayFactory struct {
+	rows   map[string][]runtime.Object
+	synced bool
+}
+
+var _ dao.Factory = (*xrayFactory)(nil)
+
+func newXrayFactory(rows map[string][]runtime.Object) *xrayFactory {
+	return &xrayFactory{rows: rows}
+}
+
+func (f *xrayFactory) Client() client.Connection {
+	return nil
+}
+
+func (f *xrayFactory) Get(gvr, path string, wait bool, sel labels.Selector) (runtime.Object, error) {
+	return nil, nil
+}
+
+func (f *xrayFactory) List(gvr, ns string, wait bool, sel labels.Selector) ([]runtime.Object, error) {
+	if !wait && !f.synced {
+		return nil, nil
+	}
+	oo := make([]runtime.Object, 0, len(f.rows[gvr]))
+	for _, o := range f.rows[gvr] {
+		if sel == nil || sel.Matches(labels.Set(o.(*unstructured.Unstructured).GetLabels())) {
+			oo = append(oo, o)
+		}
+	}
+
+	return oo, nil
+}
+
+func (f *xrayFactory) ForResource(ns, gvr string) (informers.GenericInformer, error) {
+	return nil, nil
+}
+
+func (f *xrayFactory) CanForResource(ns, gvr string, verbs []string) (informers.GenericInformer, error) {
+	return nil, nil
+}
+
+func (f *xrayFactory) WaitForCacheSync() {
+	f.synced = true
+}
+
+func (f *xrayFactory) DeleteForwarder(string) {}
+
+func (f *xrayFactory) Forwarders() watch.Forwarders {
+	return nil
+}
+
+func xrayObject(m map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: m}
+}
+
+func xrayExportTree() *xray.TreeNode {
+	root := xray.NewTreeNode("apps/v1/deployments", "default/nginx")
+	root.Add(xray.NewTreeNode("v1/pods", "default/nginx-1"))
+	root.Add(xray.NewTreeNode("v1/secrets", "default/fred"))
+
+	return root
+}
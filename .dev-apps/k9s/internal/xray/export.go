@@ -0,0 +1,198 @@
+package xray
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// ExportDOT exports a tree as a Graphviz digraph.
+	ExportDOT = "dot"
+
+	// ExportMermaid exports a tree as a Mermaid flowchart.
+	ExportMermaid = "mermaid"
+
+	// ExportJSON exports a tree as a JSON document.
+	ExportJSON = "json"
+)
+
+// ExportFormats returns the supported export formats.
+func ExportFormats() []string {
+	return []string{ExportDOT, ExportMermaid, ExportJSON}
+}
+
+// ExportExt returns an export format file extension.
+func ExportExt(format string) string {
+	switch format {
+	case ExportDOT:
+		return ".dot"
+	case ExportMermaid:
+		return ".mmd"
+	default:
+		return ".json"
+	}
+}
+
+// ExportNode represents an exported tree node.
+type ExportNode struct {
+	GVR      string        `json:"gvr"`
+	Kind     string        `json:"kind,omitempty"`
+	ID       string        `json:"id"`
+	Status   string        `json:"status"`
+	Info     string        `json:"info,omitempty"`
+	Children []*ExportNode `json:"children,omitempty"`
+}
+
+// Export writes out a tree in the given format.
+func Export(w io.Writer, root *TreeNode, format string) error {
+	if root == nil {
+		return fmt.Errorf("no xray data to export")
+	}
+
+	switch format {
+	case ExportDOT:
+		return exportDOT(w, root)
+	case ExportMermaid:
+		return exportMermaid(w, root)
+	case ExportJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(ToExportNode(root))
+	default:
+		return fmt.Errorf("unsupported export format %q. Must be one of %s", format, strings.Join(ExportFormats(), ", "))
+	}
+}
+
+// ToExportNode converts a tree to its exported representation.
+func ToExportNode(t *TreeNode) *ExportNode {
+	n := ExportNode{
+		GVR:    t.GVR,
+		Kind:   category(t.GVR),
+		ID:     t.ID,
+		Status: t.status(),
+		Info:   t.Extras[InfoKey],
+	}
+	for _, c := range t.Children {
+		n.Children = append(n.Children, ToExportNode(c))
+	}
+
+	return &n
+}
+
+// ----------------------------------------------------------------------------
+// Helpers...
+
+func (t *TreeNode) status() string {
+	if s, ok := t.Extras[StatusKey]; ok && s != "" {
+		return s
+	}
+
+	return OkStatus
+}
+
+func (t *TreeNode) exportLabels() []string {
+	ll := make([]string, 0, 4)
+	if k := category(t.GVR); k != "" {
+		ll = append(ll, k)
+	}
+	ll = append(ll, t.ID)
+	if s := t.status(); s != OkStatus {
+		ll = append(ll, strings.ToUpper(s))
+	}
+	if info := t.Extras[InfoKey]; info != "" {
+		ll = append(ll, info)
+	}
+
+	return ll
+}
+
+func exportColor(status string) string {
+	switch status {
+	case ToastStatus:
+		return "#ff4500"
+	case MissingRefStatus:
+		return "#ffa500"
+	case CompletedStatus:
+		return "#d3d3d3"
+	default:
+		return "#98fb98"
+	}
+}
+
+// walk visits all nodes depth first, assigning each a unique identifier.
+func walk(t *TreeNode, visit func(id string, n *TreeNode, parent string)) {
+	var (
+		count int
+		rec   func(n *TreeNode, parent string)
+	)
+	rec = func(n *TreeNode, parent string) {
+		id := fmt.Sprintf("n%d", count)
+		count++
+		visit(id, n, parent)
+		for _, c := range n.Children {
+			rec(c, id)
+		}
+	}
+	rec(t, "")
+}
+
+func exportDOT(w io.Writer, root *TreeNode) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %q {\n", root.ID)
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+	walk(root, func(id string, n *TreeNode, parent string) {
+		ll := n.exportLabels()
+		for i := range ll {
+			ll[i] = dotEscape(ll[i])
+		}
+		fmt.Fprintf(&b, "  %s [label=\"%s\", fillcolor=%q];\n", id, strings.Join(ll, `\n`), exportColor(n.status()))
+		if parent != "" {
+			fmt.Fprintf(&b, "  %s -> %s;\n", parent, id)
+		}
+	})
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+func exportMermaid(w io.Writer, root *TreeNode) error {
+	var (
+		b       strings.Builder
+		classes = make(map[string][]string)
+	)
+	b.WriteString("graph LR\n")
+	walk(root, func(id string, n *TreeNode, parent string) {
+		ll := n.exportLabels()
+		for i := range ll {
+			ll[i] = mermaidEscape(ll[i])
+		}
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", id, strings.Join(ll, "<br/>"))
+		if parent != "" {
+			fmt.Fprintf(&b, "  %s --> %s\n", parent, id)
+		}
+		classes[n.status()] = append(classes[n.status()], id)
+	})
+	for _, s := range []string{OkStatus, CompletedStatus, MissingRefStatus, ToastStatus} {
+		ids, ok := classes[s]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(&b, "  classDef %s fill:%s,stroke:#333\n", s, exportColor(s))
+		fmt.Fprintf(&b, "  class %s %s\n", strings.Join(ids, ","), s)
+	}
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+func mermaidEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;", "\n", "<br/>").Replace(s)
+}
@@ -0,0 +1,127 @@
+package xray_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/derailed/k9s/internal/xray"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExport(t *testing.T) {
+	uu := map[string]struct {
+		root   *xray.TreeNode
+		format string
+		err    string
+		e      string
+	}{
+		"no-tree": {
+			format: xray.ExportJSON,
+			err:    "no xray data to export",
+		},
+		"bad-format": {
+			root:   exportTree(),
+			format: "svg",
+			err:    `unsupported export format "svg". Must be one of dot, mermaid, json`,
+		},
+		"dot": {
+			root:   exportTree(),
+			format: xray.ExportDOT,
+			e: `digraph "default/nginx" {
+  rankdir=LR;
+  node [shape=box, style="rounded,filled", fontname="Helvetica"];
+  n0 [label="default/nginx\nTOAST\n1/2", fillcolor="#ff4500"];
+  n1 [label="default/nginx-1", fillcolor="#98fb98"];
+  n0 -> n1;
+  n2 [label="default/\"fred\"\nNOREF", fillcolor="#ffa500"];
+  n0 -> n2;
+}
+`,
+		},
+		"mermaid": {
+			root:   exportTree(),
+			format: xray.ExportMermaid,
+			e: `graph LR
+  n0["default/nginx<br/>TOAST<br/>1/2"]
+  n1["default/nginx-1"]
+  n0 --> n1
+  n2["default/#quot;fred#quot;<br/>NOREF"]
+  n0 --> n2
+  classDef ok fill:#98fb98,stroke:#333
+  class n1 ok
+  classDef noref fill:#ffa500,stroke:#333
+  class n2 noref
+  classDef toast fill:#ff4500,stroke:#333
+  class n0 toast
+`,
+		},
+		"json": {
+			root:   exportTree(),
+			format: xray.ExportJSON,
+			e: `{
+  "gvr": "apps/v1/deployments",
+  "id": "default/nginx",
+  "status": "toast",
+  "info": "1/2",
+  "children": [
+    {
+      "gvr": "v1/pods",
+      "id": "default/nginx-1",
+      "status": "ok"
+    },
+    {
+      "gvr": "v1/secrets",
+      "id": "default/\"fred\"",
+      "status": "noref"
+    }
+  ]
+}
+`,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			var b bytes.Buffer
+			err := xray.Export(&b, u.root, u.format)
+			if u.err != "" {
+				assert.EqualError(t, err, u.err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, u.e, b.String())
+		})
+	}
+}
+
+func TestExportExt(t *testing.T) {
+	uu := map[string]struct {
+		format, e string
+	}{
+		"dot":     {format: xray.ExportDOT, e: ".dot"},
+		"mermaid": {format: xray.ExportMermaid, e: ".mmd"},
+		"json":    {format: xray.ExportJSON, e: ".json"},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, u.e, xray.ExportExt(u.format))
+		})
+	}
+}
+
+// Helpers...
+
+func exportTree() *xray.TreeNode {
+	root := xray.NewTreeNode("apps/v1/deployments", "default/nginx")
+	root.Extras[xray.StatusKey] = xray.ToastStatus
+	root.Extras[xray.InfoKey] = "1/2"
+	root.Add(xray.NewTreeNode("v1/pods", "default/nginx-1"))
+	sec := xray.NewTreeNode("v1/secrets", `default/"fred"`)
+	sec.Extras[xray.StatusKey] = xray.MissingRefStatus
+	root.Add(sec)
+
+	return root
+}
@@ -0,0 +1,524 @@
+package xray
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/dao"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	routeGVR         = "routes"
+	endpointSliceGVR = "discovery.k8s.io/v1/endpointslices"
+	netpolGVR        = "networking.k8s.io/v1/networkpolicies"
+)
+
+// backendPort represents a service port reference either by number or name.
+type backendPort struct {
+	number int32
+	name   string
+}
+
+func (p backendPort) String() string {
+	if p.name != "" {
+		return p.name
+	}
+
+	return strconv.Itoa(int(p.number))
+}
+
+func (p backendPort) matches(sp v1.ServicePort) bool {
+	if p.name != "" {
+		return sp.Name == p.name
+	}
+
+	return sp.Port == p.number
+}
+
+// netPath walks a network path from a service down to its target pods.
+type netPath struct {
+	factory dao.Factory
+	src     dao.NetPolPeer
+}
+
+func newNetPath(ctx context.Context, controllerNS string) (*netPath, error) {
+	f, ok := ctx.Value(internal.KeyFactory).(dao.Factory)
+	if !ok {
+		return nil, fmt.Errorf("expecting a factory but got %T", ctx.Value(internal.KeyFactory))
+	}
+	n := netPath{
+		factory: f,
+		src:     dao.NetPolPeer{Namespace: controllerNS},
+	}
+	o, err := f.Get("v1/namespaces", client.FQN(client.ClusterScope, controllerNS), false, labels.Everything())
+	if u, ok := o.(*unstructured.Unstructured); err == nil && ok {
+		n.src.NSLabels = u.GetLabels()
+	}
+
+	return &n, nil
+}
+
+func controllerNamespace(ctx context.Context) string {
+	ns, _ := ctx.Value(KeyControllerNS).(string)
+	return ns
+}
+
+// service renders a service backend.
+func (n *netPath) service(ns, name string, port backendPort) (*TreeNode, error) {
+	fqn := client.FQN(ns, name)
+	root := NewTreeNode("v1/services", fqn)
+	o, err := n.factory.Get("v1/services", fqn, false, labels.Everything())
+	if err != nil || o == nil {
+		root.Extras[StatusKey] = MissingRefStatus
+		root.Extras[InfoKey] = "service not found"
+		return root, nil
+	}
+	u, ok := o.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("expecting *Unstructured but got %T", o)
+	}
+	var svc v1.Service
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &svc); err != nil {
+		return nil, err
+	}
+
+	root.Extras[StatusKey] = OkStatus
+	if svc.Spec.Type == v1.ServiceTypeExternalName {
+		root.Extras[InfoKey] = "external " + svc.Spec.ExternalName
+		return root, nil
+	}
+	var sp *v1.ServicePort
+	for i := range svc.Spec.Ports {
+		if port.matches(svc.Spec.Ports[i]) {
+			sp = &svc.Spec.Ports[i]
+			break
+		}
+	}
+	if sp == nil {
+		root.Extras[StatusKey] = ToastStatus
+		root.Extras[InfoKey] = fmt.Sprintf("port %s not exposed", port)
+		return root, nil
+	}
+	root.Extras[InfoKey] = fmt.Sprintf("%d→%s", sp.Port, sp.TargetPort.String())
+
+	if len(svc.Spec.Selector) > 0 {
+		pp, err := n.factory.List("v1/pods", ns, false, labels.SelectorFromSet(svc.Spec.Selector))
+		if err != nil {
+			return nil, err
+		}
+		if len(pp) == 0 {
+			root.Extras[StatusKey] = ToastStatus
+			root.Extras[InfoKey] = "selector matches no pods"
+			return root, nil
+		}
+	}
+
+	ss, err := n.factory.List(endpointSliceGVR, ns, false, labels.SelectorFromSet(labels.Set{discoveryv1.LabelServiceName: name}))
+	if err != nil {
+		return nil, err
+	}
+	if len(ss) == 0 {
+		root.Extras[StatusKey] = ToastStatus
+		root.Extras[InfoKey] = "no endpoints"
+		return root, nil
+	}
+	for _, o := range ss {
+		u, ok := o.(*unstructured.Unstructured)
+		if !ok {
+			return nil, fmt.Errorf("expecting *Unstructured but got %T", o)
+		}
+		var slice discoveryv1.EndpointSlice
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &slice); err != nil {
+			return nil, err
+		}
+		c, err := n.endpointSlice(slice, *sp)
+		if err != nil {
+			return nil, err
+		}
+		if c.Extras[StatusKey] != OkStatus {
+			root.Extras[StatusKey] = ToastStatus
+		}
+		root.Add(c)
+	}
+
+	return root, nil
+}
+
+func (n *netPath) endpointSlice(slice discoveryv1.EndpointSlice, sp v1.ServicePort) (*TreeNode, error) {
+	root := NewTreeNode(endpointSliceGVR, client.FQN(slice.Namespace, slice.Name))
+	root.Extras[StatusKey] = OkStatus
+
+	port := dao.NetPolPort{Protocol: sp.Protocol}
+	var found bool
+	for _, p := range slice.Ports {
+		// A nil port name stands for the service unnamed port.
+		var name string
+		if p.Name != nil {
+			name = *p.Name
+		}
+		if name == sp.Name && p.Port != nil {
+			found, port.Port = true, *p.Port
+			break
+		}
+	}
+	if !found {
+		root.Extras[StatusKey] = ToastStatus
+		root.Extras[InfoKey] = fmt.Sprintf("port mismatch %s", sp.TargetPort.String())
+		return root, nil
+	}
+	if sp.TargetPort.Type == intstr.String {
+		port.Name = sp.TargetPort.StrVal
+	}
+
+	var ready int
+	for _, e := range slice.Endpoints {
+		ok := e.Conditions.Ready == nil || *e.Conditions.Ready
+		if ok {
+			ready++
+		}
+		if e.TargetRef == nil || e.TargetRef.Kind != "Pod" {
+			continue
+		}
+		c, err := n.pod(client.FQN(e.TargetRef.Namespace, e.TargetRef.Name), port, ok)
+		if err != nil {
+			return nil, err
+		}
+		if c.Extras[StatusKey] != OkStatus {
+			root.Extras[StatusKey] = ToastStatus
+		}
+		root.Add(c)
+	}
+	root.Extras[InfoKey] = fmt.Sprintf("%d/%d ready", ready, len(slice.Endpoints))
+	if ready < len(slice.Endpoints) {
+		root.Extras[StatusKey] = ToastStatus
+	}
+
+	return root, nil
+}
+
+func (n *netPath) pod(fqn string, port dao.NetPolPort, ready bool) (*TreeNode, error) {
+	root := NewTreeNode("v1/pods", fqn)
+	root.Extras[StatusKey] = OkStatus
+	if !ready {
+		root.Extras[StatusKey] = ToastStatus
+		root.Extras[InfoKey] = "not ready"
+	}
+	o, err := n.factory.Get("v1/pods", fqn, false, labels.Everything())
+	if err != nil || o == nil {
+		root.Extras[StatusKey] = MissingRefStatus
+		return root, nil
+	}
+	u, ok := o.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("expecting *Unstructured but got %T", o)
+	}
+
+	var po v1.Pod
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &po); err != nil {
+		return nil, err
+	}
+	port = dao.ResolveNetPolPort(&po, port)
+
+	ns, _ := client.Namespaced(fqn)
+	pp, err := n.factory.List(netpolGVR, ns, false, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	nn := make([]netv1.NetworkPolicy, 0, len(pp))
+	for _, o := range pp {
+		u, ok := o.(*unstructured.Unstructured)
+		if !ok {
+			return nil, fmt.Errorf("expecting *Unstructured but got %T", o)
+		}
+		var np netv1.NetworkPolicy
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &np); err != nil {
+			return nil, err
+		}
+		nn = append(nn, np)
+	}
+	ll := u.GetLabels()
+	if ll == nil {
+		ll = make(map[string]string)
+	}
+	v := dao.EvalIngress(nn, n.src, dao.NetPolPeer{Namespace: ns, PodLabels: ll}, port)
+
+	allowing := make(map[string]struct{}, len(v.Allowing))
+	for _, p := range v.Allowing {
+		allowing[p] = struct{}{}
+	}
+	for _, p := range v.Policies {
+		c := NewTreeNode(netpolGVR, p)
+		c.Extras[StatusKey] = OkStatus
+		c.Extras[InfoKey] = "allows " + n.src.Namespace
+		if _, ok := allowing[p]; !ok {
+			c.Extras[StatusKey] = ToastStatus
+			c.Extras[InfoKey] = "no rule for " + n.src.Namespace
+		}
+		root.Add(c)
+	}
+	if !v.Allowed {
+		root.Extras[StatusKey] = ToastStatus
+		root.Extras[InfoKey] = fmt.Sprintf("ingress from %s denied", n.src.Namespace)
+	}
+
+	return root, nil
+}
+
+// addToNamespace adds a node under its namespace node.
+func addToNamespace(parent, n *TreeNode, ns string) {
+	gvr, nsID := "v1/namespaces", client.FQN(client.ClusterScope, ns)
+	nsn := parent.Find(gvr, nsID)
+	if nsn == nil {
+		nsn = NewTreeNode(gvr, nsID)
+		parent.Add(nsn)
+	}
+	nsn.Add(n)
+}
+
+// routeNode returns a new route node.
+func routeNode(host, path string) *TreeNode {
+	if host == "" {
+		host = "*"
+	}
+	if path == "" {
+		path = "/"
+	}
+
+	return NewTreeNode(routeGVR, host+path)
+}
+
+// markRoute flags a route with a broken path.
+func markRoute(route *TreeNode) {
+	route.Extras[StatusKey] = OkStatus
+	for _, c := range route.Children {
+		if c.Extras[StatusKey] != OkStatus {
+			route.Extras[StatusKey] = ToastStatus
+			return
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+
+// Ingress represents an xray renderer.
+type Ingress struct{}
+
+// Render renders an xray node.
+func (i *Ingress) Render(ctx context.Context, ns string, o interface{}) error {
+	raw, ok := o.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("expecting *Unstructured but got %T", o)
+	}
+	var ing netv1.Ingress
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(raw.Object, &ing); err != nil {
+		return err
+	}
+	parent, ok := ctx.Value(KeyParent).(*TreeNode)
+	if !ok {
+		return fmt.Errorf("expecting a TreeNode but got %T", ctx.Value(KeyParent))
+	}
+	np, err := newNetPath(ctx, controllerNamespace(ctx))
+	if err != nil {
+		return err
+	}
+
+	root := NewTreeNode("networking.k8s.io/v1/ingresses", client.FQN(ing.Namespace, ing.Name))
+	root.Extras[StatusKey] = OkStatus
+	if ing.Spec.IngressClassName != nil {
+		root.Extras[InfoKey] = *ing.Spec.IngressClassName
+	}
+	if b := ing.Spec.DefaultBackend; b != nil && b.Service != nil {
+		if err := i.addRoute(np, root, ing.Namespace, "", "", b); err != nil {
+			return err
+		}
+	}
+	for _, r := range ing.Spec.Rules {
+		if r.HTTP == nil {
+			continue
+		}
+		for _, p := range r.HTTP.Paths {
+			if err := i.addRoute(np, root, ing.Namespace, r.Host, p.Path, &p.Backend); err != nil {
+				return err
+			}
+		}
+	}
+	addToNamespace(parent, root, ing.Namespace)
+
+	return nil
+}
+
+func (*Ingress) addRoute(np *netPath, root *TreeNode, ns, host, path string, b *netv1.IngressBackend) error {
+	route := routeNode(host, path)
+	if b.Service == nil {
+		route.Extras[StatusKey] = OkStatus
+		route.Extras[InfoKey] = "resource backend"
+		root.Add(route)
+		return nil
+	}
+	port := backendPort{number: b.Service.Port.Number, name: b.Service.Port.Name}
+	svc, err := np.service(ns, b.Service.Name, port)
+	if err != nil {
+		return err
+	}
+	route.Add(svc)
+	markRoute(route)
+	if route.Extras[StatusKey] != OkStatus {
+		root.Extras[StatusKey] = ToastStatus
+	}
+	root.Add(route)
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+
+// Gateway represents an xray renderer.
+type Gateway struct{}
+
+// Render renders an xray node.
+func (g *Gateway) Render(ctx context.Context, ns string, o interface{}) error {
+	raw, ok := o.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("expecting *Unstructured but got %T", o)
+	}
+	parent, ok := ctx.Value(KeyParent).(*TreeNode)
+	if !ok {
+		return fmt.Errorf("expecting a TreeNode but got %T", ctx.Value(KeyParent))
+	}
+	// Gateway data planes typically run alongside the gateway.
+	np, err := newNetPath(ctx, raw.GetNamespace())
+	if err != nil {
+		return err
+	}
+
+	gv := raw.GetAPIVersion()
+	root := NewTreeNode(gv+"/gateways", client.FQN(raw.GetNamespace(), raw.GetName()))
+	root.Extras[StatusKey] = OkStatus
+	if c, ok, _ := unstructured.NestedString(raw.Object, "spec", "gatewayClassName"); ok {
+		root.Extras[InfoKey] = c
+	}
+
+	rr, err := np.factory.List(gv+"/httproutes", client.AllNamespaces, false, labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, o := range rr {
+		u, ok := o.(*unstructured.Unstructured)
+		if !ok {
+			return fmt.Errorf("expecting *Unstructured but got %T", o)
+		}
+		if !attachedTo(u, raw) {
+			continue
+		}
+		c, err := g.httpRoute(np, gv, u)
+		if err != nil {
+			return err
+		}
+		if c.Extras[StatusKey] != OkStatus {
+			root.Extras[StatusKey] = ToastStatus
+		}
+		root.Add(c)
+	}
+	addToNamespace(parent, root, raw.GetNamespace())
+
+	return nil
+}
+
+func (*Gateway) httpRoute(np *netPath, gv string, u *unstructured.Unstructured) (*TreeNode, error) {
+	root := NewTreeNode(gv+"/httproutes", client.FQN(u.GetNamespace(), u.GetName()))
+	root.Extras[StatusKey] = OkStatus
+
+	hosts, _, _ := unstructured.NestedStringSlice(u.Object, "spec", "hostnames")
+	if len(hosts) == 0 {
+		hosts = []string{""}
+	}
+	rules, _, _ := unstructured.NestedSlice(u.Object, "spec", "rules")
+	for _, r := range rules {
+		rule, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		paths := []string{""}
+		if mm, ok, _ := unstructured.NestedSlice(rule, "matches"); ok && len(mm) > 0 {
+			paths = paths[:0]
+			for _, m := range mm {
+				mp, _ := m.(map[string]interface{})
+				p, _, _ := unstructured.NestedString(mp, "path", "value")
+				paths = append(paths, p)
+			}
+		}
+		refs, _, _ := unstructured.NestedSlice(rule, "backendRefs")
+		for _, h := range hosts {
+			for _, p := range paths {
+				route := routeNode(h, p)
+				for _, ref := range refs {
+					c, err := backendRef(np, u.GetNamespace(), ref)
+					if err != nil {
+						return nil, err
+					}
+					if c != nil {
+						route.Add(c)
+					}
+				}
+				markRoute(route)
+				if route.Extras[StatusKey] != OkStatus {
+					root.Extras[StatusKey] = ToastStatus
+				}
+				root.Add(route)
+			}
+		}
+	}
+
+	return root, nil
+}
+
+func backendRef(np *netPath, ns string, o interface{}) (*TreeNode, error) {
+	ref, ok := o.(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+	if k, _, _ := unstructured.NestedString(ref, "kind"); k != "" && k != "Service" {
+		return nil, nil
+	}
+	name, _, _ := unstructured.NestedString(ref, "name")
+	if refNS, _, _ := unstructured.NestedString(ref, "namespace"); refNS != "" {
+		ns = refNS
+	}
+	port, _, _ := unstructured.NestedInt64(ref, "port")
+
+	return np.service(ns, name, backendPort{number: int32(port)})
+}
+
+// attachedTo checks if a route references a given gateway.
+func attachedTo(route, gw *unstructured.Unstructured) bool {
+	refs, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+	for _, r := range refs {
+		ref, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if k, _, _ := unstructured.NestedString(ref, "kind"); k != "" && k != "Gateway" {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(ref, "name")
+		ns, _, _ := unstructured.NestedString(ref, "namespace")
+		if ns == "" {
+			ns = route.GetNamespace()
+		}
+		if name == gw.GetName() && ns == gw.GetNamespace() {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,315 @@
+package xray_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/xray"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestIngressRender(t *testing.T) {
+	uu := map[string]struct {
+		port  int32
+		rows  map[string][]runtime.Object
+		nodes []string
+	}{
+		"happy": {
+			port: 80,
+			rows: netRows(t, true, false),
+			nodes: []string{
+				"v1/namespaces::-/default ok",
+				"networking.k8s.io/v1/ingresses::default/ing ok nginx",
+				"routes::fred.com/api ok",
+				"v1/services::default/svc ok 80→http",
+				"discovery.k8s.io/v1/endpointslices::default/svc-1 ok 1/1 ready",
+				"v1/pods::default/web-1 ok",
+			},
+		},
+		"no-service": {
+			port: 80,
+			rows: map[string][]runtime.Object{},
+			nodes: []string{
+				"v1/namespaces::-/default ok",
+				"networking.k8s.io/v1/ingresses::default/ing toast nginx",
+				"routes::fred.com/api toast",
+				"v1/services::default/svc noref service not found",
+			},
+		},
+		"port-mismatch": {
+			port: 8080,
+			rows: netRows(t, true, false),
+			nodes: []string{
+				"v1/namespaces::-/default ok",
+				"networking.k8s.io/v1/ingresses::default/ing toast nginx",
+				"routes::fred.com/api toast",
+				"v1/services::default/svc toast port 8080 not exposed",
+			},
+		},
+		"no-pods": {
+			port: 80,
+			rows: func() map[string][]runtime.Object {
+				rr := netRows(t, true, false)
+				delete(rr, "v1/pods")
+				return rr
+			}(),
+			nodes: []string{
+				"v1/namespaces::-/default ok",
+				"networking.k8s.io/v1/ingresses::default/ing toast nginx",
+				"routes::fred.com/api toast",
+				"v1/services::default/svc toast selector matches no pods",
+			},
+		},
+		"unready": {
+			port: 80,
+			rows: netRows(t, false, false),
+			nodes: []string{
+				"v1/namespaces::-/default ok",
+				"networking.k8s.io/v1/ingresses::default/ing toast nginx",
+				"routes::fred.com/api toast",
+				"v1/services::default/svc toast 80→http",
+				"discovery.k8s.io/v1/endpointslices::default/svc-1 toast 0/1 ready",
+				"v1/pods::default/web-1 toast not ready",
+			},
+		},
+		"denied": {
+			port: 80,
+			rows: netRows(t, true, true),
+			nodes: []string{
+				"v1/namespaces::-/default ok",
+				"networking.k8s.io/v1/ingresses::default/ing toast nginx",
+				"routes::fred.com/api toast",
+				"v1/services::default/svc toast 80→http",
+				"discovery.k8s.io/v1/endpointslices::default/svc-1 toast 1/1 ready",
+				"v1/pods::default/web-1 toast ingress from ingress-nginx denied",
+				"networking.k8s.io/v1/networkpolicies::default/deny toast no rule for ingress-nginx",
+			},
+		},
+		"named-port": {
+			port: 80,
+			rows: namedPortRows(t),
+			nodes: []string{
+				"v1/namespaces::-/default ok",
+				"networking.k8s.io/v1/ingresses::default/ing ok nginx",
+				"routes::fred.com/api ok",
+				"v1/services::default/svc ok 80→8080",
+				"discovery.k8s.io/v1/endpointslices::default/svc-1 ok 1/1 ready",
+				"v1/pods::default/web-1 ok",
+				"networking.k8s.io/v1/networkpolicies::default/allow ok allows ingress-nginx",
+			},
+		},
+	}
+
+	var re xray.Ingress
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			f := makeFactory()
+			f.rows = u.rows
+			root := xray.NewTreeNode("ingresses", "ingresses")
+			ctx := context.WithValue(context.Background(), xray.KeyParent, root)
+			ctx = context.WithValue(ctx, internal.KeyFactory, f)
+			ctx = context.WithValue(ctx, xray.KeyControllerNS, "ingress-nginx")
+
+			assert.Nil(t, re.Render(ctx, "", toUnstructured(t, makeIngress(u.port))))
+			assert.Equal(t, u.nodes, dumpNodes(root))
+		})
+	}
+}
+
+func TestGatewayRender(t *testing.T) {
+	f := makeFactory()
+	f.rows = netRows(t, true, false)
+	f.rows["gateway.networking.k8s.io/v1/httproutes"] = []runtime.Object{
+		&unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "gateway.networking.k8s.io/v1",
+			"kind":       "HTTPRoute",
+			"metadata":   map[string]interface{}{"name": "route", "namespace": "default"},
+			"spec": map[string]interface{}{
+				"parentRefs": []interface{}{map[string]interface{}{"name": "gw", "namespace": "infra"}},
+				"hostnames":  []interface{}{"fred.com"},
+				"rules": []interface{}{map[string]interface{}{
+					"matches":     []interface{}{map[string]interface{}{"path": map[string]interface{}{"value": "/api"}}},
+					"backendRefs": []interface{}{map[string]interface{}{"name": "svc", "port": int64(80)}},
+				}},
+			},
+		}},
+		&unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "gateway.networking.k8s.io/v1",
+			"kind":       "HTTPRoute",
+			"metadata":   map[string]interface{}{"name": "other", "namespace": "default"},
+			"spec": map[string]interface{}{
+				"parentRefs": []interface{}{map[string]interface{}{"name": "gw"}},
+			},
+		}},
+	}
+	gw := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "gateway.networking.k8s.io/v1",
+		"kind":       "Gateway",
+		"metadata":   map[string]interface{}{"name": "gw", "namespace": "infra"},
+		"spec":       map[string]interface{}{"gatewayClassName": "envoy"},
+	}}
+
+	root := xray.NewTreeNode("gateways", "gateways")
+	ctx := context.WithValue(context.Background(), xray.KeyParent, root)
+	ctx = context.WithValue(ctx, internal.KeyFactory, f)
+
+	var re xray.Gateway
+	assert.Nil(t, re.Render(ctx, "", &gw))
+	assert.Equal(t, []string{
+		"v1/namespaces::-/infra ok",
+		"gateway.networking.k8s.io/v1/gateways::infra/gw ok envoy",
+		"gateway.networking.k8s.io/v1/httproutes::default/route ok",
+		"routes::fred.com/api ok",
+		"v1/services::default/svc ok 80→http",
+		"discovery.k8s.io/v1/endpointslices::default/svc-1 ok 1/1 ready",
+		"v1/pods::default/web-1 ok",
+	}, dumpNodes(root))
+}
+
+// Helpers...
+
+func dumpNodes(root *xray.TreeNode) []string {
+	var (
+		ss   []string
+		walk func(n *xray.TreeNode)
+	)
+	walk = func(n *xray.TreeNode) {
+		for _, c := range n.Children {
+			s := c.GVR + xray.PathSeparator + c.ID
+			if st, ok := c.Extras[xray.StatusKey]; ok {
+				s += " " + st
+			} else {
+				s += " " + xray.OkStatus
+			}
+			if info := c.Extras[xray.InfoKey]; info != "" {
+				s += " " + info
+			}
+			ss = append(ss, s)
+			walk(c)
+		}
+	}
+	walk(root)
+
+	return ss
+}
+
+func netRows(t *testing.T, ready, deny bool) map[string][]runtime.Object {
+	var (
+		name = "http"
+		port = int32(8080)
+		kind = "Pod"
+	)
+	rows := map[string][]runtime.Object{
+		"v1/services": {toUnstructured(t, &v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "default"},
+			Spec: v1.ServiceSpec{
+				Selector: map[string]string{"app": "web"},
+				Ports:    []v1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromString("http")}},
+			},
+		})},
+		"v1/pods": {toUnstructured(t, &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"}},
+		})},
+		"discovery.k8s.io/v1/endpointslices": {toUnstructured(t, &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{Name: "svc-1", Namespace: "default"},
+			Ports:      []discoveryv1.EndpointPort{{Name: &name, Port: &port}},
+			Endpoints: []discoveryv1.Endpoint{{
+				Conditions: discoveryv1.EndpointConditions{Ready: &ready},
+				TargetRef:  &v1.ObjectReference{Kind: kind, Namespace: "default", Name: "web-1"},
+			}},
+		})},
+	}
+	if deny {
+		rows["networking.k8s.io/v1/networkpolicies"] = []runtime.Object{toUnstructured(t, &netv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "deny", Namespace: "default"},
+		})}
+	}
+
+	return rows
+}
+
+// namedPortRows returns a service with an unnamed numeric target port and a
+// policy allowing the pod named port.
+func namedPortRows(t *testing.T) map[string][]runtime.Object {
+	var (
+		port  = int32(8080)
+		ready = true
+		named = intstr.FromString("http")
+	)
+	rows := netRows(t, true, false)
+	rows["v1/services"] = []runtime.Object{toUnstructured(t, &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "default"},
+		Spec: v1.ServiceSpec{
+			Selector: map[string]string{"app": "web"},
+			Ports:    []v1.ServicePort{{Port: 80, TargetPort: intstr.FromInt(8080)}},
+		},
+	})}
+	rows["v1/pods"] = []runtime.Object{toUnstructured(t, &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"}},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "web", Ports: []v1.ContainerPort{{Name: "http", ContainerPort: 8080}}}},
+		},
+	})}
+	rows["discovery.k8s.io/v1/endpointslices"] = []runtime.Object{toUnstructured(t, &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{Name: "svc-1", Namespace: "default"},
+		Ports:      []discoveryv1.EndpointPort{{Port: &port}},
+		Endpoints: []discoveryv1.Endpoint{{
+			Conditions: discoveryv1.EndpointConditions{Ready: &ready},
+			TargetRef:  &v1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "web-1"},
+		}},
+	})}
+	rows["networking.k8s.io/v1/networkpolicies"] = []runtime.Object{toUnstructured(t, &netv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "allow", Namespace: "default"},
+		Spec: netv1.NetworkPolicySpec{
+			PolicyTypes: []netv1.PolicyType{netv1.PolicyTypeIngress},
+			Ingress: []netv1.NetworkPolicyIngressRule{{
+				From: []netv1.NetworkPolicyPeer{{
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"kubernetes.io/metadata.name": "ingress-nginx"},
+					},
+				}},
+				Ports: []netv1.NetworkPolicyPort{{Port: &named}},
+			}},
+		},
+	})}
+
+	return rows
+}
+
+func makeIngress(port int32) *netv1.Ingress {
+	class := "nginx"
+	return &netv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "ing", Namespace: "default"},
+		Spec: netv1.IngressSpec{
+			IngressClassName: &class,
+			Rules: []netv1.IngressRule{{
+				Host: "fred.com",
+				IngressRuleValue: netv1.IngressRuleValue{HTTP: &netv1.HTTPIngressRuleValue{
+					Paths: []netv1.HTTPIngressPath{{
+						Path: "/api",
+						Backend: netv1.IngressBackend{Service: &netv1.IngressServiceBackend{
+							Name: "svc",
+							Port: netv1.ServiceBackendPort{Number: port},
+						}},
+					}},
+				}},
+			}},
+		},
+	}
+}
+
+func toUnstructured(t *testing.T, o interface{}) *unstructured.Unstructured {
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(o)
+	assert.Nil(t, err)
+
+	return &unstructured.Unstructured{Object: m}
+}
@@ -0,0 +1,300 @@
+package xray
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/client"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/dao"
+	"github.com/derailed/k9s/internal/render"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const maxRelationDepth = 10
+
+// Renderer represents a resource tree renderer.
+type Renderer interface {
+	// Render renders a resource xray node.
+	Render(ctx context.Context, ns string, o interface{}) error
+}
+
+// RendererFunc returns the built-in tree renderer for a given resource if any.
+type RendererFunc func(gvr string) (Renderer, bool)
+
+// Relation renders a resource tree using user defined relationship rules.
+// Related resources without rules are expanded using the built-in renderers.
+type Relation struct {
+	gvr      string
+	rules    *config.XrayRules
+	builtins RendererFunc
+}
+
+// NewRelation returns a new renderer.
+func NewRelation(gvr string, rules *config.XrayRules, builtins RendererFunc) *Relation {
+	return &Relation{gvr: gvr, rules: rules, builtins: builtins}
+}
+
+// Render renders an xray node.
+func (r *Relation) Render(ctx context.Context, ns string, o interface{}) error {
+	raw, ok := o.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("expecting *Unstructured but got %T", o)
+	}
+	parent, ok := ctx.Value(KeyParent).(*TreeNode)
+	if !ok {
+		return fmt.Errorf("expecting a TreeNode but got %T", ctx.Value(KeyParent))
+	}
+
+	root, err := r.hydrate(ctx, r.gvr, raw, make(map[string]struct{}), 0)
+	if err != nil {
+		return err
+	}
+	if raw.GetNamespace() == "" {
+		parent.Add(root)
+		return nil
+	}
+	addToNamespace(parent, root, raw.GetNamespace())
+
+	return nil
+}
+
+func (r *Relation) hydrate(ctx context.Context, gvr string, o *unstructured.Unstructured, seen map[string]struct{}, depth int) (*TreeNode, error) {
+	root := NewTreeNode(gvr, client.FQN(o.GetNamespace(), o.GetName()))
+	root.Extras[StatusKey] = relationStatus(o)
+
+	key := gvr + PathSeparator + root.ID
+	if _, ok := seen[key]; ok || depth >= maxRelationDepth {
+		return root, nil
+	}
+	seen[key] = struct{}{}
+	defer delete(seen, key)
+
+	for _, rel := range r.rules.RelationsFor(gvr) {
+		oo, err := r.related(ctx, o, rel)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range oo {
+			nn, err := r.delegate(ctx, rel.GVR, c)
+			if err != nil {
+				return nil, err
+			}
+			if len(nn) == 0 {
+				n, err := r.hydrate(ctx, rel.GVR, c, seen, depth+1)
+				if err != nil {
+					return nil, err
+				}
+				nn = append(nn, n)
+			}
+			for _, n := range nn {
+				root.Add(n)
+			}
+		}
+	}
+
+	return root, nil
+}
+
+// delegate renders a related resource without rules using its built-in renderer.
+func (r *Relation) delegate(ctx context.Context, gvr string, o *unstructured.Unstructured) ([]*TreeNode, error) {
+	if r.builtins == nil || r.rules.HasRules(gvr) {
+		return nil, nil
+	}
+	re, ok := r.builtins(gvr)
+	if !ok {
+		return nil, nil
+	}
+	if _, ok := re.(*Generic); ok {
+		return nil, nil
+	}
+
+	var res interface{} = o
+	if gvr == "v1/pods" {
+		res = &render.PodWithMetrics{Raw: o}
+	}
+	scratch := NewTreeNode(gvr, gvr)
+	if err := re.Render(context.WithValue(ctx, KeyParent, scratch), o.GetNamespace(), res); err != nil {
+		return nil, err
+	}
+	nn := make([]*TreeNode, 0, len(scratch.Children))
+	for _, c := range scratch.Children {
+		if c.GVR == "v1/namespaces" {
+			nn = append(nn, c.Children...)
+			continue
+		}
+		nn = append(nn, c)
+	}
+
+	return nn, nil
+}
+
+func (r *Relation) related(ctx context.Context, o *unstructured.Unstructured, rel config.XrayRelation) ([]*unstructured.Unstructured, error) {
+	f, ok := ctx.Value(internal.KeyFactory).(dao.Factory)
+	if !ok {
+		return nil, fmt.Errorf("expecting a factory but got %T", ctx.Value(internal.KeyFactory))
+	}
+
+	ns := o.GetNamespace()
+	if clusterScoped(rel.GVR) {
+		ns = client.ClusterScope
+	}
+	sel := labels.Everything()
+	if rel.Selector != "" {
+		var err error
+		if sel, err = relationSelector(rel.Selector, o.Object); err != nil {
+			return nil, err
+		}
+		if sel == nil {
+			return nil, nil
+		}
+	}
+	oo, err := f.List(rel.GVR, ns, true, sel)
+	if err != nil {
+		return nil, err
+	}
+
+	var match func(*unstructured.Unstructured) (bool, error)
+	switch {
+	case rel.Owned:
+		match = func(u *unstructured.Unstructured) (bool, error) {
+			for _, ref := range u.GetOwnerReferences() {
+				if ref.UID == o.GetUID() {
+					return true, nil
+				}
+			}
+			return false, nil
+		}
+	case rel.JSONPath != "":
+		refNS := o.GetNamespace()
+		if clusterScoped(rel.GVR) {
+			refNS = ""
+		}
+		names, err := relationNames(rel.JSONPath, o, refNS)
+		if err != nil {
+			return nil, err
+		}
+		match = func(u *unstructured.Unstructured) (bool, error) {
+			_, ok := names[client.FQN(u.GetNamespace(), u.GetName())]
+			return ok, nil
+		}
+	case rel.RefJSONPath != "":
+		fqn := client.FQN(o.GetNamespace(), o.GetName())
+		match = func(u *unstructured.Unstructured) (bool, error) {
+			refNS := u.GetNamespace()
+			if o.GetNamespace() == "" {
+				refNS = ""
+			}
+			names, err := relationNames(rel.RefJSONPath, u, refNS)
+			if err != nil {
+				return false, err
+			}
+			_, ok := names[fqn]
+			return ok, nil
+		}
+	default:
+		match = func(*unstructured.Unstructured) (bool, error) { return true, nil }
+	}
+
+	uu := make([]*unstructured.Unstructured, 0, len(oo))
+	for _, c := range oo {
+		u, ok := c.(*unstructured.Unstructured)
+		if !ok {
+			return nil, fmt.Errorf("expecting *Unstructured but got %T", c)
+		}
+		ok, err := match(u)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			uu = append(uu, u)
+		}
+	}
+
+	return uu, nil
+}
+
+// relationNames returns the resource fully qualified names referenced by a
+// JSONPath. Unqualified names are deemed to live in the given namespace or
+// to be cluster scoped when no namespace is given.
+func relationNames(expr string, o *unstructured.Unstructured, ns string) (map[string]struct{}, error) {
+	vv, err := dao.JSONPathValues(expr, o.Object)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]struct{}, len(vv))
+	for _, v := range vv {
+		for _, n := range strings.Fields(v) {
+			if ns != "" && !strings.Contains(n, "/") {
+				n = client.FQN(ns, n)
+			}
+			names[n] = struct{}{}
+		}
+	}
+
+	return names, nil
+}
+
+// relationSelector returns the label selector located by a JSONPath.
+// Either a LabelSelector or a plain labels map is supported. Returns nil
+// when no selector is found.
+func relationSelector(expr string, o map[string]interface{}) (labels.Selector, error) {
+	vv, err := dao.JSONPathFind(expr, o)
+	if err != nil || len(vv) == 0 {
+		return nil, err
+	}
+	m, ok := vv[0].(map[string]interface{})
+	if !ok || len(m) == 0 {
+		return nil, nil
+	}
+
+	if _, ok := m["matchLabels"]; ok {
+		return labelSelector(m)
+	}
+	if _, ok := m["matchExpressions"]; ok {
+		return labelSelector(m)
+	}
+	set := make(labels.Set, len(m))
+	for k, v := range m {
+		set[k] = fmt.Sprintf("%v", v)
+	}
+
+	return set.AsSelector(), nil
+}
+
+func labelSelector(m map[string]interface{}) (labels.Selector, error) {
+	var sel metav1.LabelSelector
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m, &sel); err != nil {
+		return nil, err
+	}
+
+	return metav1.LabelSelectorAsSelector(&sel)
+}
+
+// clusterScoped checks if a resource is known to be cluster scoped.
+func clusterScoped(gvr string) bool {
+	meta, err := dao.MetaAccess.MetaFor(client.NewGVR(gvr))
+
+	return err == nil && !meta.Namespaced
+}
+
+// relationStatus flags resources reporting a false Ready condition.
+func relationStatus(o *unstructured.Unstructured) string {
+	cc, _, _ := unstructured.NestedSlice(o.Object, "status", "conditions")
+	for _, c := range cc {
+		m, ok := c.(map[string]interface{})
+		if !ok || m["type"] != "Ready" {
+			continue
+		}
+		if fmt.Sprintf("%v", m["status"]) == "False" {
+			return ToastStatus
+		}
+	}
+
+	return OkStatus
+}
@@ -0,0 +1,219 @@
+package xray_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/derailed/k9s/internal"
+	"github.com/derailed/k9s/internal/config"
+	"github.com/derailed/k9s/internal/xray"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestRelationRender(t *testing.T) {
+	uu := map[string]struct {
+		gvr      string
+		o        *unstructured.Unstructured
+		rules    []config.XrayRule
+		builtins xray.RendererFunc
+		e        []string
+	}{
+		"leaf": {
+			gvr: "cert-manager.io/v1/certificates",
+			o:   makeUnstructured("cert", nil, map[string]interface{}{"secretName": "cert-tls"}, false),
+			e: []string{
+				"v1/namespaces::-/default",
+				"cert-manager.io/v1/certificates::default/cert",
+			},
+		},
+		"json-path": {
+			gvr: "cert-manager.io/v1/certificates",
+			o:   makeUnstructured("cert", nil, map[string]interface{}{"secretName": "cert-tls"}, false),
+			rules: []config.XrayRule{
+				{GVR: "cert-manager.io/v1/certificates", Relations: []config.XrayRelation{{GVR: "v1/secrets", JSONPath: "{.spec.secretName}"}}},
+				{GVR: "v1/secrets", Relations: []config.XrayRelation{{GVR: "networking.k8s.io/v1/ingresses", RefJSONPath: "{.spec.tls[*].secretName}"}}},
+			},
+			e: []string{
+				"v1/namespaces::-/default",
+				"cert-manager.io/v1/certificates::default/cert",
+				"v1/secrets::default/cert-tls",
+				"networking.k8s.io/v1/ingresses::default/ing1",
+			},
+		},
+		"owned": {
+			gvr: "example.com/v1/apps",
+			o:   makeUnstructured("app", nil, map[string]interface{}{"selector": map[string]interface{}{"app": "fred"}}, true),
+			rules: []config.XrayRule{
+				{GVR: "example.com/v1/apps", Relations: []config.XrayRelation{
+					{GVR: "apps/v1/deployments", Owned: true},
+					{GVR: "v1/services", Selector: "{.spec.selector}"},
+				}},
+			},
+			e: []string{
+				"v1/namespaces::-/default",
+				"example.com/v1/apps::default/app",
+				"apps/v1/deployments::default/dp1",
+				"v1/services::default/svc1",
+			},
+		},
+		"builtins": {
+			gvr: "example.com/v1/apps",
+			o:   makeUnstructured("app", nil, nil, true),
+			rules: []config.XrayRule{
+				{GVR: "example.com/v1/apps", Relations: []config.XrayRelation{
+					{GVR: "apps/v1/deployments", Owned: true},
+					{GVR: "v1/services"},
+				}},
+			},
+			builtins: func(gvr string) (xray.Renderer, bool) {
+				if gvr != "apps/v1/deployments" {
+					return nil, false
+				}
+				return stubRenderer{}, true
+			},
+			e: []string{
+				"v1/namespaces::-/default",
+				"example.com/v1/apps::default/app",
+				"apps/v1/deployments::default/dp1",
+				"apps/v1/replicasets::default/dp1-rs",
+				"v1/services::default/svc1",
+			},
+		},
+		"cluster-ref": {
+			gvr: "example.com/v1/clusterapps",
+			o:   makeClusterUnstructured("app"),
+			rules: []config.XrayRule{
+				{GVR: "example.com/v1/clusterapps", Relations: []config.XrayRelation{{GVR: "v1/configmaps", RefJSONPath: "{.spec.app}"}}},
+			},
+			e: []string{
+				"example.com/v1/clusterapps::app",
+				"v1/configmaps::default/cm1",
+			},
+		},
+		"no-selector": {
+			gvr: "example.com/v1/apps",
+			o:   makeUnstructured("app", nil, nil, false),
+			rules: []config.XrayRule{
+				{GVR: "example.com/v1/apps", Relations: []config.XrayRelation{{GVR: "v1/services", Selector: "{.spec.selector}"}}},
+			},
+			e: []string{
+				"v1/namespaces::-/default",
+				"example.com/v1/apps::default/app",
+			},
+		},
+		"cycle": {
+			gvr: "v1/secrets",
+			o:   makeUnstructured("cert-tls", nil, nil, false),
+			rules: []config.XrayRule{
+				{GVR: "v1/secrets", Relations: []config.XrayRelation{{GVR: "v1/secrets", JSONPath: "{.metadata.name}"}}},
+			},
+			e: []string{
+				"v1/namespaces::-/default",
+				"v1/secrets::default/cert-tls",
+				"v1/secrets::default/cert-tls",
+			},
+		},
+	}
+
+	f := makeFactory()
+	f.rows = map[string][]runtime.Object{
+		"v1/secrets": {makeUnstructured("cert-tls", nil, nil, false)},
+		"networking.k8s.io/v1/ingresses": {
+			makeUnstructured("ing1", nil, map[string]interface{}{"tls": []interface{}{map[string]interface{}{"secretName": "cert-tls"}}}, false),
+			makeUnstructured("ing2", nil, map[string]interface{}{"tls": []interface{}{map[string]interface{}{"secretName": "blee"}}}, false),
+		},
+		"apps/v1/deployments": {
+			makeUnstructured("dp1", []interface{}{map[string]interface{}{"uid": "app-uid", "name": "app"}}, nil, false),
+			makeUnstructured("dp2", []interface{}{map[string]interface{}{"uid": "blee", "name": "blee"}}, nil, false),
+		},
+		"v1/services": {makeUnstructured("svc1", nil, nil, false)},
+		"v1/configmaps": {
+			makeUnstructured("cm1", nil, map[string]interface{}{"app": "app"}, false),
+			makeUnstructured("cm2", nil, map[string]interface{}{"app": "blee"}, false),
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			root := xray.NewTreeNode("root", "root")
+			ctx := context.WithValue(context.Background(), xray.KeyParent, root)
+			ctx = context.WithValue(ctx, internal.KeyFactory, f)
+
+			re := xray.NewRelation(u.gvr, &config.XrayRules{Rules: u.rules}, u.builtins)
+			assert.Nil(t, re.Render(ctx, "default", u.o))
+
+			ids := make([]string, 0, len(u.e))
+			var walk func(n *xray.TreeNode)
+			walk = func(n *xray.TreeNode) {
+				for _, c := range n.Children {
+					ids = append(ids, c.GVR+xray.PathSeparator+c.ID)
+					walk(c)
+				}
+			}
+			walk(root)
+			assert.Equal(t, u.e, ids)
+		})
+	}
+}
+
+func TestRelationStatus(t *testing.T) {
+	o := makeUnstructured("cert", nil, nil, false)
+	o.Object["status"] = map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Ready", "status": "False"},
+		},
+	}
+	root := xray.NewTreeNode("root", "root")
+	ctx := context.WithValue(context.Background(), xray.KeyParent, root)
+	ctx = context.WithValue(ctx, internal.KeyFactory, makeFactory())
+
+	re := xray.NewRelation("cert-manager.io/v1/certificates", config.NewXrayRules(), nil)
+	assert.Nil(t, re.Render(ctx, "default", o))
+	assert.Equal(t, xray.ToastStatus, root.Children[0].Children[0].Extras[xray.StatusKey])
+}
+
+// Helpers...
+
+func makeUnstructured(n string, owners []interface{}, spec map[string]interface{}, uid bool) *unstructured.Unstructured {
+	m := map[string]interface{}{
+		"name":      n,
+		"namespace": "default",
+	}
+	if uid {
+		m["uid"] = n + "-uid"
+	}
+	if owners != nil {
+		m["ownerReferences"] = owners
+	}
+	o := map[string]interface{}{"metadata": m}
+	if spec != nil {
+		o["spec"] = spec
+	}
+
+	return &unstructured.Unstructured{Object: o}
+}
+
+func makeClusterUnstructured(n string) *unstructured.Unstructured {
+	o := makeUnstructured(n, nil, nil, false)
+	o.SetNamespace("")
+
+	return o
+}
+
+type stubRenderer struct{}
+
+func (stubRenderer) Render(ctx context.Context, ns string, o interface{}) error {
+	u := o.(*unstructured.Unstructured)
+	parent := ctx.Value(xray.KeyParent).(*xray.TreeNode)
+	fqn := u.GetNamespace() + "/" + u.GetName()
+	n := xray.NewTreeNode("apps/v1/deployments", fqn)
+	n.Add(xray.NewTreeNode("apps/v1/replicasets", fqn+"-rs"))
+	nsn := xray.NewTreeNode("v1/namespaces", "-/"+ns)
+	nsn.Add(n)
+	parent.Add(nsn)
+
+	return nil
+}
@@ -19,6 +19,9 @@ const (
 	// KeySAAutomount indicates whether an automount sa token is active or not.
 	KeySAAutomount TreeRef = "automount"
 
+	// KeyControllerNS indicates the ingress controller namespace.
+	KeyControllerNS TreeRef = "controllerNS"
+
 	// PathSeparator represents a node path separator.
 	PathSeparator = "::"
 
@@ -398,7 +401,7 @@ const (
 )
 
 func (t TreeNode) toTitle() (title string) {
-	_, n := client.Namespaced(t.ID)
+	n := t.name()
 	color, status := "white", "OK"
 	if v, ok := t.Extras[StatusKey]; ok {
 		switch v {
@@ -437,7 +440,7 @@ func (t TreeNode) toTitle() (title string) {
 const colorFmt = "%s [%s::b]%s[::]"
 
 func (t TreeNode) toEmojiTitle() (title string) {
-	_, n := client.Namespaced(t.ID)
+	n := t.name()
 	color, status := "white", "OK"
 	if v, ok := t.Extras[StatusKey]; ok {
 		switch v {
@@ -467,6 +470,16 @@ func (t TreeNode) toEmojiTitle() (title string) {
 	return
 }
 
+// name returns the node display name. Routes are shown as host and path.
+func (t TreeNode) name() string {
+	if t.GVR == routeGVR {
+		return t.ID
+	}
+	_, n := client.Namespaced(t.ID)
+
+	return n
+}
+
 func toEmoji(gvr string) string {
 	if e := v1Emoji(gvr); e != "" {
 		return e
@@ -486,6 +499,12 @@ func toEmoji(gvr string) string {
 		return "👨🏻‍"
 	case "networking.k8s.io/v1/networkpolicies":
 		return "📕"
+	case "networking.k8s.io/v1/ingresses":
+		return "🌐"
+	case "discovery.k8s.io/v1/endpointslices":
+		return "🔌"
+	case routeGVR:
+		return "🛣 "
 	case "policy/v1beta1/poddisruptionbudgets":
 		return "🏷 "
 	case "policy/v1beta1/podsecuritypolicies":